@@ -161,8 +161,8 @@ func randRange(m, n int) int {
 	return r.Intn(n-m) + m
 }
 
-func IsDistinct[T comparable](a []T, comp order.CompareFn[T]) bool {
-	contract.Require(IsSorted(a, comp), "a is sorted")
+func IsDistinct[T any](a []T, comp order.CompareFn[T]) bool {
+	contract.RequireInvariant(func() bool { return IsSorted(a, comp) }, "a is sorted")
 
 	if len(a) <= 1 {
 		return true
@@ -187,9 +187,9 @@ func RangeContains[T comparable](x T, a []T, low, high int) bool {
 	return false
 }
 
-type ExtractFn[E comparable, K comparable] func(e E) (key K)
+type ExtractFn[E any, K comparable] func(e E) (key K)
 
-func Contains[E comparable, K comparable](a []E, key K, extract ExtractFn[E, K]) bool {
+func Contains[E any, K comparable](a []E, key K, extract ExtractFn[E, K]) bool {
 	for _, x := range a {
 		if extract(x) == key {
 			return true
@@ -199,9 +199,9 @@ func Contains[E comparable, K comparable](a []E, key K, extract ExtractFn[E, K])
 	return false
 }
 
-type FilterFn[T comparable] func(x T) bool
+type FilterFn[T any] func(x T) bool
 
-func Filter[T comparable](a []T, filter FilterFn[T]) []T {
+func Filter[T any](a []T, filter FilterFn[T]) []T {
 	var b []T
 	for _, x := range a {
 		if filter(x) {
@@ -229,12 +229,12 @@ func IsIn[T comparable](x T, a []T, low, high int) bool {
 	return false
 }
 
-func IsSorted[T comparable](a []T, comp order.CompareFn[T]) bool {
+func IsSorted[T any](a []T, comp order.CompareFn[T]) bool {
 	return IsRangeSorted(a, 0, len(a), comp)
 }
 
 // IsRangeSorted specification function
-func IsRangeSorted[T comparable](a []T, low, high int, comp order.CompareFn[T]) bool {
+func IsRangeSorted[T any](a []T, low, high int, comp order.CompareFn[T]) bool {
 	contract.Require(0 <= low && low <= high && high <= len(a), "low and high are within bound")
 
 	loopInv := func(i int) bool {
@@ -1,12 +1,15 @@
 package array
 
 import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
 	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
 func TestCopyArray(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+
 	a := []int{1, 2, 3}
 
 	b := CopyArray(a, 3)
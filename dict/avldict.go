@@ -2,20 +2,23 @@ package dict
 
 import (
 	"github.com/song-flying/GoDataStructures/array"
+	"github.com/song-flying/GoDataStructures/linked"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
 	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/song-flying/GoDataStructures/tree"
 )
 
-type AVLDict[K comparable, V comparable] struct {
+type AVLDict[K comparable, V any] struct {
 	tree      *tree.BinaryTree[entry[K, V]]
 	keyComp   order.CompareFn[K]
+	ordering  order.Ordering[K]
 	entryComp order.CompareFn[entry[K, V]]
-	size      int
+	modCount  int
 }
 
 func (t *AVLDict[K, V]) isOrdered(root *tree.BinaryNode[entry[K, V]], lower, upper *K) bool {
-	contract.Require(root.IsBinaryTree(), "root is binary tree")
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
 	contract.Require(t.keyComp != nil, "comparison function is not nil")
 	contract.Require(lower == nil || upper == nil || t.keyComp(*lower, *upper) < 0, "lower < upper")
 
@@ -31,7 +34,7 @@ func (t *AVLDict[K, V]) isOrdered(root *tree.BinaryNode[entry[K, V]], lower, upp
 
 func (t *AVLDict[K, V]) isOrderedWithMinMax(root *tree.BinaryNode[entry[K, V]]) (minKey, maxKey *K, isOrdered bool) {
 	contract.Require(t.keyComp != nil, "comparison function is not nil")
-	contract.Require(root.IsBinaryTree(), "root is a binary tree")
+	contract.RequireInvariant(root.IsBinaryTree, "root is a binary tree")
 
 	if root == nil {
 		return nil, nil, true
@@ -62,20 +65,52 @@ func (t *AVLDict[K, V]) isOrderedWithMinMax(root *tree.BinaryNode[entry[K, V]])
 	return minKey, maxKey, true
 }
 
+// snapshot checks the AVL invariant and keeps the entries before an update in old,
+// it is given to RequireInvariant so that the entries are only copied when the pre-condition is sampled
+func (t *AVLDict[K, V]) snapshot(old *[]entry[K, V], taken *bool) func() bool {
+	return func() bool {
+		if !t.IsAVLDict() {
+			return false
+		}
+		*old, *taken = t.ToArray(t.tree.Root), true
+		return true
+	}
+}
+
+func (t *AVLDict[K, V]) hasSameEntriesExcept(oldEntries []entry[K, V], key K) bool {
+	filter := func(e entry[K, V]) bool { return t.keyComp(e.Key, key) != 0 }
+	return t.hasSameEntries(array.Filter(oldEntries, filter), array.Filter(t.ToArray(t.tree.Root), filter))
+}
+
 func (t *AVLDict[K, V]) hasSameEntries(a1, a2 []entry[K, V]) bool {
 	contract.Require(array.IsSorted(a1, t.entryComp) && array.IsDistinct(a1, t.entryComp), "a1 is sorted & distinct")
 	contract.Require(array.IsSorted(a2, t.entryComp) && array.IsDistinct(a2, t.entryComp), "a2 is sorted & distinct")
 
-	return array.Same(a1, a2)
+	// values need not be comparable, so only keys are compared
+	if len(a1) != len(a2) {
+		return false
+	}
+	for i := range a1 {
+		if t.keyComp(a1[i].Key, a2[i].Key) != 0 {
+			return false
+		}
+	}
+
+	return true
 }
 
 func (t *AVLDict[K, V]) isHeightOKFrom(root *tree.BinaryNode[entry[K, V]]) bool {
-	contract.Require(root.IsBinaryTree(), "root is binary tree")
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
 	return root == nil || t.isHeightOKFrom(root.Left) && t.isHeightOKFrom(root.Right) && root.Height == order.Max(root.Left.GetHeight(), root.Right.GetHeight())+1
 }
 
+func (t *AVLDict[K, V]) isSizeOKFrom(root *tree.BinaryNode[entry[K, V]]) bool {
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
+	return root == nil || t.isSizeOKFrom(root.Left) && t.isSizeOKFrom(root.Right) && root.Size == root.Left.GetSize()+root.Right.GetSize()+1
+}
+
 func (t *AVLDict[K, V]) isBalancedFrom(root *tree.BinaryNode[entry[K, V]]) bool {
-	contract.Require(root.IsBinaryTree(), "root is binary tree")
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
 	return root == nil || t.isBalancedFrom(root.Left) && t.isBalancedFrom(root.Right) && abs(root.Left.GetHeight()-root.Right.GetHeight()) <= 1
 }
 
@@ -87,16 +122,24 @@ func (t *AVLDict[K, V]) IsAVLDict() bool {
 func (t *AVLDict[K, V]) IsAVL(root *tree.BinaryNode[entry[K, V]]) bool {
 	_, _, isOrdered := t.isOrderedWithMinMax(root)
 	return t.keyComp != nil && t.entryComp != nil &&
-		root.IsBinaryTree() && isOrdered && t.isHeightOKFrom(root) && t.isBalancedFrom(root)
+		root.IsBinaryTree() && isOrdered && t.isHeightOKFrom(root) && t.isSizeOKFrom(root) && t.isBalancedFrom(root)
 }
 
-func NewAVLDict[K comparable, V comparable](comp order.CompareFn[K]) (result *AVLDict[K, V]) {
+func NewAVLDict[K comparable, V any](comp order.CompareFn[K]) *AVLDict[K, V] {
 	contract.Require(comp != nil, "comparison function is not nil")
+
+	return NewAVLDictBy[K, V](order.OrderingOf(comp))
+}
+
+// NewAVLDictBy makes a dict with keys ordered by ordering, whose entries merge with those of other dicts made by it
+func NewAVLDictBy[K comparable, V any](ordering order.Ordering[K]) (result *AVLDict[K, V]) {
+	contract.Require(ordering.Compare != nil, "comparison function is not nil")
 	defer func() {
-		contract.Ensure(result.IsAVLDict(), "AVL invariant holds")
+		contract.EnsureInvariant(result.IsAVLDict, "AVL invariant holds")
 	}()
 
 	t := tree.NewBinaryTree(tree.Nil[entry[K, V]]())
+	comp := ordering.Compare
 	entryComp := func(e1, e2 entry[K, V]) int {
 		return comp(e1.Key, e2.Key)
 	}
@@ -104,13 +147,13 @@ func NewAVLDict[K comparable, V comparable](comp order.CompareFn[K]) (result *AV
 	return &AVLDict[K, V]{
 		tree:      t,
 		keyComp:   comp,
+		ordering:  ordering,
 		entryComp: entryComp,
-		size:      0,
 	}
 }
 
 func (t *AVLDict[K, V]) lookup(root *tree.BinaryNode[entry[K, V]], key K) *tree.BinaryNode[entry[K, V]] {
-	contract.Require(t.IsAVL(root), "AVL invariant holds from root")
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds from root")
 
 	if root == nil {
 		return nil
@@ -128,7 +171,7 @@ func (t *AVLDict[K, V]) lookup(root *tree.BinaryNode[entry[K, V]], key K) *tree.
 }
 
 func (t *AVLDict[K, V]) Get(key K) (V, bool) {
-	contract.Require(t.IsAVLDict(), "AVL invariant holds")
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
 
 	node := t.lookup(t.tree.Root, key)
 	if node != nil {
@@ -138,7 +181,7 @@ func (t *AVLDict[K, V]) Get(key K) (V, bool) {
 }
 
 func (t *AVLDict[K, V]) ToArray(root *tree.BinaryNode[entry[K, V]]) (result []entry[K, V]) {
-	contract.Require(t.IsAVL(root), "AVL invariant holds")
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
 	defer func() {
 		contract.Ensure(array.IsSorted(result, t.entryComp), "result entries are sorted")
 	}()
@@ -160,22 +203,16 @@ func (t *AVLDict[K, V]) ToArray(root *tree.BinaryNode[entry[K, V]]) (result []en
 }
 
 func (t *AVLDict[K, V]) insertFrom(root *tree.BinaryNode[entry[K, V]], key K, value V) (result *tree.BinaryNode[entry[K, V]]) {
-	contract.Require(t.IsAVL(root), "AVL invariant holds")
-	defer func(oldEntries []entry[K, V]) {
-		contract.Ensure(t.IsAVL(result), "AVL invariant holds")
-		newEntries := t.ToArray(result)
-		extract := func(e entry[K, V]) K { return e.Key }
-		contract.Ensure(array.Contains(newEntries, key, extract), "new root should contain new entry")
-		filter := func(e entry[K, V]) bool { return e.Key != key }
-		oldEntries = array.Filter(oldEntries, filter)
-		newEntries = array.Filter(newEntries, filter)
-		contract.Ensure(t.hasSameEntries(oldEntries, newEntries), "new root should contain same entries as old root, except for new entry")
-	}(t.ToArray(root))
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(func() bool { return t.IsAVL(result) }, "AVL invariant holds")
+	}()
 
 	if root == nil {
 		node := tree.NewBinaryNode[entry[K, V]](entry[K, V]{Key: key, Value: value})
 		node.Height = 1
-		t.size++
+		node.Size = 1
+		t.modCount++
 		return &node
 	}
 
@@ -195,27 +232,28 @@ func (t *AVLDict[K, V]) insertFrom(root *tree.BinaryNode[entry[K, V]], key K, va
 }
 
 func (t *AVLDict[K, V]) Put(key K, value V) {
-	contract.Require(t.IsAVLDict(), "AVL invariant holds")
+	var oldEntries []entry[K, V]
+	snapshotted := false
+	contract.RequireInvariant(t.snapshot(&oldEntries, &snapshotted), "AVL invariant holds")
 	defer func() {
-		contract.Ensure(t.IsAVLDict(), "AVL invariant holds")
-		v, ok := t.Get(key)
-		contract.Ensure(ok && value == v, "Get(key) returns value")
+		contract.EnsureInvariant(t.IsAVLDict, "AVL invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := t.Get(key)
+			contract.Ensure(ok, "Get(key) finds key")
+		}
+		if snapshotted {
+			contract.Ensure(t.hasSameEntriesExcept(oldEntries, key), "dict contains same entries as before, except for new entry")
+		}
 	}()
 
 	t.tree.Root = t.insertFrom(t.tree.Root, key, value)
 }
 
 func (t *AVLDict[K, V]) removeFrom(root *tree.BinaryNode[entry[K, V]], key K) (result *tree.BinaryNode[entry[K, V]]) {
-	contract.Require(t.IsAVL(root), "AVL invariant holds")
-	defer func(oldEntries []entry[K, V]) {
-		contract.Ensure(t.IsAVL(result), "AVL invariant holds")
-		newEntries := t.ToArray(result)
-		extract := func(e entry[K, V]) K { return e.Key }
-		contract.Ensure(!array.Contains(newEntries, key, extract), "root tree does not contain removed entry")
-		filter := func(e entry[K, V]) bool { return e.Key != key }
-		oldEntries = array.Filter(oldEntries, filter)
-		contract.Ensure(t.hasSameEntries(oldEntries, newEntries), "new root should contain same entries as old root excluding removed entry")
-	}(t.ToArray(root))
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(func() bool { return t.IsAVL(result) }, "AVL invariant holds")
+	}()
 
 	if root == nil {
 		return nil
@@ -231,34 +269,35 @@ func (t *AVLDict[K, V]) removeFrom(root *tree.BinaryNode[entry[K, V]], key K) (r
 		root.Right = t.removeFrom(root.Right, key) // invariant broken (not balanced)
 		root = t.rebalance(root)                   // invariant restored (balanced)
 	default: // compResult == 0
-		switch {
-		case root.Left != nil:
-			root.Left, root.Data = t.removeMax(root.Left) // invariant broken (not balanced)
-			root = t.rebalance(root)                      // invariant restored (balanced)
-		case root.Right != nil:
-			root.Right, root.Data = t.removeMin(root.Right) // invariant broken (not balanced)
-			root = t.rebalance(root)                        // invariant restored (balanced)
-		default:
-			root = nil
-		}
-		t.size--
+		root = t.removeRoot(root)
 	}
 
 	return root
 }
 
+// removeRoot replaces the entry of root by its predecessor or successor
+func (t *AVLDict[K, V]) removeRoot(root *tree.BinaryNode[entry[K, V]]) *tree.BinaryNode[entry[K, V]] {
+	switch {
+	case root.Left != nil:
+		root.Left, root.Data = t.removeMax(root.Left) // invariant broken (not balanced)
+		root = t.rebalance(root)                      // invariant restored (balanced)
+	case root.Right != nil:
+		root.Right, root.Data = t.removeMin(root.Right) // invariant broken (not balanced)
+		root = t.rebalance(root)                        // invariant restored (balanced)
+	default:
+		root = nil
+	}
+	t.modCount++
+
+	return root
+}
+
 func (t *AVLDict[K, V]) removeMax(root *tree.BinaryNode[entry[K, V]]) (result *tree.BinaryNode[entry[K, V]], max entry[K, V]) {
 	contract.Require(root != nil, "root is not nil")
-	contract.Require(t.IsAVL(root), "AVL invariant holds")
-	defer func(oldEntries []entry[K, V]) {
-		contract.Ensure(t.IsAVL(result), "AVL invariant holds")
-		newEntries := t.ToArray(result)
-		extract := func(e entry[K, V]) K { return e.Key }
-		contract.Ensure(!array.Contains(newEntries, max.Key, extract), "root tree does not contain removed entry")
-		filter := func(e entry[K, V]) bool { return e.Key != max.Key }
-		oldEntries = array.Filter(oldEntries, filter)
-		contract.Ensure(t.hasSameEntries(oldEntries, newEntries), "new root should contain same entries as old root excluding removed entry")
-	}(t.ToArray(root))
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(func() bool { return t.IsAVL(result) }, "AVL invariant holds")
+	}()
 
 	if root.Right == nil {
 		return root.Left, root.Data
@@ -272,16 +311,10 @@ func (t *AVLDict[K, V]) removeMax(root *tree.BinaryNode[entry[K, V]]) (result *t
 
 func (t *AVLDict[K, V]) removeMin(root *tree.BinaryNode[entry[K, V]]) (result *tree.BinaryNode[entry[K, V]], min entry[K, V]) {
 	contract.Require(root != nil, "root is not nil")
-	contract.Require(t.IsAVL(root), "AVL invariant holds")
-	defer func(oldEntries []entry[K, V]) {
-		contract.Ensure(t.IsAVL(result), "AVL invariant holds")
-		newEntries := t.ToArray(result)
-		extract := func(e entry[K, V]) K { return e.Key }
-		contract.Ensure(!array.Contains(newEntries, min.Key, extract), "root tree does not contain removed entry")
-		filter := func(e entry[K, V]) bool { return e.Key != min.Key }
-		oldEntries = array.Filter(oldEntries, filter)
-		contract.Ensure(t.hasSameEntries(oldEntries, newEntries), "new root should contain same entries as old root excluding removed entry")
-	}(t.ToArray(root))
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(func() bool { return t.IsAVL(result) }, "AVL invariant holds")
+	}()
 
 	if root.Left == nil {
 		return root.Right, root.Data
@@ -294,31 +327,208 @@ func (t *AVLDict[K, V]) removeMin(root *tree.BinaryNode[entry[K, V]]) (result *t
 }
 
 func (t *AVLDict[K, V]) Delete(key K) {
-	contract.Require(t.IsAVLDict(), "AVL invariant holds")
+	var oldEntries []entry[K, V]
+	snapshotted := false
+	contract.RequireInvariant(t.snapshot(&oldEntries, &snapshotted), "AVL invariant holds")
 	defer func() {
-		contract.Ensure(t.IsAVLDict(), "AVL invariant holds")
-		_, ok := t.Get(key)
-		contract.Ensure(!ok, "Get(key) returns no value")
+		contract.EnsureInvariant(t.IsAVLDict, "AVL invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := t.Get(key)
+			contract.Ensure(!ok, "Get(key) returns no value")
+		}
+		if snapshotted {
+			contract.Ensure(t.hasSameEntriesExcept(oldEntries, key), "dict contains same entries as before, excluding removed entry")
+		}
 	}()
 
 	t.tree.Root = t.removeFrom(t.tree.Root, key)
 }
 
+// computeFrom applies fn on the way down to key, and removes or inserts there rebalancing on the way back up
+func (t *AVLDict[K, V]) computeFrom(root *tree.BinaryNode[entry[K, V]], key K, fn func(V, bool) (V, bool)) (result *tree.BinaryNode[entry[K, V]], value V, present bool) {
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(func() bool { return t.IsAVL(result) }, "AVL invariant holds")
+	}()
+
+	if root == nil {
+		value, keep := fn(*new(V), false)
+		if !keep {
+			return nil, *new(V), false
+		}
+		node := tree.NewBinaryNode[entry[K, V]](entry[K, V]{Key: key, Value: value})
+		node.Height = 1
+		node.Size = 1
+		t.modCount++
+		return &node, value, true
+	}
+
+	compResult := t.keyComp(key, root.Data.Key)
+	switch {
+	case compResult < 0:
+		root.Left, value, present = t.computeFrom(root.Left, key, fn) // invariant broken (not balanced)
+		root = t.rebalance(root)                                      // invariant restored (balanced)
+	case compResult > 0:
+		root.Right, value, present = t.computeFrom(root.Right, key, fn) // invariant broken (not balanced)
+		root = t.rebalance(root)                                        // invariant restored (balanced)
+	default: // compResult == 0
+		var keep bool
+		if value, keep = fn(root.Data.Value, true); keep {
+			root.Data.Value = value
+			return root, value, true
+		}
+		return t.removeRoot(root), *new(V), false
+	}
+
+	return root, value, present
+}
+
+// Compute walks down to key once, and removes or inserts right there
+func (t *AVLDict[K, V]) Compute(key K, fn func(value V, found bool) (newValue V, keep bool)) (result V, present bool) {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsAVLDict, "AVL invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := t.Get(key)
+			contract.Ensure(ok == present, "Get(key) finds key iff it is present")
+		}
+	}()
+
+	t.tree.Root, result, present = t.computeFrom(t.tree.Root, key, fn)
+	return
+}
+
+func (t *AVLDict[K, V]) Clear() {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsAVLDict, "AVL invariant holds")
+		contract.Ensure(t.tree.Root.GetSize() == 0, "dict is empty")
+	}()
+
+	t.tree.Root = nil
+	t.modCount++
+}
+
 func (t *AVLDict[K, V]) Size() (result int) {
-	contract.Require(t.IsAVLDict(), "AVL invariant holds")
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
 	defer func() {
 		contract.Ensure(0 <= result, "result is non-negative")
 	}()
 
-	return t.size
+	return t.tree.Root.GetSize()
+}
+
+// Rank returns the number of keys less than key in O(log n)
+func (t *AVLDict[K, V]) Rank(key K) (result int) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	defer func() {
+		contract.Ensure(0 <= result && result <= t.tree.Root.GetSize(), "0 <= result <= Size()")
+	}()
+
+	for node := t.tree.Root; node != nil; {
+		if t.keyComp(key, node.Data.Key) <= 0 {
+			node = node.Left
+		} else {
+			result += node.Left.GetSize() + 1
+			node = node.Right
+		}
+	}
+
+	return
+}
+
+// Select returns the entry of rank k, i.e. the k-th smallest counting from 0, in O(log n)
+func (t *AVLDict[K, V]) Select(k int) (result Entry[K, V]) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	contract.Require(0 <= k && k < t.tree.Root.GetSize(), "0 <= k < Size()")
+	defer func() {
+		contract.Ensure(t.Rank(result.Key()) == k, "Rank(result) == k")
+	}()
+
+	for node, i := t.tree.Root, k; ; {
+		leftSize := node.Left.GetSize()
+		switch {
+		case i < leftSize:
+			node = node.Left
+		case i > leftSize:
+			i -= leftSize + 1
+			node = node.Right
+		default:
+			return keyValue[K, V]{key: node.Data.Key, value: node.Data.Value}
+		}
+	}
+}
+
+func (t *AVLDict[K, V]) GetOrDefault(key K, def V) V {
+	if value, found := t.Get(key); found {
+		return value
+	}
+
+	return def
+}
+
+func (t *AVLDict[K, V]) PutIfAbsent(key K, value V) (actual V, loaded bool) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+
+	return putIfAbsent[K, V](t, key, value)
+}
+
+func (t *AVLDict[K, V]) ComputeIfAbsent(key K, fn func(K) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+
+	return computeIfAbsent[K, V](t, key, fn)
+}
+
+func (t *AVLDict[K, V]) Merge(key K, value V, fn func(old, value V) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+
+	return merge[K, V](t, key, value, fn)
+}
+
+func (t *AVLDict[K, V]) Replace(key K, value V) (previous V, replaced bool) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+
+	return replace[K, V](t, key, value)
+}
+
+func (t *AVLDict[K, V]) Keys() (result *linked.List[K]) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	defer func() {
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(result.Length() == t.tree.Root.GetSize(), "result has all keys")
+		}
+	}()
+
+	return keysOf(t.All(), order.Equal[K])
+}
+
+func (t *AVLDict[K, V]) Values() (result []V) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == t.tree.Root.GetSize(), "result has all values")
+	}()
+
+	return valuesOf(t.All())
+}
+
+func (t *AVLDict[K, V]) Entries() (result []Entry[K, V]) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == t.tree.Root.GetSize(), "result has all entries")
+	}()
+
+	return entriesOf(t.All())
 }
 
 func (t *AVLDict[K, V]) rebalance(root *tree.BinaryNode[entry[K, V]]) (result *tree.BinaryNode[entry[K, V]]) {
 	contract.Require(root != nil, "root is not nil")
-	contract.Require(t.IsAVL(root.Left), "left child is AVL")
-	contract.Require(t.IsAVL(root.Right), "right child is AVL")
+	contract.RequireInvariant(func() bool { return t.IsAVL(root.Left) }, "left child is AVL")
+	contract.RequireInvariant(func() bool { return t.IsAVL(root.Right) }, "right child is AVL")
 	defer func() {
-		contract.Ensure(t.IsAVL(result), "result is AVL")
+		contract.EnsureInvariant(func() bool { return t.IsAVL(result) }, "result is AVL")
 	}()
 
 	diffLR := root.Left.GetHeight() - root.Right.GetHeight()
@@ -337,8 +547,11 @@ func (t *AVLDict[K, V]) rebalance(root *tree.BinaryNode[entry[K, V]]) (result *t
 			w.Left = u
 			w.Right = v
 			u.SetHeight()
+			u.SetSize()
 			v.SetHeight()
+			v.SetSize()
 			w.SetHeight()
+			w.SetSize()
 			root = w
 		} else {
 			w = v.Right
@@ -346,7 +559,9 @@ func (t *AVLDict[K, V]) rebalance(root *tree.BinaryNode[entry[K, V]]) (result *t
 			u.Right = v.Left
 			v.Left = u
 			u.SetHeight()
+			u.SetSize()
 			v.SetHeight()
+			v.SetSize()
 			root = v
 		}
 
@@ -363,8 +578,11 @@ func (t *AVLDict[K, V]) rebalance(root *tree.BinaryNode[entry[K, V]]) (result *t
 			w.Right = u
 			w.Left = v
 			u.SetHeight()
+			u.SetSize()
 			v.SetHeight()
+			v.SetSize()
 			w.SetHeight()
+			w.SetSize()
 			root = w
 		} else {
 			w = v.Left
@@ -372,13 +590,183 @@ func (t *AVLDict[K, V]) rebalance(root *tree.BinaryNode[entry[K, V]]) (result *t
 			u.Left = v.Right
 			v.Right = u
 			u.SetHeight()
+			u.SetSize()
 			v.SetHeight()
+			v.SetSize()
 			root = v
 		}
 
 	default: // -1 <= diffLR <= 1
 		root.SetHeight()
+		root.SetSize()
 	}
 
 	return root
 }
+
+func (t *AVLDict[K, V]) walk(descending bool, afterStart, beforeEnd func(entry[K, V]) bool) *tree.OrderedIterator[entry[K, V]] {
+	return tree.NewOrderedIterator(t.tree.Root, descending, afterStart, beforeEnd)
+}
+
+func (t *AVLDict[K, V]) compareKeys(a, b K) int {
+	return t.keyComp(a, b)
+}
+
+// Ordering returns the ordering of the keys of t, dicts made by it merge their entries with those of t
+func (t *AVLDict[K, V]) Ordering() order.Ordering[K] {
+	return t.ordering
+}
+
+func (t *AVLDict[K, V]) mods() int {
+	return t.modCount
+}
+
+// Iterator walks the entries in ascending key order
+func (t *AVLDict[K, V]) Iterator() iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+
+	return newTreeDictIterator[K, V](t, false, nil, nil)
+}
+
+// All walks the entries in ascending key order
+func (t *AVLDict[K, V]) All() iterator.Seq2[K, V] {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+
+	entries := t.tree.All()
+	return iterator.FailFastSeq2(func(yield func(K, V) bool) {
+		entries(func(e entry[K, V]) bool {
+			return yield(e.Key, e.Value)
+		})
+	}, t.mods)
+}
+
+// Descending walks the entries in descending key order
+func (t *AVLDict[K, V]) Descending() iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+
+	return newTreeDictIterator[K, V](t, true, nil, nil)
+}
+
+// Range walks the entries with keys within [lo, hi) in ascending key order
+func (t *AVLDict[K, V]) Range(lo, hi K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	contract.Require(t.keyComp(lo, hi) <= 0, "lo <= hi")
+
+	return newTreeDictIterator[K, V](t, false, keyAtLeast[K, V](t.keyComp, lo), keyBelow[K, V](t.keyComp, hi))
+}
+
+// ReverseRange walks the entries with keys within [lo, hi) in descending key order
+func (t *AVLDict[K, V]) ReverseRange(lo, hi K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	contract.Require(t.keyComp(lo, hi) <= 0, "lo <= hi")
+
+	return newTreeDictIterator[K, V](t, true, keyBelow[K, V](t.keyComp, hi), keyAtLeast[K, V](t.keyComp, lo))
+}
+
+// From walks the entries with keys not less than key in ascending key order
+func (t *AVLDict[K, V]) From(key K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+
+	return newTreeDictIterator[K, V](t, false, keyAtLeast[K, V](t.keyComp, key), nil)
+}
+
+// ReverseFrom walks the entries with keys not greater than key in descending key order
+func (t *AVLDict[K, V]) ReverseFrom(key K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+
+	return newTreeDictIterator[K, V](t, true, keyAtMost[K, V](t.keyComp, key), nil)
+}
+
+// Min returns the entry with the least key, ok is false when the dict is empty
+func (t *AVLDict[K, V]) Min() (Entry[K, V], bool) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+
+	return nodeEntry[K, V](minNode[K, V](t.tree.Root))
+}
+
+// Max returns the entry with the greatest key, ok is false when the dict is empty
+func (t *AVLDict[K, V]) Max() (Entry[K, V], bool) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+
+	return nodeEntry[K, V](maxNode[K, V](t.tree.Root))
+}
+
+// Floor returns the entry with the greatest key not greater than key
+func (t *AVLDict[K, V]) Floor(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.keyComp(result.Key(), key) <= 0, "result key <= key")
+	}()
+
+	return nodeEntry[K, V](floorNode[K, V](t.tree.Root, t.keyComp, key, false))
+}
+
+// Ceiling returns the entry with the least key not less than key
+func (t *AVLDict[K, V]) Ceiling(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.keyComp(result.Key(), key) >= 0, "result key >= key")
+	}()
+
+	return nodeEntry[K, V](ceilingNode[K, V](t.tree.Root, t.keyComp, key, false))
+}
+
+// Lower returns the entry with the greatest key less than key
+func (t *AVLDict[K, V]) Lower(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.keyComp(result.Key(), key) < 0, "result key < key")
+	}()
+
+	return nodeEntry[K, V](floorNode[K, V](t.tree.Root, t.keyComp, key, true))
+}
+
+// Higher returns the entry with the least key greater than key
+func (t *AVLDict[K, V]) Higher(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.keyComp(result.Key(), key) > 0, "result key > key")
+	}()
+
+	return nodeEntry[K, V](ceilingNode[K, V](t.tree.Root, t.keyComp, key, true))
+}
+
+// PollFirst removes and returns the entry with the least key
+func (t *AVLDict[K, V]) PollFirst() (result Entry[K, V], ok bool) {
+	if result, ok = t.Min(); ok {
+		t.Delete(result.Key())
+	}
+
+	return
+}
+
+// PollLast removes and returns the entry with the greatest key
+func (t *AVLDict[K, V]) PollLast() (result Entry[K, V], ok bool) {
+	if result, ok = t.Max(); ok {
+		t.Delete(result.Key())
+	}
+
+	return
+}
+
+// HeadMap returns a live view of the entries with keys less than to
+func (t *AVLDict[K, V]) HeadMap(to K) *SubDict[K, V] {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+
+	return newSubDict[K, V](t, nil, &to)
+}
+
+// TailMap returns a live view of the entries with keys not less than from
+func (t *AVLDict[K, V]) TailMap(from K) *SubDict[K, V] {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+
+	return newSubDict[K, V](t, &from, nil)
+}
+
+// SubMap returns a live view of the entries with keys within [from, to)
+func (t *AVLDict[K, V]) SubMap(from, to K) *SubDict[K, V] {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	contract.Require(t.keyComp(from, to) <= 0, "from <= to")
+
+	return newSubDict[K, V](t, &from, &to)
+}
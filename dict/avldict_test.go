@@ -2,7 +2,10 @@ package dict
 
 import (
 	"github.com/song-flying/GoDataStructures/array"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/stretchr/testify/assert"
+	"strconv"
 	"testing"
 )
 
@@ -69,3 +72,118 @@ func TestAVLDict(t *testing.T) {
 		assert.Equal(t, len(a)-i-1, dict.Size())
 	}
 }
+
+func TestAVLDict_Iterator(t *testing.T) {
+	var dict Dict[int, string] = NewAVLDict[int, string](order.IntComp)
+	assert.False(t, dict.Iterator().HasNext())
+
+	keys := []int{1, 2, 3, 4, 5}
+	array.Shuffle(keys)
+	for _, k := range keys {
+		dict.Put(k, strconv.Itoa(k))
+	}
+
+	var fromIterator []int
+	for it := dict.Iterator(); it.HasNext(); {
+		e := it.Next()
+		assert.Equal(t, strconv.Itoa(e.Key()), e.Value())
+		fromIterator = append(fromIterator, e.Key())
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, fromIterator)
+
+	var fromAll []int
+	dict.All()(func(k int, v string) bool {
+		assert.Equal(t, strconv.Itoa(k), v)
+		fromAll = append(fromAll, k)
+		return k < 3
+	})
+	assert.Equal(t, []int{1, 2, 3}, fromAll)
+}
+
+func TestAVLDict_Range(t *testing.T) {
+	dict := NewAVLDict[int, string](order.IntComp)
+	assert.False(t, dict.Descending().HasNext())
+
+	keys := []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}
+	array.Shuffle(keys)
+	for _, k := range keys {
+		dict.Put(k, strconv.Itoa(k))
+	}
+
+	collectKeys := func(it iterator.Iterator[Entry[int, string]]) []int {
+		return iterator.Collect(iterator.Map(it, func(e Entry[int, string]) int {
+			assert.Equal(t, strconv.Itoa(e.Key()), e.Value())
+			return e.Key()
+		}))
+	}
+	assert.Equal(t, []int{18, 16, 14, 12, 10, 8, 6, 4, 2, 0}, collectKeys(dict.Descending()))
+	assert.Equal(t, []int{4, 6, 8}, collectKeys(dict.Range(3, 10)))
+	assert.Equal(t, []int{8, 6, 4}, collectKeys(dict.ReverseRange(3, 10)))
+	assert.Empty(t, collectKeys(dict.Range(4, 4)))
+	assert.Equal(t, []int{14, 16, 18}, collectKeys(dict.From(14)))
+	assert.Equal(t, []int{4, 2, 0}, collectKeys(dict.ReverseFrom(5)))
+	assert.Empty(t, collectKeys(dict.From(19)))
+}
+
+func TestAVLDict_IteratorRemove(t *testing.T) {
+	dict := NewAVLDict[int, string](order.IntComp)
+	keys := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	array.Shuffle(keys)
+	for _, k := range keys {
+		dict.Put(k, strconv.Itoa(k))
+	}
+
+	for it := dict.ReverseFrom(7); it.HasNext(); {
+		if e := it.Next(); e.Key()%2 == 1 {
+			it.Remove()
+		}
+	}
+	var remaining []int
+	dict.All()(func(k int, v string) bool {
+		remaining = append(remaining, k)
+		return true
+	})
+	assert.Equal(t, []int{0, 2, 4, 6, 8, 9}, remaining)
+	assert.Equal(t, 6, dict.Size())
+
+	it := dict.Iterator()
+	dict.Put(1, "1")
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.Next() })
+
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() {
+		dict.All()(func(k int, v string) bool {
+			dict.Delete(k)
+			return true
+		})
+	})
+}
+
+func TestAVLDict_RankSelect(t *testing.T) {
+	dict := NewAVLDict[int, string](order.IntComp)
+	for _, k := range []int{50, 20, 80, 10, 30, 70, 90, 60, 40} {
+		dict.Put(k, strconv.Itoa(k))
+	}
+
+	assert.Equal(t, 0, dict.Rank(10))
+	assert.Equal(t, 3, dict.Rank(35))
+	assert.Equal(t, 9, dict.Rank(91))
+	for k := 0; k < dict.Size(); k++ {
+		e := dict.Select(k)
+		assert.Equal(t, (k+1)*10, e.Key())
+		assert.Equal(t, strconv.Itoa(e.Key()), e.Value())
+	}
+	assert.Panics(t, func() { dict.Select(dict.Size()) })
+
+	dict.Compute(20, func(string, bool) (string, bool) { return "", false })
+	dict.Compute(25, func(string, bool) (string, bool) { return "25", true })
+	assert.Equal(t, 9, dict.Size())
+	assert.Equal(t, 25, dict.Select(1).Key())
+	assert.Equal(t, 2, dict.Rank(30))
+
+	dict.tree.Root.Size--
+	assert.False(t, dict.IsAVLDict())
+}
+
+func BenchmarkAVLDict(b *testing.B) {
+	benchmarkTreeDict(b, func() Dict[int, int] { return NewAVLDict[int, int](order.IntComp) })
+}
@@ -0,0 +1,253 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/song-flying/GoDataStructures/tree"
+)
+
+// join links left, mid and right into an AVL tree in O(|height(left) - height(right)| + 1), reusing mid as the node in between,
+// all keys of left must be less than the key of mid and that less than all keys of right
+func (t *AVLDict[K, V]) join(left, mid, right *tree.BinaryNode[entry[K, V]]) (result *tree.BinaryNode[entry[K, V]]) {
+	contract.Require(mid != nil, "mid is not nil")
+	contract.RequireInvariant(func() bool { return t.IsAVL(left) && t.IsAVL(right) }, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(func() bool { return t.IsAVL(result) }, "AVL invariant holds")
+	}()
+
+	switch {
+	case left.GetHeight() > right.GetHeight()+1:
+		left.Right = t.join(left.Right, mid, right) // invariant broken (not balanced)
+		return t.rebalance(left)                    // invariant restored (balanced)
+	case right.GetHeight() > left.GetHeight()+1:
+		right.Left = t.join(left, mid, right.Left) // invariant broken (not balanced)
+		return t.rebalance(right)                  // invariant restored (balanced)
+	default:
+		mid.Left, mid.Right = left, right
+		mid.SetHeight()
+		mid.SetSize()
+		return mid
+	}
+}
+
+// join2 links left and right, all keys of left less than all of right, with the greatest entry of left in between
+func (t *AVLDict[K, V]) join2(left, right *tree.BinaryNode[entry[K, V]]) *tree.BinaryNode[entry[K, V]] {
+	if left == nil {
+		return right
+	}
+
+	left, max := t.removeMax(left)
+	mid := tree.NewBinaryNode(max)
+	return t.join(left, &mid, right)
+}
+
+// split cuts the tree rooted at root into the entries with keys less than key, the node holding key if any,
+// and the entries with keys greater than key in O(log n)
+func (t *AVLDict[K, V]) split(root *tree.BinaryNode[entry[K, V]], key K) (left, mid, right *tree.BinaryNode[entry[K, V]]) {
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
+
+	if root == nil {
+		return nil, nil, nil
+	}
+
+	compResult := t.keyComp(key, root.Data.Key)
+	switch {
+	case compResult < 0:
+		left, mid, right = t.split(root.Left, key)
+		return left, mid, t.join(right, root, root.Right)
+	case compResult > 0:
+		left, mid, right = t.split(root.Right, key)
+		return t.join(root.Left, root, left), mid, right
+	default:
+		left, right = root.Left, root.Right
+		root.Left, root.Right = nil, nil
+		root.SetHeight()
+		root.SetSize()
+		return left, root, right
+	}
+}
+
+// union links the entries of a and b into one tree in O(m log(n/m + 1)), keeping those of a where both hold a key
+func (t *AVLDict[K, V]) union(a, b *tree.BinaryNode[entry[K, V]]) *tree.BinaryNode[entry[K, V]] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	left, _, right := t.split(b, a.Data.Key)
+	aLeft, aRight := a.Left, a.Right
+	return t.join(t.union(aLeft, left), a, t.union(aRight, right))
+}
+
+// intersect links the entries of a with keys b holds as well into one tree in O(m log(n/m + 1))
+func (t *AVLDict[K, V]) intersect(a, b *tree.BinaryNode[entry[K, V]]) *tree.BinaryNode[entry[K, V]] {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	left, mid, right := t.split(b, a.Data.Key)
+	aLeft, aRight := a.Left, a.Right
+	left, right = t.intersect(aLeft, left), t.intersect(aRight, right)
+	if mid == nil {
+		return t.join2(left, right)
+	}
+	return t.join(left, a, right)
+}
+
+func (t *AVLDict[K, V]) isAscending(keys []K) bool {
+	for i := 1; i < len(keys); i++ {
+		if t.keyComp(keys[i-1], keys[i]) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// NewAVLDictFromSorted builds a dict mapping keys[i] to values[i] in O(n), keys must be strictly ascending
+func NewAVLDictFromSorted[K comparable, V any](comp order.CompareFn[K], keys []K, values []V) (result *AVLDict[K, V]) {
+	contract.Require(len(keys) == len(values), "keys and values have the same length")
+	defer func() {
+		contract.EnsureInvariant(result.IsAVLDict, "AVL invariant holds")
+		contract.Ensure(result.Size() == len(keys), "result holds all keys")
+	}()
+
+	result = NewAVLDict[K, V](comp)
+	contract.Require(result.isAscending(keys), "keys are strictly ascending")
+
+	entries := make([]entry[K, V], len(keys))
+	for i := range keys {
+		entries[i] = entry[K, V]{Key: keys[i], Value: values[i]}
+	}
+	result.tree.Root = tree.NewBalanced(entries)
+	return
+}
+
+// Split moves the entries with keys less than key into left and those greater than key into right in O(log n), leaving t empty,
+// found reports whether t held key, value is the value it had, which ends up in neither
+func (t *AVLDict[K, V]) Split(key K) (left, right *AVLDict[K, V], value V, found bool) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(left.IsAVLDict, "AVL invariant holds")
+		contract.EnsureInvariant(right.IsAVLDict, "AVL invariant holds")
+		contract.Ensure(t.Size() == 0, "t is empty")
+	}()
+
+	left, right = NewAVLDictBy[K, V](t.ordering), NewAVLDictBy[K, V](t.ordering)
+	var mid *tree.BinaryNode[entry[K, V]]
+	left.tree.Root, mid, right.tree.Root = t.split(t.tree.Root, key)
+	t.tree.Root = nil
+	t.modCount++
+
+	if mid == nil {
+		return left, right, value, false
+	}
+	return left, right, mid.Data.Value, true
+}
+
+// Join moves the entries of left and right into t in O(log n), replacing those of t,
+// all keys of left must be less than all keys of right, t may be left or right itself.
+// If left or right is of another ordering than t, see Ordering, their entries are put one by one.
+func (t *AVLDict[K, V]) Join(left, right *AVLDict[K, V]) {
+	contract.Require(left != nil && right != nil, "left and right are not nil")
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	sameOrdering := order.Same(left.ordering, t.ordering) && order.Same(right.ordering, t.ordering)
+	contract.Require(!sameOrdering || left.Size() == 0 || right.Size() == 0 ||
+		t.keyComp(maxNode[K, V](left.tree.Root).Data.Key, minNode[K, V](right.tree.Root).Data.Key) < 0, "left < right")
+	defer func() {
+		contract.EnsureInvariant(t.IsAVLDict, "AVL invariant holds")
+	}()
+
+	leftRoot, rightRoot := left.tree.Root, right.tree.Root
+	left.tree.Root, right.tree.Root = nil, nil
+	left.modCount++
+	right.modCount++
+	t.modCount++
+	if !sameOrdering {
+		t.tree.Root = nil
+		for _, root := range []*tree.BinaryNode[entry[K, V]]{leftRoot, rightRoot} {
+			root.All()(func(e entry[K, V]) bool {
+				t.Put(e.Key, e.Value)
+				return true
+			})
+		}
+		return
+	}
+
+	t.tree.Root = t.join2(leftRoot, rightRoot)
+}
+
+// DeleteRange removes the entries with keys within [lo, hi) in O(log n)
+func (t *AVLDict[K, V]) DeleteRange(lo, hi K) {
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	contract.Require(t.keyComp(lo, hi) <= 0, "lo <= hi")
+	defer func() {
+		contract.EnsureInvariant(t.IsAVLDict, "AVL invariant holds")
+		contract.Ensure(!t.Range(lo, hi).HasNext(), "no key is within [lo, hi)")
+	}()
+
+	rest, mid, right := t.split(t.tree.Root, hi)
+	if mid != nil {
+		right = t.join(nil, mid, right)
+	}
+	left, _, _ := t.split(rest, lo)
+	t.tree.Root = t.join2(left, right)
+	t.modCount++
+}
+
+// PutAll moves the entries of other into t as Put would in O(m log(n/m + 1)), where m is the smaller size,
+// leaving other empty. If other is of another ordering, see Ordering, its entries are put one by one.
+func (t *AVLDict[K, V]) PutAll(other *AVLDict[K, V]) {
+	contract.Require(other != nil && other != t, "other is neither nil nor t")
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsAVLDict, "AVL invariant holds")
+	}()
+
+	otherRoot := other.tree.Root
+	other.tree.Root = nil
+	other.modCount++
+	if !order.Same(other.ordering, t.ordering) {
+		otherRoot.All()(func(e entry[K, V]) bool {
+			t.Put(e.Key, e.Value)
+			return true
+		})
+		return
+	}
+	t.tree.Root = t.union(otherRoot, t.tree.Root)
+	t.modCount++
+}
+
+// RetainAll deletes the entries with keys other does not hold in O(m log(n/m + 1)), where m is the smaller size,
+// splitting up other on the way, which is left empty. If other is of another ordering, see Ordering,
+// the keys of t are looked up in other one by one.
+func (t *AVLDict[K, V]) RetainAll(other *AVLDict[K, V]) {
+	contract.Require(other != nil && other != t, "other is neither nil nor t")
+	contract.RequireInvariant(t.IsAVLDict, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsAVLDict, "AVL invariant holds")
+	}()
+
+	if !order.Same(other.ordering, t.ordering) {
+		var missing []K
+		t.tree.Root.All()(func(e entry[K, V]) bool {
+			if _, ok := other.Get(e.Key); !ok {
+				missing = append(missing, e.Key)
+			}
+			return true
+		})
+		other.tree.Root = nil
+		other.modCount++
+		for _, key := range missing {
+			t.Delete(key)
+		}
+		return
+	}
+
+	otherRoot := other.tree.Root
+	other.tree.Root = nil
+	other.modCount++
+	t.tree.Root = t.intersect(t.tree.Root, otherRoot)
+	t.modCount++
+}
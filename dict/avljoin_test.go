@@ -0,0 +1,155 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/array"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func avlDictOf(keys ...int) *AVLDict[int, string] {
+	return putKeys(NewAVLDict[int, string](order.IntComp), keys...)
+}
+
+func avlDictBy(ordering order.Ordering[int], keys ...int) *AVLDict[int, string] {
+	return putKeys(NewAVLDictBy[int, string](ordering), keys...)
+}
+
+func putKeys(dict *AVLDict[int, string], keys ...int) *AVLDict[int, string] {
+	for _, k := range keys {
+		dict.Put(k, strconv.Itoa(k))
+	}
+	return dict
+}
+
+func TestAVLDict_SplitJoin(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+
+	keys := make([]int, 30)
+	for i := range keys {
+		keys[i] = i
+	}
+	array.Shuffle(keys)
+	dict := avlDictOf(keys...)
+
+	left, right, value, found := dict.Split(10)
+	assert.True(t, found)
+	assert.Equal(t, "10", value)
+	assert.Equal(t, 0, dict.Size())
+	assert.Equal(t, 10, left.Size())
+	assert.Equal(t, 19, right.Size())
+
+	_, _, value, found = avlDictOf(1, 3).Split(2)
+	assert.False(t, found)
+	assert.Equal(t, "", value)
+
+	dict.Join(left, right)
+	assert.True(t, dict.IsAVLDict())
+	assert.Equal(t, 29, dict.Size())
+	assert.Equal(t, 0, right.Size())
+	_, ok := dict.Get(10)
+	assert.False(t, ok)
+	assert.Panics(t, func() { dict.Join(avlDictOf(2), avlDictOf(2)) })
+}
+
+func TestAVLDict_JoinOrderedOtherwise(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPost))
+
+	dict, left := avlDictOf(), avlDictBy(order.NewOrdering(order.Reverse(order.IntComp)), 1, 2, 3)
+	dict.Join(left, avlDictOf(10))
+	assert.True(t, dict.IsAVLDict())
+	assert.Equal(t, []int{1, 2, 3, 10}, dict.Keys().ToArray())
+	_, ok := dict.Get(3)
+	assert.True(t, ok)
+	assert.Equal(t, 0, left.Size(), "left is moved into dict")
+
+	dict.Join(avlDictOf(20), avlDictBy(order.NewOrdering(order.IntComp), 5))
+	assert.Equal(t, []int{5, 20}, dict.Keys().ToArray(), "keys are put one by one, whatever their order")
+}
+
+func TestAVLDict_DeleteRange(t *testing.T) {
+	dict := avlDictOf(1, 2, 3, 4, 5, 6, 7, 8, 9)
+
+	dict.DeleteRange(2, 5)
+	assert.Equal(t, []int{1, 5, 6, 7, 8, 9}, dict.Keys().ToArray())
+	dict.DeleteRange(6, 6)
+	dict.DeleteRange(6, 9)
+	assert.Equal(t, []int{1, 5, 9}, dict.Keys().ToArray())
+	assert.True(t, dict.IsAVLDict())
+}
+
+func TestAVLDict_PutAllRetainAll(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+
+	dict, other := avlDictOf(1, 2, 3, 4), NewAVLDict[int, string](order.IntComp)
+	for _, k := range []int{3, 4, 5, 6} {
+		other.Put(k, "other")
+	}
+
+	dict.PutAll(other)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, dict.Keys().ToArray())
+	assert.Equal(t, []string{"1", "2", "other", "other", "other", "other"}, dict.Values())
+
+	assert.Equal(t, 0, other.Size(), "other is moved into dict")
+
+	dict.Put(5, "5")
+	dict.RetainAll(avlDictOf(0, 2, 5, 7))
+	assert.Equal(t, []int{2, 5}, dict.Keys().ToArray())
+	assert.Equal(t, []string{"2", "5"}, dict.Values())
+	assert.True(t, dict.IsAVLDict())
+	assert.Panics(t, func() { dict.PutAll(dict) })
+}
+
+func TestAVLDict_PutAllRetainAllOrderedOtherwise(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+	dict, other := avlDictOf(1, 2, 3), NewAVLDict[int, string](order.Reverse(order.IntComp))
+	for _, k := range []int{3, 4, 5} {
+		other.Put(k, "other")
+	}
+
+	dict.PutAll(other)
+	assert.True(t, dict.IsAVLDict())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, dict.Keys().ToArray())
+	assert.Equal(t, []string{"1", "2", "other", "other", "other"}, dict.Values())
+	assert.Equal(t, 0, other.Size(), "other is moved into dict")
+
+	for _, k := range []int{0, 2, 4, 6} {
+		other.Put(k, "other")
+	}
+	dict.RetainAll(other)
+	assert.True(t, dict.IsAVLDict())
+	assert.Equal(t, []int{2, 4}, dict.Keys().ToArray())
+	assert.Equal(t, []string{"2", "other"}, dict.Values())
+	assert.Equal(t, 0, other.Size(), "other is left empty")
+}
+
+func TestAVLDict_PutAllIsSublinear(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelOff))
+	keys, values := make([]int, 1<<16), make([]string, 1<<16)
+	for i := range keys {
+		keys[i] = 2 * i
+	}
+	big := NewAVLDictFromSorted(order.IntComp, keys, values)
+
+	// only building the small operands allocates, joining them in reuses their nodes,
+	// as dicts made by the same built-in comparator share their ordering
+	building := testing.AllocsPerRun(10, func() { avlDictOf(1, 1001, 50001, 99999) })
+	putting := testing.AllocsPerRun(10, func() { big.PutAll(avlDictOf(1, 1001, 50001, 99999)) })
+	assert.Equal(t, building, putting)
+	assert.Equal(t, 1<<16+4, big.Size())
+}
+
+func TestNewAVLDictFromSorted(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+	dict := NewAVLDictFromSorted(order.IntComp, []int{1, 2, 3, 4, 5}, []string{"a", "b", "c", "d", "e"})
+	assert.True(t, dict.IsAVLDict())
+	assert.Equal(t, 5, dict.Size())
+	value, _ := dict.Get(4)
+	assert.Equal(t, "d", value)
+	assert.Equal(t, "c", dict.Select(2).Value())
+
+	assert.Panics(t, func() { NewAVLDictFromSorted(order.IntComp, []int{2, 1}, []string{"a", "b"}) })
+	assert.Panics(t, func() { NewAVLDictFromSorted(order.IntComp, []int{1}, []string{}) })
+}
@@ -0,0 +1,17 @@
+package dict
+
+import "github.com/song-flying/GoDataStructures/pkg/order"
+
+// bounds on keys handed to tree.NewOrderedIterator by the tree dicts, and to the searches of SkipListDict
+
+func keyAtLeast[K comparable, V any](comp order.CompareFn[K], key K) func(entry[K, V]) bool {
+	return func(e entry[K, V]) bool { return comp(e.Key, key) >= 0 }
+}
+
+func keyAtMost[K comparable, V any](comp order.CompareFn[K], key K) func(entry[K, V]) bool {
+	return func(e entry[K, V]) bool { return comp(e.Key, key) <= 0 }
+}
+
+func keyBelow[K comparable, V any](comp order.CompareFn[K], key K) func(entry[K, V]) bool {
+	return func(e entry[K, V]) bool { return comp(e.Key, key) < 0 }
+}
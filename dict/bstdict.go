@@ -2,16 +2,19 @@ package dict
 
 import (
 	"github.com/song-flying/GoDataStructures/array"
+	"github.com/song-flying/GoDataStructures/linked"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
 	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/song-flying/GoDataStructures/tree"
 )
 
-type BSTDict[K comparable, V comparable] struct {
+type BSTDict[K comparable, V any] struct {
 	tree      *tree.BinaryTree[entry[K, V]]
 	keyComp   order.CompareFn[K]
 	entryComp order.CompareFn[entry[K, V]]
 	size      int
+	modCount  int
 }
 
 func (t *BSTDict[K, V]) isOrdered(root *tree.BinaryNode[entry[K, V]], lower, upper *K) bool {
@@ -30,7 +33,7 @@ func (t *BSTDict[K, V]) isOrdered(root *tree.BinaryNode[entry[K, V]], lower, upp
 
 func (t *BSTDict[K, V]) isOrderedWithMinMax(root *tree.BinaryNode[entry[K, V]]) (minKey, maxKey *K, isOrdered bool) {
 	contract.Require(t.keyComp != nil, "comparison function is not nil")
-	contract.Require(root.IsBinaryTree(), "root is a binary tree")
+	contract.RequireInvariant(root.IsBinaryTree, "root is a binary tree")
 
 	if root == nil {
 		return nil, nil, true
@@ -65,7 +68,17 @@ func (t *BSTDict[K, V]) hasSameEntries(a1, a2 []entry[K, V]) bool {
 	contract.Require(array.IsSorted(a1, t.entryComp) && array.IsDistinct(a1, t.entryComp), "a1 is sorted & distinct")
 	contract.Require(array.IsSorted(a2, t.entryComp) && array.IsDistinct(a2, t.entryComp), "a2 is sorted & distinct")
 
-	return array.Same(a1, a2)
+	// values need not be comparable, so only keys are compared
+	if len(a1) != len(a2) {
+		return false
+	}
+	for i := range a1 {
+		if t.keyComp(a1[i].Key, a2[i].Key) != 0 {
+			return false
+		}
+	}
+
+	return true
 }
 
 // IsBSTDict data structure invariant
@@ -78,10 +91,10 @@ func (t *BSTDict[K, V]) IsBST(root *tree.BinaryNode[entry[K, V]]) bool {
 	return t.keyComp != nil && t.entryComp != nil && root.IsBinaryTree() && isOrdered
 }
 
-func NewBSTDict[K comparable, V comparable](comp order.CompareFn[K]) (result *BSTDict[K, V]) {
+func NewBSTDict[K comparable, V any](comp order.CompareFn[K]) (result *BSTDict[K, V]) {
 	contract.Require(comp != nil, "comparison function is not nil")
 	defer func() {
-		contract.Ensure(result.IsBSTDict(), "BST invariant holds")
+		contract.EnsureInvariant(result.IsBSTDict, "BST invariant holds")
 	}()
 
 	t := tree.NewBinaryTree(tree.Nil[entry[K, V]]())
@@ -99,7 +112,7 @@ func NewBSTDict[K comparable, V comparable](comp order.CompareFn[K]) (result *BS
 
 func (t *BSTDict[K, V]) lookup(root **tree.BinaryNode[entry[K, V]], key K) **tree.BinaryNode[entry[K, V]] {
 	contract.Require(root != nil, "root pointer is not nil")
-	contract.Require(t.IsBST(*root), "BST invariant holds")
+	contract.RequireInvariant(func() bool { return t.IsBST(*root) }, "BST invariant holds")
 
 	if *root == nil {
 		return nil
@@ -117,7 +130,7 @@ func (t *BSTDict[K, V]) lookup(root **tree.BinaryNode[entry[K, V]], key K) **tre
 }
 
 func (t *BSTDict[K, V]) Get(key K) (V, bool) {
-	contract.Require(t.IsBSTDict(), "BST invariant holds")
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
 
 	node := t.lookup(&t.tree.Root, key)
 	if node != nil {
@@ -127,7 +140,7 @@ func (t *BSTDict[K, V]) Get(key K) (V, bool) {
 }
 
 func (t *BSTDict[K, V]) ToArray(root *tree.BinaryNode[entry[K, V]]) (result []entry[K, V]) {
-	contract.Require(t.IsBST(root), "BST invariant holds")
+	contract.RequireInvariant(func() bool { return t.IsBST(root) }, "BST invariant holds")
 	defer func() {
 		contract.Ensure(array.IsSorted(result, t.entryComp), "result entries are sorted")
 	}()
@@ -147,21 +160,24 @@ func (t *BSTDict[K, V]) ToArray(root *tree.BinaryNode[entry[K, V]]) (result []en
 }
 
 func (t *BSTDict[K, V]) insert(root *tree.BinaryNode[entry[K, V]], key K, value V) (result *tree.BinaryNode[entry[K, V]]) {
-	contract.Require(t.IsBST(root), "BST invariant holds for root")
-	defer func(oldEntries []entry[K, V]) {
-		contract.Ensure(t.IsBST(result), "BST invariant holds for result")
-		newEntries := t.ToArray(result)
-		extract := func(e entry[K, V]) K { return e.Key }
-		contract.Ensure(array.Contains(newEntries, key, extract), "new root should contain new entry")
-		filter := func(e entry[K, V]) bool { return e.Key != key }
-		oldEntries = array.Filter(oldEntries, filter)
-		newEntries = array.Filter(newEntries, filter)
-		contract.Ensure(t.hasSameEntries(oldEntries, newEntries), "new root should contain same entries as old root, except for new entry")
-	}(t.ToArray(root))
+	contract.RequireInvariant(func() bool { return t.IsBST(root) }, "BST invariant holds for root")
+	if contract.Enabled(contract.LevelPost) {
+		defer func(oldEntries []entry[K, V]) {
+			contract.EnsureInvariant(func() bool { return t.IsBST(result) }, "BST invariant holds for result")
+			newEntries := t.ToArray(result)
+			extract := func(e entry[K, V]) K { return e.Key }
+			contract.Ensure(array.Contains(newEntries, key, extract), "new root should contain new entry")
+			filter := func(e entry[K, V]) bool { return t.keyComp(e.Key, key) != 0 }
+			oldEntries = array.Filter(oldEntries, filter)
+			newEntries = array.Filter(newEntries, filter)
+			contract.Ensure(t.hasSameEntries(oldEntries, newEntries), "new root should contain same entries as old root, except for new entry")
+		}(t.ToArray(root))
+	}
 
 	if root == nil {
 		node := tree.NewBinaryNode[entry[K, V]](entry[K, V]{Key: key, Value: value})
 		t.size++
+		t.modCount++
 		return &node
 	}
 
@@ -179,11 +195,13 @@ func (t *BSTDict[K, V]) insert(root *tree.BinaryNode[entry[K, V]], key K, value
 }
 
 func (t *BSTDict[K, V]) Put(key K, value V) {
-	contract.Require(t.IsBSTDict(), "BST invariant holds")
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
 	defer func() {
-		contract.Ensure(t.IsBSTDict(), "BST invariant holds")
-		v, ok := t.Get(key)
-		contract.Ensure(ok && value == v, "Get(key) returns value")
+		contract.EnsureInvariant(t.IsBSTDict, "BST invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := t.Get(key)
+			contract.Ensure(ok, "Get(key) finds key")
+		}
 	}()
 
 	t.tree.Root = t.insert(t.tree.Root, key, value)
@@ -192,13 +210,15 @@ func (t *BSTDict[K, V]) Put(key K, value V) {
 func (t *BSTDict[K, V]) maxFrom(root **tree.BinaryNode[entry[K, V]]) (result **tree.BinaryNode[entry[K, V]]) {
 	contract.Require(t.keyComp != nil && t.entryComp != nil, "comparison function is not nil")
 	contract.Require(root != nil && *root != nil, "root points to some node")
-	_, maxKey, isOrdered := t.isOrderedWithMinMax(*root)
-	contract.Require(isOrdered, "root node is ordered")
-	defer func(maxKey *K) {
-		contract.Ensure(result != nil && (*result) != nil, "result points to some node")
-		contract.Ensure((*result).Right == nil, "result node is the right most one")
-		contract.Ensure((*result).Data.Key == *maxKey, "result node has max key")
-	}(maxKey)
+	if contract.Enabled(contract.LevelFull) {
+		_, maxKey, isOrdered := t.isOrderedWithMinMax(*root)
+		contract.Require(isOrdered, "root node is ordered")
+		defer func(maxKey *K) {
+			contract.Ensure(result != nil && (*result) != nil, "result points to some node")
+			contract.Ensure((*result).Right == nil, "result node is the right most one")
+			contract.Ensure((*result).Data.Key == *maxKey, "result node has max key")
+		}(maxKey)
+	}
 
 	curr := root
 	for (*curr).Right != nil {
@@ -210,13 +230,15 @@ func (t *BSTDict[K, V]) maxFrom(root **tree.BinaryNode[entry[K, V]]) (result **t
 func (t *BSTDict[K, V]) minFrom(root **tree.BinaryNode[entry[K, V]]) (result **tree.BinaryNode[entry[K, V]]) {
 	contract.Require(t.keyComp != nil && t.entryComp != nil, "comparison function is not nil")
 	contract.Require(root != nil && *root != nil, "root points to some node")
-	minKey, _, isOrdered := t.isOrderedWithMinMax(*root)
-	contract.Require(isOrdered, "root node is ordered")
-	defer func(minKey *K) {
-		contract.Ensure(result != nil && (*result) != nil, "result points to some node")
-		contract.Ensure((*result).Left == nil, "result node is the left most one")
-		contract.Ensure((*result).Data.Key == *minKey, "result node has min key")
-	}(minKey)
+	if contract.Enabled(contract.LevelFull) {
+		minKey, _, isOrdered := t.isOrderedWithMinMax(*root)
+		contract.Require(isOrdered, "root node is ordered")
+		defer func(minKey *K) {
+			contract.Ensure(result != nil && (*result) != nil, "result points to some node")
+			contract.Ensure((*result).Left == nil, "result node is the left most one")
+			contract.Ensure((*result).Data.Key == *minKey, "result node has min key")
+		}(minKey)
+	}
 
 	curr := root
 	for (*curr).Left != nil {
@@ -227,16 +249,18 @@ func (t *BSTDict[K, V]) minFrom(root **tree.BinaryNode[entry[K, V]]) (result **t
 
 func (t *BSTDict[K, V]) remove(pRoot **tree.BinaryNode[entry[K, V]]) {
 	contract.Require(pRoot != nil && *pRoot != nil, "root is not nil")
-	contract.Require(t.IsBST(*pRoot), "BST invariant holds for root")
-	defer func(key K, oldEntries []entry[K, V]) {
-		contract.Ensure(t.IsBST(*pRoot), "BST invariant holds for root")
-		newEntries := t.ToArray(*pRoot)
-		extract := func(e entry[K, V]) K { return e.Key }
-		contract.Ensure(!array.Contains(newEntries, key, extract), "root tree does not contain removed entry")
-		filter := func(e entry[K, V]) bool { return e.Key != key }
-		oldEntries = array.Filter(oldEntries, filter)
-		contract.Ensure(t.hasSameEntries(oldEntries, newEntries), "new root should contain same entries as old root excluding removed entry")
-	}((*pRoot).Data.Key, t.ToArray(*pRoot))
+	contract.RequireInvariant(func() bool { return t.IsBST(*pRoot) }, "BST invariant holds for root")
+	if contract.Enabled(contract.LevelPost) {
+		defer func(key K, oldEntries []entry[K, V]) {
+			contract.EnsureInvariant(func() bool { return t.IsBST(*pRoot) }, "BST invariant holds for root")
+			newEntries := t.ToArray(*pRoot)
+			extract := func(e entry[K, V]) K { return e.Key }
+			contract.Ensure(!array.Contains(newEntries, key, extract), "root tree does not contain removed entry")
+			filter := func(e entry[K, V]) bool { return t.keyComp(e.Key, key) != 0 }
+			oldEntries = array.Filter(oldEntries, filter)
+			contract.Ensure(t.hasSameEntries(oldEntries, newEntries), "new root should contain same entries as old root excluding removed entry")
+		}((*pRoot).Data.Key, t.ToArray(*pRoot))
+	}
 
 	switch {
 	case (*pRoot).Left == nil && (*pRoot).Right == nil:
@@ -254,11 +278,13 @@ func (t *BSTDict[K, V]) remove(pRoot **tree.BinaryNode[entry[K, V]]) {
 }
 
 func (t *BSTDict[K, V]) Delete(key K) {
-	contract.Require(t.IsBSTDict(), "BST invariant holds")
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
 	defer func() {
-		contract.Ensure(t.IsBSTDict(), "BST invariant holds")
-		_, ok := t.Get(key)
-		contract.Ensure(!ok, "Get(key) returns no value")
+		contract.EnsureInvariant(t.IsBSTDict, "BST invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := t.Get(key)
+			contract.Ensure(!ok, "Get(key) returns no value")
+		}
 	}()
 
 	target := t.lookup(&t.tree.Root, key)
@@ -268,13 +294,304 @@ func (t *BSTDict[K, V]) Delete(key K) {
 
 	t.remove(target)
 	t.size--
+	t.modCount++
+}
+
+// locate returns the link to the node of key, or to nil where that node belongs
+func (t *BSTDict[K, V]) locate(key K) (link **tree.BinaryNode[entry[K, V]]) {
+	link = &t.tree.Root
+	for *link != nil {
+		compResult := t.keyComp(key, (*link).Data.Key)
+		switch {
+		case compResult == 0:
+			return
+		case compResult < 0:
+			link = &(*link).Left
+		default: //compResult > 0:
+			link = &(*link).Right
+		}
+	}
+
+	return
+}
+
+// Compute walks down to key once, and removes or inserts right there
+func (t *BSTDict[K, V]) Compute(key K, fn func(value V, found bool) (newValue V, keep bool)) (result V, present bool) {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsBSTDict, "BST invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := t.Get(key)
+			contract.Ensure(ok == present, "Get(key) finds key iff it is present")
+		}
+	}()
+
+	link := t.locate(key)
+	if *link != nil {
+		if value, keep := fn((*link).Data.Value, true); keep {
+			(*link).Data.Value = value
+			return value, true
+		}
+		t.remove(link)
+		t.size--
+		t.modCount++
+		return *new(V), false
+	}
+
+	value, keep := fn(*new(V), false)
+	if !keep {
+		return *new(V), false
+	}
+	node := tree.NewBinaryNode[entry[K, V]](entry[K, V]{Key: key, Value: value})
+	*link = &node
+	t.size++
+	t.modCount++
+	return value, true
+}
+
+func (t *BSTDict[K, V]) Clear() {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsBSTDict, "BST invariant holds")
+		contract.Ensure(t.size == 0, "dict is empty")
+	}()
+
+	t.tree.Root = nil
+	t.size = 0
+	t.modCount++
 }
 
 func (t *BSTDict[K, V]) Size() (result int) {
-	contract.Require(t.IsBSTDict(), "BST invariant holds")
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
 	defer func() {
 		contract.Ensure(0 <= result, "result is non-negative")
 	}()
 
 	return t.size
 }
+
+func (t *BSTDict[K, V]) GetOrDefault(key K, def V) V {
+	if value, found := t.Get(key); found {
+		return value
+	}
+
+	return def
+}
+
+func (t *BSTDict[K, V]) PutIfAbsent(key K, value V) (actual V, loaded bool) {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+
+	return putIfAbsent[K, V](t, key, value)
+}
+
+func (t *BSTDict[K, V]) ComputeIfAbsent(key K, fn func(K) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+
+	return computeIfAbsent[K, V](t, key, fn)
+}
+
+func (t *BSTDict[K, V]) Merge(key K, value V, fn func(old, value V) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+
+	return merge[K, V](t, key, value, fn)
+}
+
+func (t *BSTDict[K, V]) Replace(key K, value V) (previous V, replaced bool) {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+
+	return replace[K, V](t, key, value)
+}
+
+func (t *BSTDict[K, V]) Keys() (result *linked.List[K]) {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+	defer func() {
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(result.Length() == t.size, "result has all keys")
+		}
+	}()
+
+	return keysOf(t.All(), order.Equal[K])
+}
+
+func (t *BSTDict[K, V]) Values() (result []V) {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == t.size, "result has all values")
+	}()
+
+	return valuesOf(t.All())
+}
+
+func (t *BSTDict[K, V]) Entries() (result []Entry[K, V]) {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == t.size, "result has all entries")
+	}()
+
+	return entriesOf(t.All())
+}
+
+func (t *BSTDict[K, V]) walk(descending bool, afterStart, beforeEnd func(entry[K, V]) bool) *tree.OrderedIterator[entry[K, V]] {
+	return tree.NewOrderedIterator(t.tree.Root, descending, afterStart, beforeEnd)
+}
+
+func (t *BSTDict[K, V]) compareKeys(a, b K) int {
+	return t.keyComp(a, b)
+}
+
+func (t *BSTDict[K, V]) mods() int {
+	return t.modCount
+}
+
+// Iterator walks the entries in ascending key order
+func (t *BSTDict[K, V]) Iterator() iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+
+	return newTreeDictIterator[K, V](t, false, nil, nil)
+}
+
+// All walks the entries in ascending key order
+func (t *BSTDict[K, V]) All() iterator.Seq2[K, V] {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+
+	entries := t.tree.All()
+	return iterator.FailFastSeq2(func(yield func(K, V) bool) {
+		entries(func(e entry[K, V]) bool {
+			return yield(e.Key, e.Value)
+		})
+	}, t.mods)
+}
+
+// Descending walks the entries in descending key order
+func (t *BSTDict[K, V]) Descending() iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+
+	return newTreeDictIterator[K, V](t, true, nil, nil)
+}
+
+// Range walks the entries with keys within [lo, hi) in ascending key order
+func (t *BSTDict[K, V]) Range(lo, hi K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+	contract.Require(t.keyComp(lo, hi) <= 0, "lo <= hi")
+
+	return newTreeDictIterator[K, V](t, false, keyAtLeast[K, V](t.keyComp, lo), keyBelow[K, V](t.keyComp, hi))
+}
+
+// ReverseRange walks the entries with keys within [lo, hi) in descending key order
+func (t *BSTDict[K, V]) ReverseRange(lo, hi K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+	contract.Require(t.keyComp(lo, hi) <= 0, "lo <= hi")
+
+	return newTreeDictIterator[K, V](t, true, keyBelow[K, V](t.keyComp, hi), keyAtLeast[K, V](t.keyComp, lo))
+}
+
+// From walks the entries with keys not less than key in ascending key order
+func (t *BSTDict[K, V]) From(key K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+
+	return newTreeDictIterator[K, V](t, false, keyAtLeast[K, V](t.keyComp, key), nil)
+}
+
+// ReverseFrom walks the entries with keys not greater than key in descending key order
+func (t *BSTDict[K, V]) ReverseFrom(key K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+
+	return newTreeDictIterator[K, V](t, true, keyAtMost[K, V](t.keyComp, key), nil)
+}
+
+// Min returns the entry with the least key, ok is false when the dict is empty
+func (t *BSTDict[K, V]) Min() (Entry[K, V], bool) {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+
+	return nodeEntry[K, V](minNode[K, V](t.tree.Root))
+}
+
+// Max returns the entry with the greatest key, ok is false when the dict is empty
+func (t *BSTDict[K, V]) Max() (Entry[K, V], bool) {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+
+	return nodeEntry[K, V](maxNode[K, V](t.tree.Root))
+}
+
+// Floor returns the entry with the greatest key not greater than key
+func (t *BSTDict[K, V]) Floor(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.keyComp(result.Key(), key) <= 0, "result key <= key")
+	}()
+
+	return nodeEntry[K, V](floorNode[K, V](t.tree.Root, t.keyComp, key, false))
+}
+
+// Ceiling returns the entry with the least key not less than key
+func (t *BSTDict[K, V]) Ceiling(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.keyComp(result.Key(), key) >= 0, "result key >= key")
+	}()
+
+	return nodeEntry[K, V](ceilingNode[K, V](t.tree.Root, t.keyComp, key, false))
+}
+
+// Lower returns the entry with the greatest key less than key
+func (t *BSTDict[K, V]) Lower(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.keyComp(result.Key(), key) < 0, "result key < key")
+	}()
+
+	return nodeEntry[K, V](floorNode[K, V](t.tree.Root, t.keyComp, key, true))
+}
+
+// Higher returns the entry with the least key greater than key
+func (t *BSTDict[K, V]) Higher(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.keyComp(result.Key(), key) > 0, "result key > key")
+	}()
+
+	return nodeEntry[K, V](ceilingNode[K, V](t.tree.Root, t.keyComp, key, true))
+}
+
+// PollFirst removes and returns the entry with the least key
+func (t *BSTDict[K, V]) PollFirst() (result Entry[K, V], ok bool) {
+	if result, ok = t.Min(); ok {
+		t.Delete(result.Key())
+	}
+
+	return
+}
+
+// PollLast removes and returns the entry with the greatest key
+func (t *BSTDict[K, V]) PollLast() (result Entry[K, V], ok bool) {
+	if result, ok = t.Max(); ok {
+		t.Delete(result.Key())
+	}
+
+	return
+}
+
+// HeadMap returns a live view of the entries with keys less than to
+func (t *BSTDict[K, V]) HeadMap(to K) *SubDict[K, V] {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+
+	return newSubDict[K, V](t, nil, &to)
+}
+
+// TailMap returns a live view of the entries with keys not less than from
+func (t *BSTDict[K, V]) TailMap(from K) *SubDict[K, V] {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+
+	return newSubDict[K, V](t, &from, nil)
+}
+
+// SubMap returns a live view of the entries with keys within [from, to)
+func (t *BSTDict[K, V]) SubMap(from, to K) *SubDict[K, V] {
+	contract.RequireInvariant(t.IsBSTDict, "BST invariant holds")
+	contract.Require(t.keyComp(from, to) <= 0, "from <= to")
+
+	return newSubDict[K, V](t, &from, &to)
+}
@@ -2,7 +2,10 @@ package dict
 
 import (
 	"github.com/song-flying/GoDataStructures/array"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/stretchr/testify/assert"
+	"strconv"
 	"testing"
 )
 
@@ -69,3 +72,106 @@ func TestBSTDict(t *testing.T) {
 		assert.Equal(t, len(a)-i-1, dict.Size())
 	}
 }
+
+func TestBSTDict_Iterator(t *testing.T) {
+	var dict Dict[int, string] = NewBSTDict[int, string](order.IntComp)
+	assert.False(t, dict.Iterator().HasNext())
+
+	keys := []int{1, 2, 3, 4, 5}
+	array.Shuffle(keys)
+	for _, k := range keys {
+		dict.Put(k, strconv.Itoa(k))
+	}
+
+	var fromIterator []int
+	for it := dict.Iterator(); it.HasNext(); {
+		e := it.Next()
+		assert.Equal(t, strconv.Itoa(e.Key()), e.Value())
+		fromIterator = append(fromIterator, e.Key())
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, fromIterator)
+
+	var fromAll []int
+	dict.All()(func(k int, v string) bool {
+		assert.Equal(t, strconv.Itoa(k), v)
+		fromAll = append(fromAll, k)
+		return k < 3
+	})
+	assert.Equal(t, []int{1, 2, 3}, fromAll)
+}
+
+func TestBSTDict_Range(t *testing.T) {
+	dict := NewBSTDict[int, string](order.IntComp)
+	assert.False(t, dict.Descending().HasNext())
+
+	keys := []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}
+	array.Shuffle(keys)
+	for _, k := range keys {
+		dict.Put(k, strconv.Itoa(k))
+	}
+
+	collectKeys := func(it iterator.Iterator[Entry[int, string]]) []int {
+		return iterator.Collect(iterator.Map(it, func(e Entry[int, string]) int {
+			assert.Equal(t, strconv.Itoa(e.Key()), e.Value())
+			return e.Key()
+		}))
+	}
+	assert.Equal(t, []int{18, 16, 14, 12, 10, 8, 6, 4, 2, 0}, collectKeys(dict.Descending()))
+	assert.Equal(t, []int{4, 6, 8}, collectKeys(dict.Range(3, 10)))
+	assert.Equal(t, []int{8, 6, 4}, collectKeys(dict.ReverseRange(3, 10)))
+	assert.Empty(t, collectKeys(dict.Range(4, 4)))
+	assert.Equal(t, []int{14, 16, 18}, collectKeys(dict.From(14)))
+	assert.Equal(t, []int{4, 2, 0}, collectKeys(dict.ReverseFrom(5)))
+	assert.Empty(t, collectKeys(dict.From(19)))
+}
+
+func TestBSTDict_IteratorRemove(t *testing.T) {
+	dict := NewBSTDict[int, string](order.IntComp)
+	keys := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	array.Shuffle(keys)
+	for _, k := range keys {
+		dict.Put(k, strconv.Itoa(k))
+	}
+
+	for it := dict.ReverseFrom(7); it.HasNext(); {
+		if e := it.Next(); e.Key()%2 == 1 {
+			it.Remove()
+		}
+	}
+	var remaining []int
+	dict.All()(func(k int, v string) bool {
+		remaining = append(remaining, k)
+		return true
+	})
+	assert.Equal(t, []int{0, 2, 4, 6, 8, 9}, remaining)
+	assert.Equal(t, 6, dict.Size())
+
+	it := dict.Iterator()
+	dict.Put(1, "1")
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.Next() })
+
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() {
+		dict.All()(func(k int, v string) bool {
+			dict.Delete(k)
+			return true
+		})
+	})
+}
+
+func TestBSTDict_ComposedOrder(t *testing.T) {
+	type point struct{ x, y int }
+	byX := order.ByKey(func(p point) int { return p.x }, order.IntComp)
+	byY := order.ByKey(func(p point) int { return p.y }, order.IntComp)
+	dict := NewBSTDict[point, string](order.ThenComparing(byX, order.Reverse(byY)))
+
+	dict.Put(point{1, 1}, "a")
+	dict.Put(point{0, 5}, "b")
+	dict.Put(point{1, 2}, "c")
+
+	var values []string
+	dict.All()(func(k point, v string) bool {
+		values = append(values, v)
+		return true
+	})
+	assert.Equal(t, []string{"b", "c", "a"}, values)
+}
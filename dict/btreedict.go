@@ -0,0 +1,779 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/linked"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/song-flying/GoDataStructures/searching/array"
+)
+
+// bTreeNode holds its keys sorted, with children[i] holding the keys between keys[i-1] and keys[i]
+type bTreeNode[K any, V any] struct {
+	keys     []K
+	values   []V
+	children []*bTreeNode[K, V] // nil for leaves
+}
+
+func (n *bTreeNode[K, V]) isLeaf() bool {
+	return n.children == nil
+}
+
+// BTreeDict is a B-tree of minimum degree degree: every node but the root holds between degree-1 and 2*degree-1 keys,
+// and all leaves are at the same depth
+type BTreeDict[K comparable, V any] struct {
+	root     *bTreeNode[K, V]
+	degree   int
+	keyComp  order.CompareFn[K]
+	size     int
+	modCount int
+}
+
+func (d *BTreeDict[K, V]) maxKeys() int {
+	return 2*d.degree - 1
+}
+
+// isOrderedFrom checks that the keys below node are strictly ascending and within (lower, upper)
+func (d *BTreeDict[K, V]) isOrderedFrom(node *bTreeNode[K, V], lower, upper *K) bool {
+	for i, key := range node.keys {
+		if i > 0 && d.keyComp(node.keys[i-1], key) >= 0 {
+			return false
+		}
+	}
+	if n := len(node.keys); n > 0 && (lower != nil && d.keyComp(*lower, node.keys[0]) >= 0 ||
+		upper != nil && d.keyComp(node.keys[n-1], *upper) >= 0) {
+		return false
+	}
+
+	for i, child := range node.children {
+		childLower, childUpper := lower, upper
+		if i > 0 {
+			childLower = &node.keys[i-1]
+		}
+		if i < len(node.keys) {
+			childUpper = &node.keys[i]
+		}
+		if !d.isOrderedFrom(child, childLower, childUpper) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isFilledFrom checks the number of keys, values and children of every node below node
+func (d *BTreeDict[K, V]) isFilledFrom(node *bTreeNode[K, V], isRoot bool) bool {
+	if len(node.keys) > d.maxKeys() || !isRoot && len(node.keys) < d.degree-1 || len(node.values) != len(node.keys) {
+		return false
+	}
+	if node.isLeaf() {
+		return true
+	}
+	if len(node.children) != len(node.keys)+1 {
+		return false
+	}
+
+	for _, child := range node.children {
+		if child == nil || !d.isFilledFrom(child, false) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// leafDepth returns the depth of the leaves below node, ok is false if they differ
+func (d *BTreeDict[K, V]) leafDepth(node *bTreeNode[K, V]) (depth int, ok bool) {
+	if node.isLeaf() {
+		return 0, true
+	}
+
+	for i, child := range node.children {
+		childDepth, childOK := d.leafDepth(child)
+		if !childOK || i > 0 && childDepth+1 != depth {
+			return 0, false
+		}
+		depth = childDepth + 1
+	}
+
+	return depth, true
+}
+
+func (d *BTreeDict[K, V]) countFrom(node *bTreeNode[K, V]) (result int) {
+	result = len(node.keys)
+	for _, child := range node.children {
+		result += d.countFrom(child)
+	}
+
+	return
+}
+
+// IsBTreeDict data structure invariant
+func (d *BTreeDict[K, V]) IsBTreeDict() bool {
+	if d == nil || d.root == nil || d.degree < 2 || d.keyComp == nil {
+		return false
+	}
+	_, sameDepth := d.leafDepth(d.root)
+	return d.isFilledFrom(d.root, true) && sameDepth && d.isOrderedFrom(d.root, nil, nil) && d.size == d.countFrom(d.root)
+}
+
+// NewBTreeDict takes the minimum degree of the tree, nodes hold up to 2*degree-1 keys
+func NewBTreeDict[K comparable, V any](degree int, comp order.CompareFn[K]) (result *BTreeDict[K, V]) {
+	contract.Require(2 <= degree, "degree is at least 2")
+	contract.Require(comp != nil, "comparison function is not nil")
+	defer func() {
+		contract.EnsureInvariant(result.IsBTreeDict, "B-tree invariant holds")
+	}()
+
+	result = &BTreeDict[K, V]{
+		degree:  degree,
+		keyComp: comp,
+	}
+	result.root = result.newNode(nil, nil, nil)
+	return
+}
+
+// NewBTreeDictFromSorted bulk loads keys[i] mapped to values[i] in O(n), keys must be strictly ascending.
+// Nodes are filled evenly, so that the tree has the least height and later updates rarely split.
+func NewBTreeDictFromSorted[K comparable, V any](degree int, comp order.CompareFn[K], keys []K, values []V) (result *BTreeDict[K, V]) {
+	contract.Require(len(keys) == len(values), "keys and values have the same length")
+	defer func() {
+		contract.EnsureInvariant(result.IsBTreeDict, "B-tree invariant holds")
+		contract.Ensure(result.size == len(keys), "result holds all keys")
+	}()
+
+	result = NewBTreeDict[K, V](degree, comp)
+	contract.Require(result.isAscending(keys), "keys are strictly ascending")
+
+	result.root = result.build(keys, values, nil)
+	result.size = len(keys)
+	return
+}
+
+func (d *BTreeDict[K, V]) isAscending(keys []K) bool {
+	for i := 1; i < len(keys); i++ {
+		if d.keyComp(keys[i-1], keys[i]) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// newNode copies keys, values and children into slices with room for a node overfilled by one key
+func (d *BTreeDict[K, V]) newNode(keys []K, values []V, children []*bTreeNode[K, V]) *bTreeNode[K, V] {
+	node := &bTreeNode[K, V]{
+		keys:   append(make([]K, 0, d.maxKeys()+1), keys...),
+		values: append(make([]V, 0, d.maxKeys()+1), values...),
+	}
+	if children != nil {
+		node.children = append(make([]*bTreeNode[K, V], 0, d.maxKeys()+2), children...)
+	}
+
+	return node
+}
+
+// build links one level of nodes, leaves if children is nil, and the levels above it.
+// The k nodes of a level share the keys but k-1, which separate them on the level above.
+func (d *BTreeDict[K, V]) build(keys []K, values []V, children []*bTreeNode[K, V]) *bTreeNode[K, V] {
+	contract.Require(children == nil || len(children) == len(keys)+1, "one more child than keys")
+
+	n := len(keys)
+	if n <= d.maxKeys() {
+		return d.newNode(keys, values, children)
+	}
+
+	k := (n + 2*d.degree) / (2 * d.degree) // ceil((n+1) / (2*degree)) nodes keep between degree-1 and 2*degree-1 keys each
+	base, extra := (n-k+1)/k, (n-k+1)%k
+	upKeys, upValues, nodes := make([]K, 0, k-1), make([]V, 0, k-1), make([]*bTreeNode[K, V], 0, k)
+	pos, childPos := 0, 0
+	for j := 0; j < k; j++ {
+		s := base
+		if j < extra {
+			s++
+		}
+		var nodeChildren []*bTreeNode[K, V]
+		if children != nil {
+			nodeChildren = children[childPos : childPos+s+1]
+			childPos += s + 1
+		}
+		nodes = append(nodes, d.newNode(keys[pos:pos+s], values[pos:pos+s], nodeChildren))
+		pos += s
+		if j < k-1 {
+			upKeys = append(upKeys, keys[pos])
+			upValues = append(upValues, values[pos])
+			pos++
+		}
+	}
+
+	return d.build(upKeys, upValues, nodes)
+}
+
+func insertAt[T any](a []T, i int, x T) []T {
+	a = append(a, x)
+	copy(a[i+1:], a[i:])
+	a[i] = x
+	return a
+}
+
+func removeAt[T any](a []T, i int) []T {
+	copy(a[i:], a[i+1:])
+	a[len(a)-1] = *new(T)
+	return a[:len(a)-1]
+}
+
+// search finds the node holding key, or the leaf where it would be inserted
+func (d *BTreeDict[K, V]) search(key K) (node *bTreeNode[K, V], i int, found bool) {
+	for node = d.root; ; node = node.children[i] {
+		if i, found = array.BinarySearchPosition(key, node.keys, d.keyComp); found || node.isLeaf() {
+			return
+		}
+	}
+}
+
+func (d *BTreeDict[K, V]) Get(key K) (V, bool) {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+
+	if node, i, found := d.search(key); found {
+		return node.values[i], true
+	}
+	return *new(V), false
+}
+
+// splitChild moves the upper half of the full or overfilled parent.children[i] into a new sibling, and its middle key up into parent
+func (d *BTreeDict[K, V]) splitChild(parent *bTreeNode[K, V], i int) {
+	full := parent.children[i]
+	contract.Require(len(full.keys) == d.maxKeys() || len(full.keys) == d.maxKeys()+1, "child is full or overfilled by one key")
+	contract.Require(len(parent.keys) <= d.maxKeys(), "parent is not overfilled")
+
+	t := d.degree
+	var rightChildren []*bTreeNode[K, V]
+	if !full.isLeaf() {
+		rightChildren = full.children[t:]
+	}
+	right := d.newNode(full.keys[t:], full.values[t:], rightChildren)
+	parent.keys = insertAt(parent.keys, i, full.keys[t-1])
+	parent.values = insertAt(parent.values, i, full.values[t-1])
+	parent.children = insertAt(parent.children, i+1, right)
+
+	for j := t - 1; j < len(full.keys); j++ {
+		full.keys[j], full.values[j] = *new(K), *new(V)
+	}
+	full.keys, full.values = full.keys[:t-1], full.values[:t-1]
+	if !full.isLeaf() {
+		for j := t; j < len(full.children); j++ {
+			full.children[j] = nil
+		}
+		full.children = full.children[:t]
+	}
+	d.modCount++
+}
+
+// splitRoot puts a new root above the full or overfilled root and splits the old one below it
+func (d *BTreeDict[K, V]) splitRoot() {
+	d.root = &bTreeNode[K, V]{children: append(make([]*bTreeNode[K, V], 0, d.maxKeys()+2), d.root)}
+	d.splitChild(d.root, 0)
+}
+
+// insert walks down once, splitting full nodes on the way so that a leaf has room for key
+func (d *BTreeDict[K, V]) insert(key K, value V) {
+	if len(d.root.keys) == d.maxKeys() {
+		d.splitRoot()
+	}
+
+	for node := d.root; ; {
+		i, found := array.BinarySearchPosition(key, node.keys, d.keyComp)
+		if found {
+			node.values[i] = value
+			return
+		}
+		if node.isLeaf() {
+			node.keys = insertAt(node.keys, i, key)
+			node.values = insertAt(node.values, i, value)
+			d.size++
+			d.modCount++
+			return
+		}
+		if len(node.children[i].keys) == d.maxKeys() {
+			d.splitChild(node, i)
+			compResult := d.keyComp(key, node.keys[i])
+			if compResult == 0 {
+				node.values[i] = value
+				return
+			}
+			if compResult > 0 {
+				i++
+			}
+		}
+		node = node.children[i]
+	}
+}
+
+func (d *BTreeDict[K, V]) Put(key K, value V) {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsBTreeDict, "B-tree invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := d.Get(key)
+			contract.Ensure(ok, "Get(key) finds key")
+		}
+	}()
+
+	d.insert(key, value)
+}
+
+// borrowFromLeft rotates the last key of node.children[i-1] through node into node.children[i]
+func (d *BTreeDict[K, V]) borrowFromLeft(node *bTreeNode[K, V], i int) {
+	child, left := node.children[i], node.children[i-1]
+	last := len(left.keys) - 1
+
+	child.keys = insertAt(child.keys, 0, node.keys[i-1])
+	child.values = insertAt(child.values, 0, node.values[i-1])
+	node.keys[i-1], node.values[i-1] = left.keys[last], left.values[last]
+	left.keys, left.values = removeAt(left.keys, last), removeAt(left.values, last)
+	if !child.isLeaf() {
+		child.children = insertAt(child.children, 0, left.children[last+1])
+		left.children = removeAt(left.children, last+1)
+	}
+}
+
+// borrowFromRight rotates the first key of node.children[i+1] through node into node.children[i]
+func (d *BTreeDict[K, V]) borrowFromRight(node *bTreeNode[K, V], i int) {
+	child, right := node.children[i], node.children[i+1]
+
+	child.keys = append(child.keys, node.keys[i])
+	child.values = append(child.values, node.values[i])
+	node.keys[i], node.values[i] = right.keys[0], right.values[0]
+	right.keys, right.values = removeAt(right.keys, 0), removeAt(right.values, 0)
+	if !child.isLeaf() {
+		child.children = append(child.children, right.children[0])
+		right.children = removeAt(right.children, 0)
+	}
+}
+
+// merge moves node.keys[i] and all of node.children[i+1] into node.children[i]
+func (d *BTreeDict[K, V]) merge(node *bTreeNode[K, V], i int) {
+	left, right := node.children[i], node.children[i+1]
+	contract.Require(len(left.keys)+len(right.keys) < d.maxKeys(), "merged node fits")
+
+	left.keys = append(append(left.keys, node.keys[i]), right.keys...)
+	left.values = append(append(left.values, node.values[i]), right.values...)
+	if !left.isLeaf() {
+		left.children = append(left.children, right.children...)
+	}
+	node.keys, node.values = removeAt(node.keys, i), removeAt(node.values, i)
+	node.children = removeAt(node.children, i+1)
+}
+
+// fill gives node.children[i] a key more than it has, which is at most the minimum, and returns the index of the child now covering its keys
+func (d *BTreeDict[K, V]) fill(node *bTreeNode[K, V], i int) int {
+	switch {
+	case i > 0 && len(node.children[i-1].keys) >= d.degree:
+		d.borrowFromLeft(node, i)
+		return i
+	case i < len(node.keys) && len(node.children[i+1].keys) >= d.degree:
+		d.borrowFromRight(node, i)
+		return i
+	case i < len(node.keys):
+		d.merge(node, i)
+		return i
+	default:
+		d.merge(node, i-1)
+		return i - 1
+	}
+}
+
+func (d *BTreeDict[K, V]) last(node *bTreeNode[K, V]) (K, V) {
+	for !node.isLeaf() {
+		node = node.children[len(node.children)-1]
+	}
+	return node.keys[len(node.keys)-1], node.values[len(node.values)-1]
+}
+
+func (d *BTreeDict[K, V]) first(node *bTreeNode[K, V]) (K, V) {
+	for !node.isLeaf() {
+		node = node.children[0]
+	}
+	return node.keys[0], node.values[0]
+}
+
+// removeFrom removes key, which must be below node, walking down once.
+// Every node it walks into holds at least degree keys, so that a key can be taken out of it.
+func (d *BTreeDict[K, V]) removeFrom(node *bTreeNode[K, V], key K) {
+	i, found := array.BinarySearchPosition(key, node.keys, d.keyComp)
+	if node.isLeaf() {
+		contract.Assert(found, "key is in the leaf")
+		node.keys, node.values = removeAt(node.keys, i), removeAt(node.values, i)
+		return
+	}
+
+	if !found {
+		if len(node.children[i].keys) < d.degree {
+			i = d.fill(node, i)
+		}
+		d.removeFrom(node.children[i], key)
+		return
+	}
+
+	left, right := node.children[i], node.children[i+1]
+	switch {
+	case len(left.keys) >= d.degree:
+		predecessor, value := d.last(left)
+		node.keys[i], node.values[i] = predecessor, value
+		d.removeFrom(left, predecessor)
+	case len(right.keys) >= d.degree:
+		successor, value := d.first(right)
+		node.keys[i], node.values[i] = successor, value
+		d.removeFrom(right, successor)
+	default:
+		d.merge(node, i)
+		d.removeFrom(left, key)
+	}
+}
+
+// remove removes key, which must be present
+func (d *BTreeDict[K, V]) remove(key K) {
+	d.removeFrom(d.root, key)
+	d.shrinkRoot()
+	d.size--
+	d.modCount++
+}
+
+// shrinkRoot drops a root whose last key was merged into its only child
+func (d *BTreeDict[K, V]) shrinkRoot() {
+	if len(d.root.keys) == 0 && !d.root.isLeaf() {
+		d.root = d.root.children[0]
+	}
+}
+
+func (d *BTreeDict[K, V]) Delete(key K) {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsBTreeDict, "B-tree invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := d.Get(key)
+			contract.Ensure(!ok, "Get(key) does not find key")
+		}
+	}()
+
+	if _, _, found := d.search(key); found {
+		d.remove(key)
+	}
+}
+
+// repair splits node.children[i] when an insertion overfilled it, or fills it when a removal left it a key short
+func (d *BTreeDict[K, V]) repair(node *bTreeNode[K, V], i int) {
+	switch child := node.children[i]; {
+	case len(child.keys) > d.maxKeys():
+		d.splitChild(node, i)
+	case len(child.keys) < d.degree-1:
+		d.fill(node, i)
+	}
+}
+
+// takeLast removes the entry with the greatest key below node, repairing the nodes it leaves short on the way up
+func (d *BTreeDict[K, V]) takeLast(node *bTreeNode[K, V]) (key K, value V) {
+	last := len(node.keys) - 1
+	if node.isLeaf() {
+		key, value = node.keys[last], node.values[last]
+		node.keys, node.values = removeAt(node.keys, last), removeAt(node.values, last)
+		return
+	}
+
+	key, value = d.takeLast(node.children[last+1])
+	d.repair(node, last+1)
+	return
+}
+
+// computeFrom walks down from node to where key is or belongs once, calls fn there and inserts or removes right away.
+// Which of the two fn asks for is only known at the bottom, so the nodes on the way are repaired on the way back up:
+// an overfilled child is split, and a child a key short is filled from its siblings.
+func (d *BTreeDict[K, V]) computeFrom(node *bTreeNode[K, V], key K, fn func(V, bool) (V, bool)) (result V, present bool) {
+	i, found := array.BinarySearchPosition(key, node.keys, d.keyComp)
+	switch {
+	case found:
+		var keep bool
+		if result, keep = fn(node.values[i], true); keep {
+			node.values[i] = result
+			return result, true
+		}
+		if node.isLeaf() {
+			node.keys, node.values = removeAt(node.keys, i), removeAt(node.values, i)
+		} else {
+			node.keys[i], node.values[i] = d.takeLast(node.children[i])
+			d.repair(node, i)
+		}
+		d.size--
+		d.modCount++
+		return *new(V), false
+	case node.isLeaf():
+		var keep bool
+		if result, keep = fn(*new(V), false); !keep {
+			return *new(V), false
+		}
+		node.keys = insertAt(node.keys, i, key)
+		node.values = insertAt(node.values, i, result)
+		d.size++
+		d.modCount++
+		return result, true
+	}
+
+	result, present = d.computeFrom(node.children[i], key, fn)
+	d.repair(node, i)
+	return
+}
+
+// Compute walks down to key once, inserting or removing right there
+func (d *BTreeDict[K, V]) Compute(key K, fn func(value V, found bool) (newValue V, keep bool)) (result V, present bool) {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsBTreeDict, "B-tree invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := d.Get(key)
+			contract.Ensure(ok == present, "Get(key) finds key iff it is present")
+		}
+	}()
+
+	result, present = d.computeFrom(d.root, key, fn)
+	if len(d.root.keys) > d.maxKeys() {
+		d.splitRoot()
+	}
+	d.shrinkRoot()
+	return
+}
+
+func (d *BTreeDict[K, V]) Clear() {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsBTreeDict, "B-tree invariant holds")
+		contract.Ensure(d.size == 0, "dict is empty")
+	}()
+
+	d.root = d.newNode(nil, nil, nil)
+	d.size = 0
+	d.modCount++
+}
+
+func (d *BTreeDict[K, V]) Size() (result int) {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+	defer func() {
+		contract.Ensure(0 <= result, "result is non-negative")
+	}()
+
+	return d.size
+}
+
+func (d *BTreeDict[K, V]) GetOrDefault(key K, def V) V {
+	if value, found := d.Get(key); found {
+		return value
+	}
+
+	return def
+}
+
+func (d *BTreeDict[K, V]) PutIfAbsent(key K, value V) (actual V, loaded bool) {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+
+	return putIfAbsent[K, V](d, key, value)
+}
+
+func (d *BTreeDict[K, V]) ComputeIfAbsent(key K, fn func(K) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+
+	return computeIfAbsent[K, V](d, key, fn)
+}
+
+func (d *BTreeDict[K, V]) Merge(key K, value V, fn func(old, value V) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+
+	return merge[K, V](d, key, value, fn)
+}
+
+func (d *BTreeDict[K, V]) Replace(key K, value V) (previous V, replaced bool) {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+
+	return replace[K, V](d, key, value)
+}
+
+func (d *BTreeDict[K, V]) Keys() (result *linked.List[K]) {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+	defer func() {
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(result.Length() == d.size, "result has all keys")
+		}
+	}()
+
+	return keysOf(d.All(), order.Equal[K])
+}
+
+func (d *BTreeDict[K, V]) Values() (result []V) {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == d.size, "result has all values")
+	}()
+
+	return valuesOf(d.All())
+}
+
+func (d *BTreeDict[K, V]) Entries() (result []Entry[K, V]) {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == d.size, "result has all entries")
+	}()
+
+	return entriesOf(d.All())
+}
+
+// bTreeFrame is a node on the path to the next key, which is keys[index] once the children before it are walked
+type bTreeFrame[K any, V any] struct {
+	node  *bTreeNode[K, V]
+	index int
+}
+
+// bTreeDictIterator walks the keys in ascending order up to hi, nil for no bound
+type bTreeDictIterator[K comparable, V any] struct {
+	dict      *BTreeDict[K, V]
+	path      []bTreeFrame[K, V]
+	hi        *K
+	last      K
+	canRemove bool
+	modCount  int
+}
+
+func newBTreeDictIterator[K comparable, V any](dict *BTreeDict[K, V], lo, hi *K) *bTreeDictIterator[K, V] {
+	it := &bTreeDictIterator[K, V]{
+		dict:     dict,
+		hi:       hi,
+		modCount: dict.modCount,
+	}
+	if lo == nil {
+		it.pushFirst(dict.root)
+		it.skipWalked()
+	} else {
+		it.seek(*lo, false)
+	}
+
+	return it
+}
+
+func (it *bTreeDictIterator[K, V]) pushFirst(node *bTreeNode[K, V]) {
+	for ; node != nil; node = node.children[0] {
+		it.path = append(it.path, bTreeFrame[K, V]{node: node})
+		if node.isLeaf() {
+			return
+		}
+	}
+}
+
+// seek positions the walk at the first key not less than key, or greater than key if strict
+func (it *bTreeDictIterator[K, V]) seek(key K, strict bool) {
+	it.path = it.path[:0]
+	for node := it.dict.root; ; {
+		i, found := array.BinarySearchPosition(key, node.keys, it.dict.keyComp)
+		if found && strict {
+			i++
+		}
+		it.path = append(it.path, bTreeFrame[K, V]{node: node, index: i})
+		if found && !strict || node.isLeaf() {
+			break
+		}
+		node = node.children[i]
+	}
+	it.skipWalked()
+}
+
+// skipWalked pops the nodes whose keys are all walked
+func (it *bTreeDictIterator[K, V]) skipWalked() {
+	for n := len(it.path); n > 0 && it.path[n-1].index == len(it.path[n-1].node.keys); n-- {
+		it.path = it.path[:n-1]
+	}
+}
+
+func (it *bTreeDictIterator[K, V]) HasNext() bool {
+	iterator.CheckMods(it.dict.modCount, it.modCount)
+
+	if len(it.path) == 0 {
+		return false
+	}
+	top := it.path[len(it.path)-1]
+	return it.hi == nil || it.dict.keyComp(top.node.keys[top.index], *it.hi) < 0
+}
+
+func (it *bTreeDictIterator[K, V]) Next() Entry[K, V] {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	top := &it.path[len(it.path)-1]
+	node, i := top.node, top.index
+	top.index++
+	if !node.isLeaf() {
+		it.pushFirst(node.children[i+1])
+	}
+	it.skipWalked()
+
+	it.last, it.canRemove = node.keys[i], true
+	return keyValue[K, V]{key: node.keys[i], value: node.values[i]}
+}
+
+// Remove deletes the last key, and seeks past it in the restructured tree
+func (it *bTreeDictIterator[K, V]) Remove() {
+	iterator.CheckMods(it.dict.modCount, it.modCount)
+	contract.Require(it.canRemove, "Next is called before Remove, and Remove at most once per Next")
+
+	it.dict.Delete(it.last)
+	it.modCount = it.dict.modCount
+	it.canRemove = false
+	it.seek(it.last, true)
+}
+
+// Iterator walks the entries in ascending key order
+func (d *BTreeDict[K, V]) Iterator() iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+
+	return newBTreeDictIterator[K, V](d, nil, nil)
+}
+
+func (d *BTreeDict[K, V]) walk(node *bTreeNode[K, V], yield func(K, V) bool) bool {
+	for i := range node.keys {
+		if !node.isLeaf() && !d.walk(node.children[i], yield) || !yield(node.keys[i], node.values[i]) {
+			return false
+		}
+	}
+
+	return node.isLeaf() || d.walk(node.children[len(node.keys)], yield)
+}
+
+// All walks the entries in ascending key order
+func (d *BTreeDict[K, V]) All() iterator.Seq2[K, V] {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+
+	return iterator.FailFastSeq2(func(yield func(K, V) bool) {
+		d.walk(d.root, yield)
+	}, d.mods)
+}
+
+func (d *BTreeDict[K, V]) mods() int {
+	return d.modCount
+}
+
+// Range walks the entries with keys within [lo, hi) in ascending key order
+func (d *BTreeDict[K, V]) Range(lo, hi K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+	contract.Require(d.keyComp(lo, hi) <= 0, "lo <= hi")
+
+	return newBTreeDictIterator[K, V](d, &lo, &hi)
+}
+
+// From walks the entries with keys not less than key in ascending key order
+func (d *BTreeDict[K, V]) From(key K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(d.IsBTreeDict, "B-tree invariant holds")
+
+	return newBTreeDictIterator[K, V](d, &key, nil)
+}
+
+// Height returns the number of levels, which is O(log n / log degree)
+func (d *BTreeDict[K, V]) Height() (result int) {
+	depth, _ := d.leafDepth(d.root)
+	return depth + 1
+}
@@ -0,0 +1,185 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestBTreeDict(t *testing.T) {
+	dict := NewBTreeDict[string, int](2, order.StringComp)
+
+	_, ok := dict.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, dict.Size())
+
+	dict.Put("b", 2)
+	dict.Put("a", 1)
+	dict.Put("c", 3)
+	dict.Put("d", 4)
+	dict.Put("a", 10)
+	assert.Equal(t, 4, dict.Size())
+	assert.Equal(t, 2, dict.Height())
+	value, ok := dict.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, dict.Keys().ToArray())
+
+	dict.Delete("e")
+	dict.Delete("b")
+	assert.Equal(t, []string{"a", "c", "d"}, dict.Keys().ToArray())
+	assert.Equal(t, []int{10, 3, 4}, dict.Values())
+
+	dict.Clear()
+	assert.Equal(t, 0, dict.Size())
+	assert.Equal(t, 1, dict.Height())
+	assert.True(t, dict.IsBTreeDict())
+}
+
+func TestBTreeDict_Random(t *testing.T) {
+	for _, degree := range []int{2, 3} {
+		dict := NewBTreeDict[int, int](degree, order.IntComp)
+		testRandomUpdates(t, dict, 24, 60, 300, dict.IsBTreeDict)
+	}
+}
+
+func TestBTreeDict_Iterator(t *testing.T) {
+	dict := NewBTreeDict[int, int](2, order.IntComp)
+	for _, k := range rand.New(rand.NewSource(5)).Perm(40) {
+		dict.Put(k, -k)
+	}
+
+	var keys []int
+	for it := dict.Iterator(); it.HasNext(); {
+		e := it.Next()
+		assert.Equal(t, -e.Key(), e.Value())
+		keys = append(keys, e.Key())
+	}
+	assert.Equal(t, dict.Keys().ToArray(), keys)
+	assert.Len(t, keys, 40)
+
+	keys = keys[:0]
+	for it := dict.Range(10, 15); it.HasNext(); {
+		keys = append(keys, it.Next().Key())
+	}
+	assert.Equal(t, []int{10, 11, 12, 13, 14}, keys)
+
+	keys = keys[:0]
+	for it := dict.From(37); it.HasNext(); {
+		keys = append(keys, it.Next().Key())
+	}
+	assert.Equal(t, []int{37, 38, 39}, keys)
+	assert.False(t, dict.Range(7, 7).HasNext())
+	assert.False(t, dict.From(40).HasNext())
+
+	for it := dict.Iterator(); it.HasNext(); {
+		if it.Next().Key()%3 != 0 {
+			it.Remove()
+		}
+	}
+	assert.Equal(t, []int{0, 3, 6, 9, 12, 15, 18, 21, 24, 27, 30, 33, 36, 39}, dict.Keys().ToArray())
+
+	it := dict.Iterator()
+	dict.Put(100, 100)
+	assert.Panics(t, func() { it.HasNext() })
+}
+
+func TestBTreeDict_FromSorted(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+	for _, degree := range []int{2, 3, 5} {
+		for n := 0; n < 80; n++ {
+			keys, values := make([]int, n), make([]string, n)
+			for i := range keys {
+				keys[i], values[i] = 2*i, string(rune('a'+i%26))
+			}
+			dict := NewBTreeDictFromSorted(degree, order.IntComp, keys, values)
+			assert.True(t, dict.IsBTreeDict())
+			assert.Equal(t, n, dict.Size())
+			assert.Equal(t, keys, append([]int{}, dict.Keys().ToArray()...))
+			assert.Equal(t, values, append([]string{}, dict.Values()...))
+		}
+	}
+
+	dict := NewBTreeDictFromSorted(2, order.IntComp, []int{1, 2, 3, 4, 5, 6, 7}, []int{1, 2, 3, 4, 5, 6, 7})
+	assert.Equal(t, 2, dict.Height())
+	dict.Put(0, 0)
+	dict.Delete(4)
+	assert.Equal(t, []int{0, 1, 2, 3, 5, 6, 7}, dict.Keys().ToArray())
+	assert.Panics(t, func() { NewBTreeDictFromSorted(2, order.IntComp, []int{2, 1}, []int{2, 1}) })
+}
+
+func TestBTreeDict_IsBTreeDict(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+
+	dict := NewBTreeDictFromSorted(2, order.IntComp, []int{1, 2, 3, 4, 5, 6, 7}, []int{1, 2, 3, 4, 5, 6, 7})
+	left, right := dict.root.children[0], dict.root.children[1]
+	swap := func() { left.keys[0], left.keys[1] = left.keys[1], left.keys[0] }
+	keys, values := right.keys, right.values
+	testInvariantBreaks(t, dict.IsBTreeDict, []invariantBreak{
+		{"keys are unsorted", swap, swap},
+		{"node is underfull", func() { right.keys, right.values = keys[:0], values[:0] },
+			func() { right.keys, right.values = keys, values }},
+	})
+
+	assert.Panics(t, func() { NewBTreeDict[int, int](1, order.IntComp) })
+}
+
+func TestBTreeDict_ComputeWalksDownOnce(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelOff))
+	comparisons := 0
+	counting := func(a, b int) int {
+		comparisons++
+		return order.IntComp(a, b)
+	}
+	cost := func(f func()) int {
+		comparisons = 0
+		f()
+		return comparisons
+	}
+
+	for _, degree := range []int{2, 3} {
+		dict := NewBTreeDict[int, int](degree, counting)
+		keys := rand.New(rand.NewSource(24)).Perm(1 << 9)
+		for _, k := range keys {
+			lookup := cost(func() { dict.Get(k) })
+			inserted := cost(func() { dict.Compute(k, func(int, bool) (int, bool) { return k, true }) })
+			assert.LessOrEqual(t, inserted-cost(func() { dict.Get(k) }), lookup, "the postcondition looks key up once more")
+		}
+		for _, k := range keys {
+			lookup := cost(func() { dict.Get(k) })
+			removed := cost(func() { dict.Compute(k, func(int, bool) (int, bool) { return 0, false }) })
+			assert.LessOrEqual(t, removed-cost(func() { dict.Get(k) }), lookup, "the postcondition looks key up once more")
+		}
+
+		contract.SetLevel(contract.LevelFull)
+		assert.True(t, dict.IsBTreeDict())
+		assert.Equal(t, 0, dict.Size())
+		contract.SetLevel(contract.LevelOff)
+	}
+}
+
+func TestBTreeDict_ConditionalUpdates(t *testing.T) {
+	testConditionalUpdates(t, NewBTreeDict[string, int](2, order.StringComp))
+}
+
+func TestBTreeDict_All(t *testing.T) {
+	dict := NewBTreeDict[int, int](3, order.IntComp)
+	for k := 0; k < 20; k++ {
+		dict.Put(k, k)
+	}
+
+	var keys []int
+	dict.All()(func(k, _ int) bool {
+		keys = append(keys, k)
+		return k < 4
+	})
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, keys)
+	assert.Equal(t, dict.Keys().ToArray(), iterator.Collect[int](iterator.Map[Entry[int, int], int](dict.Iterator(), Entry[int, int].Key)))
+}
+
+func BenchmarkBTreeDict(b *testing.B) {
+	benchmarkTreeDict(b, func() Dict[int, int] { return NewBTreeDict[int, int](16, order.IntComp) })
+}
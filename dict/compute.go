@@ -0,0 +1,88 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/linked"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+)
+
+// computer is implemented by every Dict in a single traversal, the other conditional updates build on it
+type computer[K any, V any] interface {
+	Compute(key K, fn func(value V, found bool) (newValue V, keep bool)) (V, bool)
+}
+
+func putIfAbsent[K any, V any](d computer[K, V], key K, value V) (actual V, loaded bool) {
+	actual, _ = d.Compute(key, func(old V, found bool) (V, bool) {
+		loaded = found
+		if found {
+			return old, true
+		}
+		return value, true
+	})
+
+	return
+}
+
+func computeIfAbsent[K any, V any](d computer[K, V], key K, fn func(K) V) (result V) {
+	result, _ = d.Compute(key, func(old V, found bool) (V, bool) {
+		if found {
+			return old, true
+		}
+		return fn(key), true
+	})
+
+	return
+}
+
+func merge[K any, V any](d computer[K, V], key K, value V, fn func(old, value V) V) (result V) {
+	result, _ = d.Compute(key, func(old V, found bool) (V, bool) {
+		if found {
+			return fn(old, value), true
+		}
+		return value, true
+	})
+
+	return
+}
+
+func replace[K any, V any](d computer[K, V], key K, value V) (previous V, replaced bool) {
+	d.Compute(key, func(old V, found bool) (V, bool) {
+		previous, replaced = old, found
+		return value, found
+	})
+
+	return
+}
+
+// keysOf lists the keys in the order of all, the list compares them with equalFn
+func keysOf[K any, V any](all iterator.Seq2[K, V], equalFn order.Equaler[K]) (result *linked.List[K]) {
+	var keys []K
+	all(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+
+	result = linked.NewEmptyListFunc(equalFn)
+	for i := len(keys) - 1; i >= 0; i-- {
+		result.Add(keys[i])
+	}
+	return
+}
+
+func valuesOf[K any, V any](all iterator.Seq2[K, V]) (result []V) {
+	all(func(_ K, v V) bool {
+		result = append(result, v)
+		return true
+	})
+
+	return
+}
+
+func entriesOf[K any, V any](all iterator.Seq2[K, V]) (result []Entry[K, V]) {
+	all(func(k K, v V) bool {
+		result = append(result, keyValue[K, V]{key: k, value: v})
+		return true
+	})
+
+	return
+}
@@ -0,0 +1,120 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func testConditionalUpdates(t *testing.T, dict Dict[string, int]) {
+	assert.Equal(t, -1, dict.GetOrDefault("a", -1))
+
+	actual, loaded := dict.PutIfAbsent("a", 1)
+	assert.False(t, loaded)
+	assert.Equal(t, 1, actual)
+	actual, loaded = dict.PutIfAbsent("a", 2)
+	assert.True(t, loaded)
+	assert.Equal(t, 1, actual)
+	assert.Equal(t, 1, dict.GetOrDefault("a", -1))
+
+	previous, replaced := dict.Replace("b", 2)
+	assert.False(t, replaced)
+	assert.Equal(t, 0, previous)
+	_, ok := dict.Get("b")
+	assert.False(t, ok)
+	previous, replaced = dict.Replace("a", 3)
+	assert.True(t, replaced)
+	assert.Equal(t, 1, previous)
+
+	calls := 0
+	length := func(k string) int {
+		calls++
+		return len(k)
+	}
+	assert.Equal(t, 3, dict.ComputeIfAbsent("bcd", length))
+	assert.Equal(t, 3, dict.ComputeIfAbsent("bcd", length))
+	assert.Equal(t, 1, calls)
+
+	for _, w := range strings.Fields("x y x z x y") {
+		dict.Merge(w, 1, func(old, value int) int { return old + value })
+	}
+	assert.Equal(t, 3, dict.GetOrDefault("x", 0))
+	assert.Equal(t, 2, dict.GetOrDefault("y", 0))
+	assert.Equal(t, 1, dict.GetOrDefault("z", 0))
+
+	value, present := dict.Compute("x", func(old int, found bool) (int, bool) {
+		assert.True(t, found)
+		return old * 10, true
+	})
+	assert.True(t, present)
+	assert.Equal(t, 30, value)
+	_, present = dict.Compute("y", func(int, bool) (int, bool) { return 0, false })
+	assert.False(t, present)
+	_, ok = dict.Get("y")
+	assert.False(t, ok)
+	_, present = dict.Compute("w", func(old int, found bool) (int, bool) {
+		assert.False(t, found)
+		return 0, false
+	})
+	assert.False(t, present)
+	value, present = dict.Compute("w", func(old int, found bool) (int, bool) { return 7, true })
+	assert.True(t, present)
+	assert.Equal(t, 7, value)
+
+	assert.Equal(t, 5, dict.Size())
+	assert.ElementsMatch(t, []string{"a", "bcd", "w", "x", "z"}, dict.Keys().ToArray())
+	assert.ElementsMatch(t, []int{3, 3, 7, 30, 1}, dict.Values())
+	entries := dict.Entries()
+	assert.Len(t, entries, 5)
+	for i, e := range entries {
+		assert.Equal(t, dict.Keys().ToArray()[i], e.Key())
+		assert.Equal(t, dict.Values()[i], e.Value())
+	}
+
+	it := dict.Iterator()
+	dict.Clear()
+	assert.Equal(t, 0, dict.Size())
+	assert.Empty(t, dict.Keys().ToArray())
+	assert.Panics(t, func() { it.HasNext() })
+	dict.Put("a", 1)
+	assert.Equal(t, 1, dict.GetOrDefault("a", 0))
+}
+
+func TestHashDict_ConditionalUpdates(t *testing.T) {
+	testConditionalUpdates(t, NewHashDict[string, int](1, nil, 1))
+}
+
+func TestBSTDict_ConditionalUpdates(t *testing.T) {
+	dict := NewBSTDict[string, int](order.StringComp)
+	testConditionalUpdates(t, dict)
+	assert.Equal(t, []string{"a"}, dict.Keys().ToArray())
+}
+
+func TestAVLDict_ConditionalUpdates(t *testing.T) {
+	testConditionalUpdates(t, NewAVLDict[string, int](order.StringComp))
+}
+
+func TestProbingDict_ConditionalUpdates(t *testing.T) {
+	for _, probing := range probings {
+		testConditionalUpdates(t, NewProbingDict[string, int](1, nil, probing))
+	}
+}
+
+func TestSwissDict_ConditionalUpdates(t *testing.T) {
+	testConditionalUpdates(t, NewSwissDict[string, int](1, nil))
+}
+
+func TestAVLDict_ComputeRemovesBalanced(t *testing.T) {
+	dict := NewAVLDict[int, int](order.IntComp)
+	for i := 0; i < 32; i++ {
+		dict.Compute(i, func(int, bool) (int, bool) { return i, true })
+	}
+	for i := 0; i < 32; i += 2 {
+		dict.Compute(i, func(int, bool) (int, bool) { return 0, false })
+	}
+
+	assert.Equal(t, 16, dict.Size())
+	assert.True(t, dict.IsAVLDict())
+	assert.LessOrEqual(t, dict.tree.Root.GetHeight(), 6)
+}
@@ -4,3 +4,17 @@ type entry[K any, V any] struct {
 	Key   K
 	Value V
 }
+
+// keyValue is the Entry handed out by iterators, so callers cannot modify stored entries
+type keyValue[K any, V any] struct {
+	key   K
+	value V
+}
+
+func (kv keyValue[K, V]) Key() K {
+	return kv.key
+}
+
+func (kv keyValue[K, V]) Value() V {
+	return kv.value
+}
@@ -3,21 +3,72 @@ package dict
 import (
 	"github.com/song-flying/GoDataStructures/linked"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/hash"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
 )
 
+// HashFn is the hash function type of the constructors comparing with ==, it converts to hash.Hasher[K]
 type HashFn[K comparable] func(K) int
 
-type HashDict[K comparable, V comparable] struct {
-	size     int
-	capacity int
-	table    []linked.List[entry[K, V]]
-	hashFn   HashFn[K]
-	maxLoad  int
+// migrateStep is the count of old chains an insertion or deletion moves while a resize is in progress
+const migrateStep = 4
+
+// HashDict resizes incrementally like Redis dicts: a resize only allocates the new table,
+// the chains of the old one are moved over a few at a time by the following insertions and deletions.
+// An entry stays in the old table until its chain is moved, lookups never move chains so that they stay read-only.
+// Reseeding moves the entries the same way, the old table stays placed by oldSeed until it is dropped.
+type HashDict[K any, V any] struct {
+	size       int
+	capacity   int
+	table      []*linked.Node[entry[K, V]]
+	oldTable   []*linked.Node[entry[K, V]]
+	migrated   int
+	hashFn     hash.KeyedHasher[K]
+	seed       hash.Seed
+	oldSeed    hash.Seed
+	reseedKey  *K // key whose too long chain started the reseed in progress
+	equalFn    order.Equaler[K]
+	maxLoad    int
+	modCount   int
+	chainLimit int
+	reseeds    int
+	resizes    int
+	probeLog   *hash.ProbeLog
+}
+
+// tables returns the chains not moved yet during a resize followed by the chains of the current table
+func (h *HashDict[K, V]) tables() [][]*linked.Node[entry[K, V]] {
+	if h.oldTable == nil {
+		return [][]*linked.Node[entry[K, V]]{h.table}
+	}
+
+	return [][]*linked.Node[entry[K, V]]{h.oldTable[h.migrated:], h.table}
 }
 
 func (h *HashDict[K, V]) listOK() bool {
-	for _, l := range h.table {
-		if !l.IsList() {
+	for _, table := range h.tables() {
+		for _, chain := range table {
+			if !chain.IsList() {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// migrationOK holds when no resize is in progress, or the chains of the old table before migrated are all moved
+func (h *HashDict[K, V]) migrationOK() bool {
+	if h.oldTable == nil {
+		return h.migrated == 0
+	}
+	if h.migrated < 0 || len(h.oldTable) <= h.migrated {
+		return false
+	}
+
+	for _, chain := range h.oldTable[:h.migrated] {
+		if chain != nil {
 			return false
 		}
 	}
@@ -25,11 +76,19 @@ func (h *HashDict[K, V]) listOK() bool {
 	return true
 }
 
+// hashOK holds when every key is in its chain of the old table if that is not moved yet, else in its chain of the current table
 func (h *HashDict[K, V]) hashOK() bool {
-	for i, l := range h.table {
-		for curr := l.Head; curr != nil; curr = curr.Next {
-			hashIndex := h.indexOfKey(curr.Data.Key)
-			if i != hashIndex {
+	for i, chain := range h.table {
+		for curr := chain; curr != nil; curr = curr.Next {
+			if i != h.indexOfKey(curr.Data.Key) || h.oldTable != nil && h.oldIndexOfKey(curr.Data.Key) >= h.migrated {
+				return false
+			}
+		}
+	}
+
+	for i := h.migrated; i < len(h.oldTable); i++ {
+		for curr := h.oldTable[i]; curr != nil; curr = curr.Next {
+			if i != h.oldIndexOfKey(curr.Data.Key) {
 				return false
 			}
 		}
@@ -40,8 +99,10 @@ func (h *HashDict[K, V]) hashOK() bool {
 
 func (h *HashDict[K, V]) sizeOK() bool {
 	size := 0
-	for _, l := range h.table {
-		size += l.Length()
+	for _, table := range h.tables() {
+		for _, chain := range table {
+			size += h.chainLength(chain)
+		}
 	}
 
 	return h.size == size
@@ -50,24 +111,48 @@ func (h *HashDict[K, V]) sizeOK() bool {
 // IsHashDict data structure invariant
 func (h *HashDict[K, V]) IsHashDict() bool {
 	return h != nil && 0 <= h.size && 0 < h.capacity && len(h.table) == h.capacity &&
-		0 < h.maxLoad && h.listOK() && h.hashOK() && h.sizeOK()
+		h.hashFn != nil && h.equalFn != nil && 0 < h.maxLoad && 0 < h.chainLimit && h.listOK() && h.migrationOK() && h.hashOK() && h.sizeOK()
+}
+
+// NewHashDict compares keys with ==, a nil hashFn stands for hash.OfKeyed[K](), which hashes strings with SipHash.
+// Only SipHash hashers resist flooding with colliding keys: an unkeyed hashFn, like the Hasher given to NewHashDictFunc,
+// is seeded after hashing, so keys colliding under it collide under every seed.
+func NewHashDict[K comparable, V any](capacity int, hashFn HashFn[K], maxLoad int) (result *HashDict[K, V]) {
+	if hashFn == nil {
+		return NewHashDictKeyed[K, V](capacity, hash.OfKeyed[K](), order.Equal[K], maxLoad)
+	}
+
+	return NewHashDictFunc[K, V](capacity, hash.Hasher[K](hashFn), order.Equal[K], maxLoad)
+}
+
+// NewHashDictFunc compares keys with equalFn, hashFn must agree with it
+func NewHashDictFunc[K any, V any](capacity int, hashFn hash.Hasher[K], equalFn order.Equaler[K], maxLoad int) (result *HashDict[K, V]) {
+	contract.Require(hashFn != nil, "hash function is not nil")
+
+	return NewHashDictKeyed[K, V](capacity, hash.Keyed(hashFn), equalFn, maxLoad)
 }
 
-func NewHashDict[K comparable, V comparable](capacity int, hashFn HashFn[K], maxLoad int) (result *HashDict[K, V]) {
+// NewHashDictKeyed hashes with a KeyedHasher like hash.SipString, so that chains stay short even for keys chosen by an attacker.
+// Whenever a chain grows longer than maxLoad*hash.DefaultChainLimit, all keys are rehashed with a fresh random seed.
+func NewHashDictKeyed[K any, V any](capacity int, hashFn hash.KeyedHasher[K], equalFn order.Equaler[K], maxLoad int) (result *HashDict[K, V]) {
 	contract.Require(0 < capacity, "capacity is positive")
 	contract.Require(0 < maxLoad, "maxLoad is positive")
 	contract.Require(hashFn != nil, "hash function is not nil")
+	contract.Require(equalFn != nil, "equality function is not nil")
 	defer func() {
-		contract.Ensure(result.IsHashDict(), "hash dict invariant holds")
+		contract.EnsureInvariant(result.IsHashDict, "hash dict invariant holds")
 	}()
 
-	table := make([]linked.List[entry[K, V]], capacity)
+	table := make([]*linked.Node[entry[K, V]], capacity)
 	return &HashDict[K, V]{
-		size:     0,
-		capacity: capacity,
-		table:    table,
-		hashFn:   hashFn,
-		maxLoad:  maxLoad,
+		size:       0,
+		capacity:   capacity,
+		table:      table,
+		hashFn:     hashFn,
+		seed:       hash.RandomSeed(),
+		equalFn:    equalFn,
+		maxLoad:    maxLoad,
+		chainLimit: maxLoad * hash.DefaultChainLimit,
 	}
 }
 
@@ -90,89 +175,184 @@ func (h *HashDict[K, V]) indexOfKey(key K) (result int) {
 		contract.Ensure(0 <= result && result < h.capacity, "result is within bound")
 	}()
 
-	return abs(h.hashFn(key) % h.capacity)
+	return int(uint64(h.hashFn(h.seed, key)) % uint64(h.capacity))
 }
 
-func (h *HashDict[K, V]) Get(key K) (result V, found bool) {
-	contract.Require(h.IsHashDict(), "hash dict invariant holds")
+func (h *HashDict[K, V]) oldIndexOfKey(key K) (result int) {
+	contract.Require(h.oldTable != nil, "resize is in progress")
+	defer func() {
+		contract.Ensure(0 <= result && result < len(h.oldTable), "result is within bound")
+	}()
 
-	index := h.indexOfKey(key)
-	l := &h.table[index]
+	return int(uint64(h.hashFn(h.oldSeed, key)) % uint64(len(h.oldTable)))
+}
 
-	for curr := l.Head; curr != nil; curr = curr.Next {
-		if curr.Data.Key == key {
-			return curr.Data.Value, true
+// chainOf returns the head of the chain key belongs to
+func (h *HashDict[K, V]) chainOf(key K) **linked.Node[entry[K, V]] {
+	if h.oldTable != nil {
+		if i := h.oldIndexOfKey(key); i >= h.migrated {
+			return &h.oldTable[i]
 		}
 	}
 
-	return *new(V), false
+	return &h.table[h.indexOfKey(key)]
+}
+
+// find returns the node of key, or nil, and the count of nodes it probed.
+// It records nothing, so that lookups made by contracts leave Stats alone.
+func (h *HashDict[K, V]) find(key K) (result *linked.Node[entry[K, V]], probes int) {
+	for curr := *h.chainOf(key); curr != nil; curr = curr.Next {
+		probes++
+		if h.equalFn(curr.Data.Key, key) {
+			return curr, probes
+		}
+	}
+
+	return nil, probes
+}
+
+func (h *HashDict[K, V]) Get(key K) (result V, found bool) {
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+
+	node, probes := h.find(key)
+	h.probeLog.Record(probes)
+	if node == nil {
+		return *new(V), false
+	}
+
+	return node.Data.Value, true
 }
 
 func (h *HashDict[K, V]) Put(key K, value V) {
-	contract.Require(h.IsHashDict(), "hash dict invariant holds")
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
 	defer func() {
-		contract.Ensure(h.IsHashDict(), "hash dict invariant holds")
-		v, ok := h.Get(key)
-		contract.Ensure(ok && v == value, "Get(key) returns value")
+		contract.EnsureInvariant(h.IsHashDict, "hash dict invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			node, _ := h.find(key)
+			contract.Ensure(node != nil, "Get(key) finds key")
+		}
 	}()
 
-	index := h.indexOfKey(key)
-	l := &h.table[index]
-	for curr := l.Head; curr != nil; curr = curr.Next {
-		if curr.Data.Key == key {
+	for curr := *h.chainOf(key); curr != nil; curr = curr.Next {
+		if h.equalFn(curr.Data.Key, key) {
 			curr.Data.Value = value
 			return
 		}
 	}
 
+	h.insert(key, value)
+}
+
+// insert prepends key, which is not in h, to its chain and grows or reseeds if needed
+func (h *HashDict[K, V]) insert(key K, value V) {
+	h.migrate(migrateStep)
+	head := h.chainOf(key)
 	newHead := linked.NewNode(entry[K, V]{Key: key, Value: value})
-	newHead.Next = l.Head
-	l.Head = &newHead
+	newHead.Next = *head
+	*head = &newHead
 	h.size++
+	h.modCount++
 
-	if h.size >= h.capacity*h.maxLoad {
+	if h.oldTable == nil && h.size >= h.capacity*h.maxLoad {
 		h.resize(h.capacity * 2)
+	} else if h.oldTable == nil && h.chainLength(*head) > h.chainLimit {
+		h.reseed(key)
 	}
 }
 
 func (h *HashDict[K, V]) Delete(key K) {
-	contract.Require(h.IsHashDict(), "hash dict invariant holds")
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
 	defer func() {
-		contract.Ensure(h.IsHashDict(), "hash dict invariant holds")
-		_, ok := h.Get(key)
-		contract.Ensure(!ok, "Get() returns no value for key")
+		contract.EnsureInvariant(h.IsHashDict, "hash dict invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			node, _ := h.find(key)
+			contract.Ensure(node == nil, "Get() returns no value for key")
+		}
 	}()
 
-	index := h.indexOfKey(key)
-	l := &h.table[index]
-	if l.Head == nil {
-		return
+	if h.unlink(key) {
+		h.shrink()
 	}
+}
 
-	isDeleted := false
-	for curr := &l.Head; *curr != nil; curr = &(*curr).Next {
-		if (*curr).Data.Key == key {
-			target := *curr
-			*curr = target.Next
-			target.Next = nil
-			isDeleted = true
-			break
-		}
-		if (*curr).Next == nil {
-			break
+// shrink goes on with a resize in progress after a deletion or shrinks the table if it got sparse
+func (h *HashDict[K, V]) shrink() {
+	h.migrate(migrateStep)
+	if h.oldTable == nil && 4*h.size <= h.capacity*h.maxLoad {
+		h.resize((h.capacity + 1) / 2)
+	}
+}
+
+// unlink removes key from its chain without moving chains, so that iterators can remove too
+func (h *HashDict[K, V]) unlink(key K) bool {
+	for curr := h.chainOf(key); *curr != nil; curr = &(*curr).Next {
+		if h.equalFn((*curr).Data.Key, key) {
+			h.unlinkAt(curr)
+			return true
 		}
 	}
 
-	if isDeleted {
-		h.size--
-		if 4*h.size <= h.capacity*h.maxLoad {
-			h.resize((h.capacity + 1) / 2)
+	return false
+}
+
+func (h *HashDict[K, V]) unlinkAt(curr **linked.Node[entry[K, V]]) {
+	target := *curr
+	*curr = target.Next
+	target.Next = nil
+	h.size--
+	h.modCount++
+}
+
+// Compute walks the chain of key once, and removes or inserts right there
+func (h *HashDict[K, V]) Compute(key K, fn func(value V, found bool) (newValue V, keep bool)) (result V, present bool) {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+	defer func() {
+		contract.EnsureInvariant(h.IsHashDict, "hash dict invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			node, _ := h.find(key)
+			contract.Ensure((node != nil) == present, "Get(key) finds key iff it is present")
+		}
+	}()
+
+	for curr := h.chainOf(key); *curr != nil; curr = &(*curr).Next {
+		if h.equalFn((*curr).Data.Key, key) {
+			if value, keep := fn((*curr).Data.Value, true); keep {
+				(*curr).Data.Value = value
+				return value, true
+			}
+			h.unlinkAt(curr)
+			h.shrink()
+			return *new(V), false
 		}
 	}
+
+	value, keep := fn(*new(V), false)
+	if !keep {
+		return *new(V), false
+	}
+	h.insert(key, value)
+	return value, true
+}
+
+// Clear keeps the capacity
+func (h *HashDict[K, V]) Clear() {
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+	defer func() {
+		contract.EnsureInvariant(h.IsHashDict, "hash dict invariant holds")
+		contract.Ensure(h.size == 0, "dict is empty")
+	}()
+
+	h.table = make([]*linked.Node[entry[K, V]], h.capacity)
+	h.oldTable = nil
+	h.migrated = 0
+	h.reseedKey = nil
+	h.size = 0
+	h.modCount++
 }
 
 func (h *HashDict[K, V]) Size() (result int) {
-	contract.Require(h.IsHashDict(), "hash dict invariant holds")
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
 	defer func() {
 		contract.Ensure(result >= 0, "result is non-negative")
 	}()
@@ -180,10 +360,75 @@ func (h *HashDict[K, V]) Size() (result int) {
 	return h.size
 }
 
+func (h *HashDict[K, V]) GetOrDefault(key K, def V) V {
+	if value, found := h.Get(key); found {
+		return value
+	}
+
+	return def
+}
+
+func (h *HashDict[K, V]) PutIfAbsent(key K, value V) (actual V, loaded bool) {
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+
+	return putIfAbsent[K, V](h, key, value)
+}
+
+func (h *HashDict[K, V]) ComputeIfAbsent(key K, fn func(K) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+
+	return computeIfAbsent[K, V](h, key, fn)
+}
+
+func (h *HashDict[K, V]) Merge(key K, value V, fn func(old, value V) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+
+	return merge[K, V](h, key, value, fn)
+}
+
+func (h *HashDict[K, V]) Replace(key K, value V) (previous V, replaced bool) {
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+
+	return replace[K, V](h, key, value)
+}
+
+func (h *HashDict[K, V]) Keys() (result *linked.List[K]) {
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+	defer func() {
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(result.Length() == h.size, "result has all keys")
+		}
+	}()
+
+	return keysOf(h.All(), h.equalFn)
+}
+
+func (h *HashDict[K, V]) Values() (result []V) {
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == h.size, "result has all values")
+	}()
+
+	return valuesOf(h.All())
+}
+
+func (h *HashDict[K, V]) Entries() (result []Entry[K, V]) {
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == h.size, "result has all entries")
+	}()
+
+	return entriesOf(h.All())
+}
+
+// resize starts moving the entries into a new table of newCapacity chains
 func (h *HashDict[K, V]) resize(newCapacity int) {
-	contract.Require(h.IsHashDict(), "hash dict invariant holds")
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+	contract.Require(h.oldTable == nil, "no resize is in progress")
 	defer func(oldSize int) {
-		contract.Ensure(h.IsHashDict(), "hash dict invariant holds")
+		contract.EnsureInvariant(h.IsHashDict, "hash dict invariant holds")
 		contract.Ensure(oldSize == h.size, "resize does not change count of entries")
 		contract.Ensure(newCapacity == h.capacity, "resize changes capacity")
 	}(h.size)
@@ -192,28 +437,247 @@ func (h *HashDict[K, V]) resize(newCapacity int) {
 		return
 	}
 
+	h.oldTable = h.table
+	h.oldSeed = h.seed
+	h.table = make([]*linked.Node[entry[K, V]], newCapacity)
+	h.capacity = newCapacity
+	h.resizes++
+}
+
+// migrate moves up to n chains of the old table into the current one, visiting at most 10*n old chains,
+// and drops the old table once all of them are moved
+func (h *HashDict[K, V]) migrate(n int) {
+	for visits := 10 * n; h.oldTable != nil && n > 0 && visits > 0; visits-- {
+		if chain := h.oldTable[h.migrated]; chain != nil {
+			h.oldTable[h.migrated] = nil
+			h.relink(chain)
+			n--
+		}
+
+		h.migrated++
+		if h.migrated == len(h.oldTable) {
+			h.oldTable = nil
+			h.migrated = 0
+			h.reseeded()
+		}
+	}
+}
+
+// relink moves all nodes of chain into their chains of the current table
+func (h *HashDict[K, V]) relink(chain *linked.Node[entry[K, V]]) {
+	for curr := chain; curr != nil; {
+		next := curr.Next
+		index := h.indexOfKey(curr.Data.Key)
+		curr.Next = h.table[index]
+		h.table[index] = curr
+		curr = next
+	}
+}
+
+// rehash moves all nodes into a new table of newCapacity chains at once, placed by the current seed,
+// a resize or reseed in progress is finished first
+func (h *HashDict[K, V]) rehash(newCapacity int) {
+	for h.oldTable != nil {
+		h.migrate(migrateStep)
+	}
+
 	oldTable := h.table
-	h.table = make([]linked.List[entry[K, V]], newCapacity)
+	h.table = make([]*linked.Node[entry[K, V]], newCapacity)
 	h.capacity = newCapacity
-	h.size = 0
 
-	for _, l := range oldTable {
-		for curr := l.Head; curr != nil; curr = curr.Next {
-			h.Put(curr.Data.Key, curr.Data.Value)
+	for _, chain := range oldTable {
+		h.relink(chain)
+	}
+	h.modCount++
+}
+
+// reseed starts moving all entries into a new table placed by a fresh random seed once the chain of key got too long,
+// which takes bad luck or an attack. Like a resize, the chains are moved a few at a time by the following updates.
+func (h *HashDict[K, V]) reseed(key K) {
+	contract.Require(h.oldTable == nil, "no resize is in progress")
+
+	h.oldTable = h.table
+	h.oldSeed = h.seed
+	h.seed = hash.RandomSeed()
+	h.table = make([]*linked.Node[entry[K, V]], h.capacity)
+	h.reseedKey = &key
+	h.reseeds++
+}
+
+// reseeded is called once all chains are moved. If the chain of the key that started a reseed is still too long,
+// its keys collide under every seed, so chainLimit is doubled to not reseed on every insertion.
+func (h *HashDict[K, V]) reseeded() {
+	if h.reseedKey == nil {
+		return
+	}
+
+	for h.chainLength(*h.chainOf(*h.reseedKey)) > h.chainLimit {
+		h.chainLimit *= 2
+	}
+	h.reseedKey = nil
+}
+
+func (h *HashDict[K, V]) chainLength(chain *linked.Node[entry[K, V]]) (result int) {
+	for curr := chain; curr != nil; curr = curr.Next {
+		result++
+	}
+
+	return
+}
+
+// LongestChain returns the length of the longest chain
+func (h *HashDict[K, V]) LongestChain() (result int) {
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+
+	for _, table := range h.tables() {
+		for _, chain := range table {
+			if n := h.chainLength(chain); n > result {
+				result = n
+			}
 		}
 	}
+
+	return
 }
 
-func (h *HashDict[K, V]) Keys() (result *linked.List[K]) {
-	contract.Require(h.IsHashDict(), "hash invariant holds")
+// RecordProbes makes Stats report the probes of the last n lookups by Get, 0 stops recording
+func (h *HashDict[K, V]) RecordProbes(n int) {
+	contract.Require(0 <= n, "n is non-negative")
+
+	if n == 0 {
+		h.probeLog = nil
+	} else {
+		h.probeLog = hash.NewProbeLog(n)
+	}
+}
 
-	keys := linked.NewEmptyList[K]()
+// Stats takes O(capacity) to walk all chains, during a resize the chains of the old table not moved yet count too
+func (h *HashDict[K, V]) Stats() (result hash.TableStats) {
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+	defer func() {
+		contract.Ensure(len(result.ChainLengths) > 0 && result.ChainLengths[len(result.ChainLengths)-1] > 0, "histogram ends with the longest chain")
+	}()
 
-	for _, l := range h.table {
-		for curr := l.Head; curr != nil; curr = curr.Next {
-			keys.Add(curr.Data.Key)
+	result = hash.TableStats{
+		Capacity:   h.capacity,
+		Size:       h.size,
+		LoadFactor: float64(h.size) / float64(h.capacity),
+		Resizes:    h.resizes,
+		Reseeds:    h.reseeds,
+		Probes:     h.probeLog.Last(),
+	}
+	for _, table := range h.tables() {
+		for _, chain := range table {
+			n := h.chainLength(chain)
+			for len(result.ChainLengths) <= n {
+				result.ChainLengths = append(result.ChainLengths, 0)
+			}
+			result.ChainLengths[n]++
 		}
 	}
+	result.LongestChain = len(result.ChainLengths) - 1
 
-	return keys
+	return
+}
+
+// Reseeds returns how often a too long chain made this hash dict pick a fresh seed
+func (h *HashDict[K, V]) Reseeds() int {
+	return h.reseeds
+}
+
+// Seed returns the seed keying the hash function of this hash dict
+func (h *HashDict[K, V]) Seed() hash.Seed {
+	return h.seed
+}
+
+// SetSeed rehashes all entries with seed, which is random by default
+func (h *HashDict[K, V]) SetSeed(seed hash.Seed) {
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+	defer func(oldSize int) {
+		contract.EnsureInvariant(h.IsHashDict, "hash dict invariant holds")
+		contract.Ensure(oldSize == h.size, "rehashing does not change count of entries")
+		contract.Ensure(h.seed == seed, "rehashing changes seed")
+	}(h.size)
+
+	h.seed = seed
+	h.rehash(h.capacity)
+}
+
+type hashDictIterator[K any, V any] struct {
+	h        *HashDict[K, V]
+	tables   [][]*linked.Node[entry[K, V]]
+	curr     *linked.Node[entry[K, V]]
+	last     *linked.Node[entry[K, V]]
+	modCount int
+}
+
+func (it *hashDictIterator[K, V]) skipEmptyChains() {
+	for it.curr == nil && len(it.tables) > 0 {
+		if len(it.tables[0]) == 0 {
+			it.tables = it.tables[1:]
+			continue
+		}
+		it.curr = it.tables[0][0]
+		it.tables[0] = it.tables[0][1:]
+	}
+}
+
+func (it *hashDictIterator[K, V]) HasNext() bool {
+	iterator.CheckMods(it.h.modCount, it.modCount)
+
+	return it.curr != nil
+}
+
+func (it *hashDictIterator[K, V]) Next() Entry[K, V] {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	it.last = it.curr
+	it.curr = it.curr.Next
+	it.skipEmptyChains()
+
+	return keyValue[K, V]{key: it.last.Data.Key, value: it.last.Data.Value}
+}
+
+// Remove deletes the entry last returned by Next, the table is not shrunk until the next Delete
+func (it *hashDictIterator[K, V]) Remove() {
+	iterator.CheckMods(it.h.modCount, it.modCount)
+	contract.Require(it.last != nil, "Next is called after the last Remove")
+	defer func() {
+		contract.EnsureInvariant(it.h.IsHashDict, "hash dict invariant holds")
+	}()
+
+	it.h.unlink(it.last.Data.Key)
+	it.last = nil
+	it.modCount = it.h.modCount
+}
+
+// Iterator walks the entries in no particular order
+func (h *HashDict[K, V]) Iterator() iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+
+	it := &hashDictIterator[K, V]{
+		h:        h,
+		tables:   h.tables(),
+		modCount: h.modCount,
+	}
+	it.skipEmptyChains()
+
+	return it
+}
+
+// All walks the entries in no particular order
+func (h *HashDict[K, V]) All() iterator.Seq2[K, V] {
+	contract.RequireInvariant(h.IsHashDict, "hash dict invariant holds")
+
+	return iterator.FailFastSeq2(func(yield func(K, V) bool) {
+		for _, table := range h.tables() {
+			for _, chain := range table {
+				for curr := chain; curr != nil; curr = curr.Next {
+					if !yield(curr.Data.Key, curr.Data.Value) {
+						return
+					}
+				}
+			}
+		}
+	}, func() int { return h.modCount })
 }
@@ -1,9 +1,13 @@
 package dict
 
 import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
 	"github.com/song-flying/GoDataStructures/pkg/hash"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/stretchr/testify/assert"
 	"strconv"
+	"sync"
 	"testing"
 )
 
@@ -50,3 +54,344 @@ func TestHashDict(t *testing.T) {
 	}
 	assert.Equal(t, 4, dict.capacity)
 }
+
+func TestHashDict_Iterator(t *testing.T) {
+	var dict Dict[string, int] = NewHashDict[string, int](1, hash.String, 1)
+	assert.False(t, dict.Iterator().HasNext())
+
+	var keys []string
+	for i := 0; i < 10; i++ {
+		keys = append(keys, strconv.Itoa(i))
+		dict.Put(strconv.Itoa(i), i)
+	}
+
+	var fromIterator []string
+	for it := dict.Iterator(); it.HasNext(); {
+		e := it.Next()
+		assert.Equal(t, e.Key(), strconv.Itoa(e.Value()))
+		fromIterator = append(fromIterator, e.Key())
+	}
+	assert.ElementsMatch(t, keys, fromIterator)
+
+	var fromAll []string
+	dict.All()(func(k string, v int) bool {
+		fromAll = append(fromAll, k)
+		return len(fromAll) < 3
+	})
+	assert.Equal(t, fromIterator[:3], fromAll)
+}
+
+func TestHashDict_IteratorRemove(t *testing.T) {
+	dict := NewHashDict[int, string](1, hash.Universal[int], 1)
+	for i := 0; i < 10; i++ {
+		dict.Put(i, strconv.Itoa(i))
+	}
+
+	for it := dict.Iterator(); it.HasNext(); {
+		if it.Next().Key()%2 == 1 {
+			it.Remove()
+		}
+	}
+	var keys []int
+	dict.All()(func(k int, v string) bool {
+		keys = append(keys, k)
+		return true
+	})
+	assert.ElementsMatch(t, []int{0, 2, 4, 6, 8}, keys)
+	assert.Equal(t, 5, dict.Size())
+
+	it := dict.Iterator()
+	it.Next()
+	dict.Put(10, "10")
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.Remove() })
+
+	dict.Put(10, "ten")
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.Next() })
+}
+
+func TestHashDict_CustomEquality(t *testing.T) {
+	dict := NewHashDictFunc[[]int, []string](1, hash.Slice(hash.Universal[int]), order.Equivalent(order.Lexicographic(order.IntComp)), 1)
+	dict.Put([]int{1, 2}, []string{"a"})
+	dict.Put([]int{1}, []string{"b"})
+	dict.Put([]int{1, 2}, []string{"c", "d"})
+
+	v, ok := dict.Get([]int{1, 2})
+	assert.True(t, ok)
+	assert.Equal(t, []string{"c", "d"}, v)
+	assert.Equal(t, 2, dict.Size())
+
+	dict.Delete([]int{1})
+	_, ok = dict.Get([]int{1})
+	assert.False(t, ok)
+	assert.Equal(t, [][]int{{1, 2}}, dict.Keys().ToArray())
+
+	words := NewHashDictFunc[string, int](1, hash.CaseInsensitiveString, order.Equivalent(order.CaseInsensitiveComp), 1)
+	for _, w := range []string{"Go", "go", "GO", "rust"} {
+		n, _ := words.Get(w)
+		words.Put(w, n+1)
+	}
+	n, _ := words.Get("gO")
+	assert.Equal(t, 3, n)
+	assert.Equal(t, 2, words.Size())
+}
+
+func TestHashDict_DefaultHash(t *testing.T) {
+	dict := NewHashDict[int, string](1, nil, 1)
+	for i := 0; i < 100; i++ {
+		dict.Put(i, strconv.Itoa(i))
+	}
+
+	for i := 0; i < 100; i++ {
+		v, ok := dict.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, strconv.Itoa(i), v)
+	}
+}
+
+func TestHashDict_Seed(t *testing.T) {
+	testSeed(t, func() *HashDict[int, int] { return NewHashDict[int, int](1, nil, 1) },
+		func(dict *HashDict[int, int], key int) any { return dict.indexOfKey(key) })
+}
+
+// run with -race, readers share one dict while writers own theirs
+func TestHashDict_Concurrent(t *testing.T) {
+	const goroutines, n = 8, 200
+
+	shared := NewHashDict[string, int](1, nil, 1)
+	for i := 0; i < n; i++ {
+		shared.Put(strconv.Itoa(i), i)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				v, ok := shared.Get(strconv.Itoa(i))
+				assert.True(t, ok)
+				assert.Equal(t, i, v)
+			}
+		}()
+		go func(g int) {
+			defer wg.Done()
+			own := NewHashDict[string, int](1, nil, 1)
+			own.SetSeed(hash.FixedSeed(uint64(g), 0))
+			for i := 0; i < n; i++ {
+				own.Put(strconv.Itoa(i), g)
+			}
+			assert.Equal(t, n, own.Size())
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestHashDict_Reseed(t *testing.T) {
+	dict := NewHashDictKeyed[string, int](1024, hash.SipString, order.Equal[string], 1)
+	dict.SetSeed(hash.FixedSeed(1, 2))
+
+	// keys sharing a chain under the known seed, as an attacker would pick them
+	var keys []string
+	for i := 0; len(keys) <= hash.DefaultChainLimit; i++ {
+		if k := strconv.Itoa(i); dict.indexOfKey(k) == 0 {
+			keys = append(keys, k)
+		}
+	}
+	for i, k := range keys {
+		dict.Put(k, i)
+	}
+
+	assert.Equal(t, 1, dict.Reseeds())
+	assert.NotEqual(t, hash.FixedSeed(1, 2), dict.Seed())
+	assert.NotNil(t, dict.oldTable, "the entries are moved incrementally like on a resize")
+	for i := 0; dict.oldTable != nil; i++ {
+		dict.Put("other"+strconv.Itoa(i), i)
+	}
+	assert.Equal(t, 1, dict.Reseeds())
+	assert.LessOrEqual(t, dict.LongestChain(), hash.DefaultChainLimit)
+	for i, k := range keys {
+		v, ok := dict.Get(k)
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+}
+
+func TestHashDict_ReseedCollidingHasher(t *testing.T) {
+	// all keys collide under every seed, reseeding gives up instead of rehashing on every Put
+	dict := NewHashDict[int, int](1024, func(int) int { return 0 }, 1)
+	for i := 0; i < 100; i++ {
+		dict.Put(i, i)
+	}
+
+	assert.Equal(t, 100, dict.LongestChain())
+	assert.LessOrEqual(t, dict.Reseeds(), 4)
+	for i := 0; i < 100; i++ {
+		v, ok := dict.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+}
+
+func TestHashDict_DefaultHashesStringsWithSipHash(t *testing.T) {
+	dict := NewHashDict[string, int](1, nil, 1)
+	dict.SetSeed(hash.FixedSeed(1, 2))
+	assert.Equal(t, hash.SipString(hash.FixedSeed(1, 2), "go"), dict.hashFn(dict.Seed(), "go"))
+}
+
+func TestHashDict_Stats(t *testing.T) {
+	dict := NewHashDict[int, int](1, nil, 1)
+	for i := 0; i < 100; i++ {
+		dict.Put(i, i)
+	}
+
+	stats := dict.Stats()
+	assert.Equal(t, 128, stats.Capacity)
+	assert.Equal(t, 100, stats.Size)
+	assert.InDelta(t, 100.0/128, stats.LoadFactor, 1e-9)
+	assert.Equal(t, 7, stats.Resizes)
+	assert.Equal(t, dict.LongestChain(), stats.LongestChain)
+	assert.Equal(t, stats.LongestChain+1, len(stats.ChainLengths))
+	chains, entries := 0, 0
+	for n, count := range stats.ChainLengths {
+		chains += count
+		entries += n * count
+	}
+	assert.Equal(t, stats.Capacity, chains)
+	assert.Equal(t, stats.Size, entries)
+	assert.Empty(t, stats.Probes)
+
+	dict.RecordProbes(10)
+	for i := 0; i < 20; i++ {
+		dict.Get(i)
+	}
+	assert.Len(t, dict.Stats().Probes, 10)
+
+	dict.RecordProbes(0)
+	dict.Get(0)
+	assert.Empty(t, dict.Stats().Probes)
+}
+
+func TestHashDict_ProbesIgnoreContracts(t *testing.T) {
+	for _, level := range []contract.Level{contract.LevelOff, contract.LevelFull} {
+		restore := contract.SetLevel(level)
+		dict := NewHashDict[int, int](1, nil, 1)
+		dict.RecordProbes(10)
+		for i := 0; i < 20; i++ {
+			dict.Put(i, i)
+			dict.Compute(i, func(v int, _ bool) (int, bool) { return v, i%2 == 0 })
+			dict.Delete(i + 1)
+		}
+		assert.Empty(t, dict.Stats().Probes, "level %v", level)
+
+		dict.Get(0)
+		assert.Len(t, dict.Stats().Probes, 1, "level %v", level)
+		contract.SetLevel(restore)
+	}
+}
+
+func TestHashDict_StatsBadHasher(t *testing.T) {
+	good := NewHashDict[int, int](1, nil, 1)
+	bad := NewHashDict[int, int](1, func(x int) int { return x % 2 }, 1)
+	for _, d := range []*HashDict[int, int]{good, bad} {
+		d.RecordProbes(100)
+		for i := 0; i < 100; i++ {
+			d.Put(i, i)
+		}
+		for i := 0; i < 100; i++ {
+			d.Get(i)
+		}
+	}
+
+	assert.Less(t, good.Stats().MeanProbes(), 2.0)
+	assert.Greater(t, bad.Stats().MeanProbes(), 10.0)
+	assert.Equal(t, 2, bad.Stats().Capacity-bad.Stats().ChainLengths[0])
+}
+
+func TestHashDict_IncrementalResize(t *testing.T) {
+	dict := NewHashDict[int, int](64, nil, 1)
+	for i := 0; i < 64; i++ {
+		dict.Put(i, i)
+	}
+	assert.NotNil(t, dict.oldTable)
+	assert.Equal(t, 128, dict.capacity)
+
+	oldChains := func() (result int) {
+		for _, chain := range dict.oldTable[dict.migrated:] {
+			if chain != nil {
+				result++
+			}
+		}
+		return
+	}
+	for i := 64; dict.oldTable != nil; i++ {
+		before := oldChains()
+		dict.Put(i, i)
+		if dict.oldTable != nil {
+			assert.LessOrEqual(t, before-oldChains(), migrateStep)
+		}
+
+		for k := 0; k <= i; k++ {
+			v, ok := dict.Get(k)
+			assert.True(t, ok)
+			assert.Equal(t, k, v)
+		}
+		assert.ElementsMatch(t, dict.Keys().ToArray(), iterator.Collect[int](iterator.Map[Entry[int, int], int](dict.Iterator(), Entry[int, int].Key)))
+	}
+	assert.True(t, dict.IsHashDict())
+}
+
+func TestHashDict_IncrementalResizeDelete(t *testing.T) {
+	dict := NewHashDict[int, int](64, nil, 1)
+	for i := 0; i < 64; i++ {
+		dict.Put(i, i)
+	}
+	assert.NotNil(t, dict.oldTable)
+
+	for i := 0; i < 60; i++ {
+		dict.Delete(i)
+		_, ok := dict.Get(i)
+		assert.False(t, ok)
+		assert.Equal(t, 63-i, dict.Size())
+	}
+	for i := 60; i < 64; i++ {
+		v, ok := dict.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+	assert.Less(t, dict.capacity, 64)
+
+	dict.Put(64, 64)
+	for dict.oldTable == nil {
+		dict.Put(dict.Size()+64, 0)
+	}
+	dict.SetSeed(hash.FixedSeed(1, 2))
+	assert.Nil(t, dict.oldTable)
+	assert.True(t, dict.IsHashDict())
+}
+
+func TestHashDict_IsHashDictDuringResize(t *testing.T) {
+	dict := NewHashDict[int, int](64, nil, 1)
+	for i := 0; i < 64; i++ {
+		dict.Put(i, i)
+	}
+	assert.True(t, dict.IsHashDict())
+
+	// a moved chain put back before the migrated ones breaks the invariant
+	dict.Put(64, 64)
+	assert.NotNil(t, dict.oldTable)
+	assert.Less(t, 0, dict.migrated)
+	index := 0
+	for dict.table[index] == nil {
+		index++
+	}
+	dict.oldTable[0], dict.table[index] = dict.table[index], nil
+	assert.False(t, dict.IsHashDict())
+}
+
+func BenchmarkHashDict_Get(b *testing.B) {
+	benchmarkDictGet(b, NewHashDict[int, int](1, nil, 1))
+}
+
+func BenchmarkHashDict_Put(b *testing.B) {
+	benchmarkDictPut(b, func() Dict[int, int] { return NewHashDict[int, int](1, nil, 1) })
+}
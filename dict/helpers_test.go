@@ -0,0 +1,165 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/hash"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// testRandomUpdates runs random deletes, computes and puts of keys below keys against a map
+func testRandomUpdates(t *testing.T, dict Dict[int, int], seed int64, keys, steps int, isValid func() bool) {
+	r := rand.New(rand.NewSource(seed))
+	expected := map[int]int{}
+	for i := 0; i < steps; i++ {
+		k := r.Intn(keys)
+		switch r.Intn(4) {
+		case 0:
+			dict.Delete(k)
+			delete(expected, k)
+		case 1:
+			keep := i%2 == 0
+			dict.Compute(k, func(int, bool) (int, bool) { return i, keep })
+			if keep {
+				expected[k] = i
+			} else {
+				delete(expected, k)
+			}
+		default:
+			dict.Put(k, i)
+			expected[k] = i
+		}
+		assert.Equal(t, len(expected), dict.Size())
+	}
+
+	assert.True(t, isValid())
+	for k := 0; k < keys; k++ {
+		v, ok := dict.Get(k)
+		ev, eok := expected[k]
+		assert.Equal(t, eok, ok)
+		assert.Equal(t, ev, v)
+	}
+}
+
+// invariantBreak corrupts a dict with apply, undo puts it back
+type invariantBreak struct {
+	name        string
+	apply, undo func()
+}
+
+// testInvariantBreaks checks that isValid fails after each break and holds again after its undo
+func testInvariantBreaks(t *testing.T, isValid func() bool, breaks []invariantBreak) {
+	assert.True(t, isValid())
+	for _, b := range breaks {
+		b.apply()
+		assert.False(t, isValid(), b.name)
+		b.undo()
+		assert.True(t, isValid(), b.name)
+	}
+}
+
+// seededDict is a hash dict whose seed can be read and replaced
+type seededDict interface {
+	Dict[int, int]
+	Seed() hash.Seed
+	SetSeed(seed hash.Seed)
+}
+
+// testSeed checks that fresh dicts differ in seed and that sharing one puts every key in the same slot
+func testSeed[D seededDict](t *testing.T, newDict func() D, slotOf func(dict D, key int) any) {
+	a, b := newDict(), newDict()
+	assert.NotEqual(t, a.Seed(), b.Seed())
+
+	for i := 0; i < 100; i++ {
+		a.Put(i, i)
+		b.Put(i, i)
+	}
+	b.SetSeed(a.Seed())
+	assert.Equal(t, a.Seed(), b.Seed())
+	assert.Equal(t, 100, b.Size())
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, slotOf(a, i), slotOf(b, i))
+	}
+}
+
+func benchmarkTreeDict(b *testing.B, newDict func() Dict[int, int]) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelOff))
+	const n = 1 << 14
+	keys := rand.New(rand.NewSource(1)).Perm(n)
+
+	b.Run("Put", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dict := newDict()
+			for _, k := range keys {
+				dict.Put(k, k)
+			}
+		}
+	})
+	b.Run("PutDelete", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dict := newDict()
+			for _, k := range keys {
+				dict.Put(k, k)
+			}
+			for _, k := range keys {
+				dict.Delete(k)
+			}
+		}
+	})
+	b.Run("Get", func(b *testing.B) {
+		dict := newDict()
+		for _, k := range keys {
+			dict.Put(k, k)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			dict.Get(keys[i%n])
+		}
+	})
+}
+
+const benchSize = 1 << 16
+
+func benchmarkDictGet(b *testing.B, dict Dict[int, int]) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelOff))
+
+	for i := 0; i < benchSize; i++ {
+		dict.Put(i, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dict.Get(i % (2 * benchSize))
+	}
+}
+
+func benchmarkDictPut(b *testing.B, newDict func() Dict[int, int]) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelOff))
+
+	for i := 0; i < b.N; i++ {
+		dict := newDict()
+		for k := 0; k < 1024; k++ {
+			dict.Put(k, k)
+		}
+	}
+}
+
+func BenchmarkMap_Get(b *testing.B) {
+	m := map[int]int{}
+	for i := 0; i < benchSize; i++ {
+		m[i] = i
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m[i%(2*benchSize)]
+	}
+}
+
+func BenchmarkMap_Put(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		m := map[int]int{}
+		for k := 0; k < 1024; k++ {
+			m[k] = k
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/song-flying/GoDataStructures/tree"
+)
+
+// floorNode returns the node with the greatest key not greater than key, or less than key if strict
+func floorNode[K comparable, V any, N tree.SearchNode[entry[K, V], N]](root N, comp order.CompareFn[K], key K, strict bool) (result N) {
+	var none N
+	for node := root; node != none; {
+		if compResult := comp(node.GetData().Key, key); compResult < 0 || compResult == 0 && !strict {
+			result = node
+			node = node.GetRight()
+		} else {
+			node = node.GetLeft()
+		}
+	}
+
+	return
+}
+
+// ceilingNode returns the node with the least key not less than key, or greater than key if strict
+func ceilingNode[K comparable, V any, N tree.SearchNode[entry[K, V], N]](root N, comp order.CompareFn[K], key K, strict bool) (result N) {
+	var none N
+	for node := root; node != none; {
+		if compResult := comp(node.GetData().Key, key); compResult > 0 || compResult == 0 && !strict {
+			result = node
+			node = node.GetLeft()
+		} else {
+			node = node.GetRight()
+		}
+	}
+
+	return
+}
+
+func minNode[K comparable, V any, N tree.SearchNode[entry[K, V], N]](root N) N {
+	var none N
+	for root != none && root.GetLeft() != none {
+		root = root.GetLeft()
+	}
+
+	return root
+}
+
+func maxNode[K comparable, V any, N tree.SearchNode[entry[K, V], N]](root N) N {
+	var none N
+	for root != none && root.GetRight() != none {
+		root = root.GetRight()
+	}
+
+	return root
+}
+
+func nodeEntry[K comparable, V any, N tree.SearchNode[entry[K, V], N]](node N) (Entry[K, V], bool) {
+	var none N
+	if node == none {
+		return nil, false
+	}
+
+	return keyValue[K, V]{key: node.GetData().Key, value: node.GetData().Value}, true
+}
+
+// SubDict is a live view of the entries of a tree dict with keys within [lo, hi),
+// a nil bound leaves that side open. Updates of the view and of the dict show through each other.
+type SubDict[K comparable, V any] struct {
+	dict   treeDict[K, V]
+	lo, hi *K
+}
+
+func newSubDict[K comparable, V any](dict treeDict[K, V], lo, hi *K) *SubDict[K, V] {
+	contract.Require(lo == nil || hi == nil || dict.compareKeys(*lo, *hi) <= 0, "lo <= hi")
+
+	return &SubDict[K, V]{dict: dict, lo: lo, hi: hi}
+}
+
+func (v *SubDict[K, V]) inRange(key K) bool {
+	return (v.lo == nil || v.dict.compareKeys(key, *v.lo) >= 0) && (v.hi == nil || v.dict.compareKeys(key, *v.hi) < 0)
+}
+
+func (v *SubDict[K, V]) within(e Entry[K, V], ok bool) (Entry[K, V], bool) {
+	if !ok || !v.inRange(e.Key()) {
+		return nil, false
+	}
+
+	return e, true
+}
+
+func (v *SubDict[K, V]) Get(key K) (V, bool) {
+	if !v.inRange(key) {
+		return *new(V), false
+	}
+
+	return v.dict.Get(key)
+}
+
+func (v *SubDict[K, V]) Put(key K, value V) {
+	contract.Require(v.inRange(key), "key is within the view")
+
+	v.dict.Put(key, value)
+}
+
+// Delete ignores keys outside the view
+func (v *SubDict[K, V]) Delete(key K) {
+	if v.inRange(key) {
+		v.dict.Delete(key)
+	}
+}
+
+// Size counts the entries of the view in O(log n + size), the count is not cached so that updates of the dict show
+func (v *SubDict[K, V]) Size() int {
+	return iterator.Count[Entry[K, V]](v.Iterator())
+}
+
+func (v *SubDict[K, V]) Min() (Entry[K, V], bool) {
+	if v.lo == nil {
+		return v.within(v.dict.Min())
+	}
+
+	return v.within(v.dict.Ceiling(*v.lo))
+}
+
+func (v *SubDict[K, V]) Max() (Entry[K, V], bool) {
+	if v.hi == nil {
+		return v.within(v.dict.Max())
+	}
+
+	return v.within(v.dict.Lower(*v.hi))
+}
+
+func (v *SubDict[K, V]) Floor(key K) (Entry[K, V], bool) {
+	if v.hi != nil && v.dict.compareKeys(key, *v.hi) >= 0 {
+		return v.within(v.dict.Lower(*v.hi))
+	}
+
+	return v.within(v.dict.Floor(key))
+}
+
+func (v *SubDict[K, V]) Ceiling(key K) (Entry[K, V], bool) {
+	if v.lo != nil && v.dict.compareKeys(key, *v.lo) < 0 {
+		return v.within(v.dict.Ceiling(*v.lo))
+	}
+
+	return v.within(v.dict.Ceiling(key))
+}
+
+func (v *SubDict[K, V]) Lower(key K) (Entry[K, V], bool) {
+	if v.hi != nil && v.dict.compareKeys(key, *v.hi) > 0 {
+		return v.within(v.dict.Lower(*v.hi))
+	}
+
+	return v.within(v.dict.Lower(key))
+}
+
+func (v *SubDict[K, V]) Higher(key K) (Entry[K, V], bool) {
+	if v.lo != nil && v.dict.compareKeys(key, *v.lo) < 0 {
+		return v.within(v.dict.Ceiling(*v.lo))
+	}
+
+	return v.within(v.dict.Higher(key))
+}
+
+// PollFirst removes and returns the entry of the view with the least key
+func (v *SubDict[K, V]) PollFirst() (result Entry[K, V], ok bool) {
+	if result, ok = v.Min(); ok {
+		v.dict.Delete(result.Key())
+	}
+
+	return
+}
+
+// PollLast removes and returns the entry of the view with the greatest key
+func (v *SubDict[K, V]) PollLast() (result Entry[K, V], ok bool) {
+	if result, ok = v.Max(); ok {
+		v.dict.Delete(result.Key())
+	}
+
+	return
+}
+
+// narrow returns a live view of the keys within both v and [lo, hi), which is empty if they do not overlap
+func (v *SubDict[K, V]) narrow(lo, hi *K) *SubDict[K, V] {
+	if lo == nil || v.lo != nil && v.dict.compareKeys(*v.lo, *lo) > 0 {
+		lo = v.lo
+	}
+	if hi == nil || v.hi != nil && v.dict.compareKeys(*v.hi, *hi) < 0 {
+		hi = v.hi
+	}
+	if lo != nil && hi != nil && v.dict.compareKeys(*lo, *hi) > 0 {
+		hi = lo
+	}
+
+	return newSubDict[K, V](v.dict, lo, hi)
+}
+
+// HeadMap returns a live view of the entries of the view with keys less than to
+func (v *SubDict[K, V]) HeadMap(to K) *SubDict[K, V] {
+	return v.narrow(nil, &to)
+}
+
+// TailMap returns a live view of the entries of the view with keys not less than from
+func (v *SubDict[K, V]) TailMap(from K) *SubDict[K, V] {
+	return v.narrow(&from, nil)
+}
+
+// SubMap returns a live view of the entries of the view with keys within [from, to)
+func (v *SubDict[K, V]) SubMap(from, to K) *SubDict[K, V] {
+	contract.Require(v.dict.compareKeys(from, to) <= 0, "from <= to")
+
+	return v.narrow(&from, &to)
+}
+
+// Iterator walks the entries of the view in ascending key order
+func (v *SubDict[K, V]) Iterator() iterator.MutableIterator[Entry[K, V]] {
+	var afterStart, beforeEnd func(entry[K, V]) bool
+	if v.lo != nil {
+		afterStart = keyAtLeast[K, V](v.dict.compareKeys, *v.lo)
+	}
+	if v.hi != nil {
+		beforeEnd = keyBelow[K, V](v.dict.compareKeys, *v.hi)
+	}
+
+	return newTreeDictIterator[K, V](v.dict, false, afterStart, beforeEnd)
+}
+
+// All walks the entries of the view in ascending key order
+func (v *SubDict[K, V]) All() iterator.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for it := v.Iterator(); it.HasNext(); {
+			if e := it.Next(); !yield(e.Key(), e.Value()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type navigableDict interface {
+	Dict[int, string]
+	Min() (Entry[int, string], bool)
+	Max() (Entry[int, string], bool)
+	Floor(key int) (Entry[int, string], bool)
+	Ceiling(key int) (Entry[int, string], bool)
+	Lower(key int) (Entry[int, string], bool)
+	Higher(key int) (Entry[int, string], bool)
+	PollFirst() (Entry[int, string], bool)
+	PollLast() (Entry[int, string], bool)
+	HeadMap(to int) *SubDict[int, string]
+	TailMap(from int) *SubDict[int, string]
+	SubMap(from, to int) *SubDict[int, string]
+}
+
+// keyOf returns the key of a navigation result or -1 when there is none
+func keyOf(e Entry[int, string], ok bool) int {
+	if !ok {
+		return -1
+	}
+	return e.Key()
+}
+
+func viewKeys(view *SubDict[int, string]) (result []int) {
+	view.All()(func(k int, _ string) bool {
+		result = append(result, k)
+		return true
+	})
+	return
+}
+
+func testNavigable(t *testing.T, dict navigableDict) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+
+	assert.Equal(t, -1, keyOf(dict.Min()))
+	assert.Equal(t, -1, keyOf(dict.Max()))
+	assert.Equal(t, -1, keyOf(dict.Floor(1)))
+	assert.Equal(t, -1, keyOf(dict.PollFirst()))
+	assert.Equal(t, -1, keyOf(dict.PollLast()))
+
+	for _, k := range []int{50, 20, 80, 10, 30, 70, 90, 60} {
+		dict.Put(k, "v")
+	}
+
+	assert.Equal(t, 10, keyOf(dict.Min()))
+	assert.Equal(t, 90, keyOf(dict.Max()))
+	assert.Equal(t, 30, keyOf(dict.Floor(30)))
+	assert.Equal(t, 30, keyOf(dict.Floor(35)))
+	assert.Equal(t, -1, keyOf(dict.Floor(5)))
+	assert.Equal(t, 30, keyOf(dict.Ceiling(30)))
+	assert.Equal(t, 50, keyOf(dict.Ceiling(35)))
+	assert.Equal(t, -1, keyOf(dict.Ceiling(95)))
+	assert.Equal(t, 20, keyOf(dict.Lower(30)))
+	assert.Equal(t, -1, keyOf(dict.Lower(10)))
+	assert.Equal(t, 50, keyOf(dict.Higher(30)))
+	assert.Equal(t, -1, keyOf(dict.Higher(90)))
+
+	head, tail, sub := dict.HeadMap(50), dict.TailMap(50), dict.SubMap(25, 75)
+	assert.Equal(t, []int{10, 20, 30}, viewKeys(head))
+	assert.Equal(t, []int{50, 60, 70, 80, 90}, viewKeys(tail))
+	assert.Equal(t, []int{30, 50, 60, 70}, viewKeys(sub))
+	assert.Equal(t, 4, sub.Size())
+
+	assert.Equal(t, 30, keyOf(sub.Min()))
+	assert.Equal(t, 70, keyOf(sub.Max()))
+	assert.Equal(t, 70, keyOf(sub.Floor(100)))
+	assert.Equal(t, -1, keyOf(sub.Floor(27)))
+	assert.Equal(t, 30, keyOf(sub.Ceiling(0)))
+	assert.Equal(t, -1, keyOf(sub.Ceiling(72)))
+	assert.Equal(t, 70, keyOf(sub.Lower(100)))
+	assert.Equal(t, -1, keyOf(sub.Lower(30)))
+	assert.Equal(t, 30, keyOf(sub.Higher(0)))
+	assert.Equal(t, -1, keyOf(sub.Higher(70)))
+	assert.Equal(t, 30, keyOf(head.Max()))
+	assert.Equal(t, -1, keyOf(head.Ceiling(50)))
+	assert.Equal(t, 50, keyOf(tail.Min()))
+	assert.Equal(t, -1, keyOf(tail.Floor(40)))
+
+	_, ok := sub.Get(20)
+	assert.False(t, ok)
+	_, ok = sub.Get(60)
+	assert.True(t, ok)
+
+	// views are live in both directions
+	dict.Put(40, "v")
+	sub.Put(65, "v")
+	sub.Delete(80)
+	sub.Delete(60)
+	assert.Equal(t, []int{30, 40, 50, 65, 70}, viewKeys(sub))
+	assert.Equal(t, []int{10, 20, 30, 40, 50, 65, 70, 80, 90}, dict.Keys().ToArray())
+	assert.Panics(t, func() { sub.Put(75, "v") })
+	assert.Panics(t, func() { dict.SubMap(75, 25) })
+
+	it := sub.Iterator()
+	for it.HasNext() {
+		if it.Next().Key() < 50 {
+			it.Remove()
+		}
+	}
+	assert.Equal(t, []int{10, 20, 50, 65, 70, 80, 90}, dict.Keys().ToArray())
+
+	assert.Equal(t, 10, keyOf(dict.PollFirst()))
+	assert.Equal(t, 90, keyOf(dict.PollLast()))
+	assert.Equal(t, 5, dict.Size())
+	assert.Equal(t, []int{20, 50, 65, 70, 80}, dict.Keys().ToArray())
+
+	// views of views narrow the bounds
+	assert.Equal(t, []int{50, 65}, viewKeys(sub.HeadMap(70)))
+	assert.Equal(t, []int{50, 65, 70}, viewKeys(sub.HeadMap(100)))
+	assert.Equal(t, []int{65, 70}, viewKeys(sub.TailMap(60)))
+	assert.Equal(t, []int{50, 65, 70}, viewKeys(sub.TailMap(0)))
+	assert.Equal(t, []int{65}, viewKeys(sub.SubMap(60, 70)))
+	assert.Equal(t, []int{20}, viewKeys(head.TailMap(20).SubMap(15, 30)))
+	assert.Empty(t, viewKeys(sub.HeadMap(10)))
+	assert.Empty(t, viewKeys(sub.TailMap(80)))
+	assert.Panics(t, func() { sub.SubMap(70, 60) })
+	assert.Panics(t, func() { sub.TailMap(60).Put(75, "v") })
+
+	assert.Equal(t, -1, keyOf(head.TailMap(40).PollFirst()))
+	assert.Equal(t, 20, keyOf(head.PollFirst()))
+	assert.Equal(t, 70, keyOf(sub.PollLast()))
+	assert.Equal(t, 65, keyOf(sub.TailMap(60).PollLast()))
+	assert.Equal(t, -1, keyOf(sub.TailMap(60).PollLast()))
+	assert.Equal(t, []int{50, 80}, dict.Keys().ToArray())
+}
+
+func TestAVLDict_Navigable(t *testing.T) {
+	testNavigable(t, NewAVLDict[int, string](order.IntComp))
+}
+
+func TestBSTDict_Navigable(t *testing.T) {
+	testNavigable(t, NewBSTDict[int, string](order.IntComp))
+}
+
+// intervals keyed by their start, e.g. reservations that must not overlap
+func TestAVLDict_IntervalIndex(t *testing.T) {
+	ends := NewAVLDict[int, int](order.IntComp)
+	overlaps := func(start, end int) bool {
+		if e, ok := ends.Lower(end); ok && e.Value() > start {
+			return true
+		}
+		return false
+	}
+	reserve := func(start, end int) bool {
+		if overlaps(start, end) {
+			return false
+		}
+		ends.Put(start, end)
+		return true
+	}
+
+	assert.True(t, reserve(10, 20))
+	assert.True(t, reserve(30, 40))
+	assert.True(t, reserve(20, 30))
+	assert.False(t, reserve(15, 25))
+	assert.False(t, reserve(35, 36))
+	assert.False(t, reserve(0, 11))
+	assert.True(t, reserve(0, 10))
+	assert.True(t, reserve(40, 50))
+
+	starts := iterator.Collect[Entry[int, int]](ends.SubMap(15, 45).Iterator())
+	assert.Len(t, starts, 3)
+	assert.Equal(t, 20, starts[0].Key())
+}
@@ -0,0 +1,528 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/linked"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/hash"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+)
+
+// Probing selects how a ProbingDict resolves collisions
+type Probing int
+
+const (
+	// LinearProbing places an entry in the first free slot after its home slot
+	LinearProbing Probing = iota
+	// RobinHoodProbing lets an entry take the slot of an entry closer to its home, which evens out probe lengths
+	// and lets lookups of missing keys stop early
+	RobinHoodProbing
+)
+
+// DefaultProbeLimit is the probe length past which a ProbingDict picks a fresh seed
+const DefaultProbeLimit = 64
+
+// slot holds an entry dist-1 slots after its home slot, dist 0 marks a free slot
+type slot[K any, V any] struct {
+	entry entry[K, V]
+	dist  int
+}
+
+// ProbingDict stores entries in one array of slots, so unlike HashDict it allocates no node per entry.
+// It grows at a load of 3/4 and deletes by moving the following entries back, so it needs no tombstones.
+type ProbingDict[K any, V any] struct {
+	size       int
+	slots      []slot[K, V]
+	hashFn     hash.KeyedHasher[K]
+	seed       hash.Seed
+	equalFn    order.Equaler[K]
+	probing    Probing
+	modCount   int
+	probeLimit int
+	reseeds    int
+}
+
+func (d *ProbingDict[K, V]) distOK() bool {
+	for i, s := range d.slots {
+		if s.dist != 0 && s.dist != (i-d.indexOfKey(s.entry.Key)+len(d.slots))%len(d.slots)+1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// probeOK holds when no free slot lies between an entry and its home slot,
+// with Robin Hood probing the distances along a run also grow by at most 1 per slot
+func (d *ProbingDict[K, V]) probeOK() bool {
+	for i, s := range d.slots {
+		for k := 1; k < s.dist; k++ {
+			if d.slots[(i-k+len(d.slots))%len(d.slots)].dist == 0 {
+				return false
+			}
+		}
+		if d.probing == RobinHoodProbing && s.dist > 1 && d.slots[(i-1+len(d.slots))%len(d.slots)].dist < s.dist-1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *ProbingDict[K, V]) sizeOK() bool {
+	size := 0
+	for _, s := range d.slots {
+		if s.dist != 0 {
+			size++
+		}
+	}
+
+	return d.size == size
+}
+
+// IsProbingDict data structure invariant
+func (d *ProbingDict[K, V]) IsProbingDict() bool {
+	return d != nil && 0 <= d.size && d.size < len(d.slots) && d.hashFn != nil && d.equalFn != nil && 0 < d.probeLimit &&
+		(d.probing == LinearProbing || d.probing == RobinHoodProbing) && d.sizeOK() && d.distOK() && d.probeOK()
+}
+
+// NewProbingDict compares keys with ==, a nil hashFn stands for hash.OfKeyed[K](), which hashes strings with SipHash.
+func NewProbingDict[K comparable, V any](capacity int, hashFn HashFn[K], probing Probing) (result *ProbingDict[K, V]) {
+	if hashFn == nil {
+		return NewProbingDictKeyed[K, V](capacity, hash.OfKeyed[K](), order.Equal[K], probing)
+	}
+
+	return NewProbingDictFunc[K, V](capacity, hash.Hasher[K](hashFn), order.Equal[K], probing)
+}
+
+// NewProbingDictFunc compares keys with equalFn, hashFn must agree with it
+func NewProbingDictFunc[K any, V any](capacity int, hashFn hash.Hasher[K], equalFn order.Equaler[K], probing Probing) (result *ProbingDict[K, V]) {
+	contract.Require(hashFn != nil, "hash function is not nil")
+
+	return NewProbingDictKeyed[K, V](capacity, hash.Keyed(hashFn), equalFn, probing)
+}
+
+// NewProbingDictKeyed hashes with a KeyedHasher like hash.SipString, so that probes stay short even for keys chosen by an attacker.
+// Whenever an insertion probes more than DefaultProbeLimit slots, all keys are rehashed with a fresh random seed.
+func NewProbingDictKeyed[K any, V any](capacity int, hashFn hash.KeyedHasher[K], equalFn order.Equaler[K], probing Probing) (result *ProbingDict[K, V]) {
+	contract.Require(0 < capacity, "capacity is positive")
+	contract.Require(hashFn != nil, "hash function is not nil")
+	contract.Require(equalFn != nil, "equality function is not nil")
+	contract.Require(probing == LinearProbing || probing == RobinHoodProbing, "probing is known")
+	defer func() {
+		contract.EnsureInvariant(result.IsProbingDict, "probing dict invariant holds")
+	}()
+
+	return &ProbingDict[K, V]{
+		slots:      make([]slot[K, V], capacity),
+		hashFn:     hashFn,
+		seed:       hash.RandomSeed(),
+		equalFn:    equalFn,
+		probing:    probing,
+		probeLimit: DefaultProbeLimit,
+	}
+}
+
+func (d *ProbingDict[K, V]) indexOfKey(key K) (result int) {
+	contract.Require(len(d.slots) > 0, "capacity is positive")
+	defer func() {
+		contract.Ensure(0 <= result && result < len(d.slots), "result is within bound")
+	}()
+
+	return int(uint64(d.hashFn(d.seed, key)) % uint64(len(d.slots)))
+}
+
+// find returns the slot holding key, it stops at a free slot,
+// which is always there, or with Robin Hood probing at an entry closer to its home than key would be
+func (d *ProbingDict[K, V]) find(key K) (index int, found bool) {
+	for i, dist := d.indexOfKey(key), 1; d.slots[i].dist != 0; i, dist = (i+1)%len(d.slots), dist+1 {
+		if d.probing == RobinHoodProbing && d.slots[i].dist < dist {
+			break
+		}
+		if d.equalFn(d.slots[i].entry.Key, key) {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+// place stores e, whose key is not in d, in a free slot, dist is the probe length of the free slot it ends up taking
+func (d *ProbingDict[K, V]) place(e entry[K, V]) (dist int) {
+	i := d.indexOfKey(e.Key)
+	for dist = 1; d.slots[i].dist != 0; i, dist = (i+1)%len(d.slots), dist+1 {
+		if d.probing == RobinHoodProbing && d.slots[i].dist < dist {
+			// take the slot of the entry closer to its home and go on placing that one
+			d.slots[i].entry, e = e, d.slots[i].entry
+			d.slots[i].dist, dist = dist, d.slots[i].dist
+		}
+	}
+
+	d.slots[i] = slot[K, V]{entry: e, dist: dist}
+	return
+}
+
+func (d *ProbingDict[K, V]) Get(key K) (V, bool) {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+
+	if i, found := d.find(key); found {
+		return d.slots[i].entry.Value, true
+	}
+
+	return *new(V), false
+}
+
+func (d *ProbingDict[K, V]) Put(key K, value V) {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsProbingDict, "probing dict invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := d.Get(key)
+			contract.Ensure(ok, "Get(key) finds key")
+		}
+	}()
+
+	if i, found := d.find(key); found {
+		d.slots[i].entry.Value = value
+		return
+	}
+
+	d.insert(key, value)
+}
+
+// insert places key, which is not in d, growing first if needed, and reseeds after a too long probe
+func (d *ProbingDict[K, V]) insert(key K, value V) {
+	if 4*(d.size+1) > 3*len(d.slots) {
+		d.resize(2 * len(d.slots))
+	}
+	dist := d.place(entry[K, V]{Key: key, Value: value})
+	d.size++
+	d.modCount++
+
+	if dist > d.probeLimit {
+		d.reseed()
+	}
+}
+
+func (d *ProbingDict[K, V]) Delete(key K) {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsProbingDict, "probing dict invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := d.Get(key)
+			contract.Ensure(!ok, "Get() returns no value for key")
+		}
+	}()
+
+	i, found := d.find(key)
+	if !found {
+		return
+	}
+
+	d.removeAt(i)
+	d.shrink()
+}
+
+func (d *ProbingDict[K, V]) shrink() {
+	if 8*d.size <= len(d.slots) && len(d.slots) > 1 {
+		d.resize((len(d.slots) + 1) / 2)
+	}
+}
+
+func (d *ProbingDict[K, V]) Compute(key K, fn func(value V, found bool) (newValue V, keep bool)) (result V, present bool) {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsProbingDict, "probing dict invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := d.Get(key)
+			contract.Ensure(ok == present, "Get(key) finds key iff it is present")
+		}
+	}()
+
+	if i, found := d.find(key); found {
+		if value, keep := fn(d.slots[i].entry.Value, true); keep {
+			d.slots[i].entry.Value = value
+			return value, true
+		}
+		d.removeAt(i)
+		d.shrink()
+		return *new(V), false
+	}
+
+	value, keep := fn(*new(V), false)
+	if !keep {
+		return *new(V), false
+	}
+	d.insert(key, value)
+	return value, true
+}
+
+// Clear keeps the capacity
+func (d *ProbingDict[K, V]) Clear() {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsProbingDict, "probing dict invariant holds")
+		contract.Ensure(d.size == 0, "dict is empty")
+	}()
+
+	d.slots = make([]slot[K, V], len(d.slots))
+	d.size = 0
+	d.modCount++
+}
+
+// removeAt frees slot i and moves back each following entry, up to the next free slot, whose home is not after the freed slot.
+// With Robin Hood probing this shifts the run back by one up to the next entry at home.
+// It does not shrink the table, so that iterators can remove too.
+func (d *ProbingDict[K, V]) removeAt(i int) {
+	n := len(d.slots)
+	for j := (i + 1) % n; d.slots[j].dist != 0; j = (j + 1) % n {
+		if d.probing == RobinHoodProbing && d.slots[j].dist == 1 {
+			break
+		}
+		if gap := (j - i + n) % n; d.slots[j].dist > gap {
+			d.slots[i] = d.slots[j]
+			d.slots[i].dist -= gap
+			i = j
+		}
+	}
+
+	d.slots[i] = slot[K, V]{}
+	d.size--
+	d.modCount++
+}
+
+func (d *ProbingDict[K, V]) resize(newCapacity int) {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+	contract.Require(d.size < newCapacity, "new capacity leaves a free slot")
+	defer func(oldSize int) {
+		contract.EnsureInvariant(d.IsProbingDict, "probing dict invariant holds")
+		contract.Ensure(oldSize == d.size, "resize does not change count of entries")
+		contract.Ensure(newCapacity == len(d.slots), "resize changes capacity")
+	}(d.size)
+
+	d.rehash(newCapacity)
+}
+
+// rehash places all entries into newCapacity new slots, by the current seed
+func (d *ProbingDict[K, V]) rehash(newCapacity int) {
+	oldSlots := d.slots
+	d.slots = make([]slot[K, V], newCapacity)
+
+	for _, s := range oldSlots {
+		if s.dist != 0 {
+			d.place(s.entry)
+		}
+	}
+	d.modCount++
+}
+
+// reseed rehashes with a fresh random seed once a probe got too long, which takes bad luck or an attack.
+// If a probe is still too long, its keys collide under every seed, so probeLimit is doubled to not rehash on every insertion.
+func (d *ProbingDict[K, V]) reseed() {
+	d.seed = hash.RandomSeed()
+	d.rehash(len(d.slots))
+	d.reseeds++
+
+	if d.longestProbe() > d.probeLimit {
+		d.probeLimit *= 2
+	}
+}
+
+func (d *ProbingDict[K, V]) Size() (result int) {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+	defer func() {
+		contract.Ensure(result >= 0, "result is non-negative")
+	}()
+
+	return d.size
+}
+
+func (d *ProbingDict[K, V]) GetOrDefault(key K, def V) V {
+	if value, found := d.Get(key); found {
+		return value
+	}
+
+	return def
+}
+
+func (d *ProbingDict[K, V]) PutIfAbsent(key K, value V) (actual V, loaded bool) {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+
+	return putIfAbsent[K, V](d, key, value)
+}
+
+func (d *ProbingDict[K, V]) ComputeIfAbsent(key K, fn func(K) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+
+	return computeIfAbsent[K, V](d, key, fn)
+}
+
+func (d *ProbingDict[K, V]) Merge(key K, value V, fn func(old, value V) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+
+	return merge[K, V](d, key, value, fn)
+}
+
+func (d *ProbingDict[K, V]) Replace(key K, value V) (previous V, replaced bool) {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+
+	return replace[K, V](d, key, value)
+}
+
+func (d *ProbingDict[K, V]) Keys() (result *linked.List[K]) {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+	defer func() {
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(result.Length() == d.size, "result has all keys")
+		}
+	}()
+
+	return keysOf(d.All(), d.equalFn)
+}
+
+func (d *ProbingDict[K, V]) Values() (result []V) {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == d.size, "result has all values")
+	}()
+
+	return valuesOf(d.All())
+}
+
+func (d *ProbingDict[K, V]) Entries() (result []Entry[K, V]) {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == d.size, "result has all entries")
+	}()
+
+	return entriesOf(d.All())
+}
+
+// Seed returns the seed keying the hash function of this probing dict
+func (d *ProbingDict[K, V]) Seed() hash.Seed {
+	return d.seed
+}
+
+// Reseeds returns how often a too long probe made this probing dict pick a fresh seed
+func (d *ProbingDict[K, V]) Reseeds() int {
+	return d.reseeds
+}
+
+// SetSeed rehashes all entries with seed, which is random by default
+func (d *ProbingDict[K, V]) SetSeed(seed hash.Seed) {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+	defer func(oldSize int) {
+		contract.EnsureInvariant(d.IsProbingDict, "probing dict invariant holds")
+		contract.Ensure(oldSize == d.size, "rehashing does not change count of entries")
+		contract.Ensure(d.seed == seed, "rehashing changes seed")
+	}(d.size)
+
+	d.seed = seed
+	d.rehash(len(d.slots))
+}
+
+// LongestProbe returns the most slots a lookup of a present key inspects
+func (d *ProbingDict[K, V]) LongestProbe() int {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+
+	return d.longestProbe()
+}
+
+func (d *ProbingDict[K, V]) longestProbe() (result int) {
+	for _, s := range d.slots {
+		if s.dist > result {
+			result = s.dist
+		}
+	}
+
+	return
+}
+
+// probingDictIterator walks the slots downwards, starting below a free slot and wrapping around.
+// Removing shifts entries down only from slots up to that free slot, which have all been walked,
+// so Remove neither skips nor repeats entries.
+type probingDictIterator[K any, V any] struct {
+	d        *ProbingDict[K, V]
+	index    int
+	left     int
+	last     int
+	modCount int
+}
+
+func (it *probingDictIterator[K, V]) skipFreeSlots() {
+	for it.left > 0 && it.d.slots[it.index].dist == 0 {
+		it.step()
+	}
+}
+
+func (it *probingDictIterator[K, V]) step() {
+	it.index = (it.index - 1 + len(it.d.slots)) % len(it.d.slots)
+	it.left--
+}
+
+func (it *probingDictIterator[K, V]) HasNext() bool {
+	iterator.CheckMods(it.d.modCount, it.modCount)
+
+	return it.left > 0
+}
+
+func (it *probingDictIterator[K, V]) Next() Entry[K, V] {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	it.last = it.index
+	it.step()
+	it.skipFreeSlots()
+
+	e := it.d.slots[it.last].entry
+	return keyValue[K, V]{key: e.Key, value: e.Value}
+}
+
+// Remove deletes the entry last returned by Next, the table is not shrunk until the next Delete
+func (it *probingDictIterator[K, V]) Remove() {
+	iterator.CheckMods(it.d.modCount, it.modCount)
+	contract.Require(it.last >= 0, "Next is called after the last Remove")
+	defer func() {
+		contract.EnsureInvariant(it.d.IsProbingDict, "probing dict invariant holds")
+	}()
+
+	it.d.removeAt(it.last)
+	it.last = -1
+	it.modCount = it.d.modCount
+}
+
+// Iterator walks the entries in no particular order
+func (d *ProbingDict[K, V]) Iterator() iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+
+	free := 0
+	for d.slots[free].dist != 0 {
+		free++
+	}
+
+	it := &probingDictIterator[K, V]{
+		d:        d,
+		index:    free,
+		left:     len(d.slots),
+		last:     -1,
+		modCount: d.modCount,
+	}
+	it.skipFreeSlots()
+
+	return it
+}
+
+// All walks the entries in no particular order
+func (d *ProbingDict[K, V]) All() iterator.Seq2[K, V] {
+	contract.RequireInvariant(d.IsProbingDict, "probing dict invariant holds")
+
+	return iterator.FailFastSeq2(func(yield func(K, V) bool) {
+		for _, s := range d.slots {
+			if s.dist != 0 && !yield(s.entry.Key, s.entry.Value) {
+				return
+			}
+		}
+	}, func() int { return d.modCount })
+}
@@ -0,0 +1,233 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/hash"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+var probings = []Probing{LinearProbing, RobinHoodProbing}
+
+func TestProbingDict(t *testing.T) {
+	for _, probing := range probings {
+		dict := NewProbingDict[string, int](1, hash.String, probing)
+		v, ok := dict.Get("hello")
+		assert.False(t, ok)
+		assert.Equal(t, 0, v)
+		assert.Equal(t, 0, dict.Size())
+
+		dict.Put("hello", 1)
+		v, ok = dict.Get("hello")
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+		assert.Equal(t, 1, dict.Size())
+
+		dict.Put("world", 2)
+		v, ok = dict.Get("world")
+		assert.True(t, ok)
+		assert.Equal(t, 2, v)
+		assert.Equal(t, 2, dict.Size())
+
+		dict.Put("hello", 3)
+		v, ok = dict.Get("hello")
+		assert.True(t, ok)
+		assert.Equal(t, 3, v)
+		assert.Equal(t, 2, dict.Size())
+
+		dict.Delete("world")
+		v, ok = dict.Get("world")
+		assert.False(t, ok)
+		assert.Equal(t, 1, dict.Size())
+
+		dict.Delete("hello")
+		assert.Equal(t, 0, dict.Size())
+
+		for i := 0; i < 12; i++ {
+			dict.Put(strconv.Itoa(i), i)
+		}
+		assert.Equal(t, 16, len(dict.slots))
+
+		for i := 0; i < 10; i++ {
+			dict.Delete(strconv.Itoa(i))
+		}
+		assert.Equal(t, 8, len(dict.slots))
+	}
+}
+
+func TestProbingDict_Random(t *testing.T) {
+	for _, probing := range probings {
+		dict := NewProbingDict[int, int](1, nil, probing)
+		// few home slots make long runs which wrap around the end of the table
+		dict.hashFn = hash.Keyed(func(x int) int { return x % 4 })
+		testRandomUpdates(t, dict, 42, 100, 2000, dict.IsProbingDict)
+	}
+}
+
+func TestProbingDict_SwapsForHashDict(t *testing.T) {
+	var hashFn HashFn[string] = hash.String
+	for _, dict := range []Dict[string, int]{NewHashDict[string, int](1, hashFn, 1), NewProbingDict[string, int](1, hashFn, LinearProbing)} {
+		dict.Put("a", 1)
+		v, ok := dict.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestProbingDict_Iterator(t *testing.T) {
+	for _, probing := range probings {
+		var dict Dict[string, int] = NewProbingDict[string, int](1, hash.String, probing)
+		assert.False(t, dict.Iterator().HasNext())
+
+		var keys []string
+		for i := 0; i < 10; i++ {
+			keys = append(keys, strconv.Itoa(i))
+			dict.Put(strconv.Itoa(i), i)
+		}
+
+		var fromIterator []string
+		for it := dict.Iterator(); it.HasNext(); {
+			e := it.Next()
+			assert.Equal(t, e.Key(), strconv.Itoa(e.Value()))
+			fromIterator = append(fromIterator, e.Key())
+		}
+		assert.ElementsMatch(t, keys, fromIterator)
+
+		var fromAll []string
+		dict.All()(func(k string, v int) bool {
+			fromAll = append(fromAll, k)
+			return len(fromAll) < 3
+		})
+		assert.Len(t, fromAll, 3)
+	}
+}
+
+func TestProbingDict_IteratorRemove(t *testing.T) {
+	for _, probing := range probings {
+		dict := NewProbingDict[int, string](1, nil, probing)
+		// runs of colliding keys make Remove shift entries back, also across the end of the table
+		dict.hashFn = hash.Keyed(func(x int) int { return x % 3 })
+		for i := 0; i < 30; i++ {
+			dict.Put(i, strconv.Itoa(i))
+		}
+
+		var walked []int
+		for it := dict.Iterator(); it.HasNext(); {
+			k := it.Next().Key()
+			walked = append(walked, k)
+			if k%2 == 1 {
+				it.Remove()
+			}
+		}
+		assert.Len(t, walked, 30)
+		var keys []int
+		dict.All()(func(k int, v string) bool {
+			keys = append(keys, k)
+			return true
+		})
+		assert.ElementsMatch(t, []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28}, keys)
+		assert.Equal(t, 15, dict.Size())
+
+		it := dict.Iterator()
+		it.Next()
+		dict.Put(30, "30")
+		assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.Remove() })
+
+		dict.Put(30, "thirty")
+		assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.Next() })
+	}
+}
+
+func TestProbingDict_CustomEquality(t *testing.T) {
+	for _, probing := range probings {
+		words := NewProbingDictFunc[string, int](1, hash.CaseInsensitiveString, order.Equivalent(order.CaseInsensitiveComp), probing)
+		for _, w := range []string{"Go", "go", "GO", "rust"} {
+			n, _ := words.Get(w)
+			words.Put(w, n+1)
+		}
+		n, _ := words.Get("gO")
+		assert.Equal(t, 3, n)
+		assert.Equal(t, 2, words.Size())
+	}
+}
+
+func TestProbingDict_Seed(t *testing.T) {
+	for _, probing := range probings {
+		testSeed(t, func() *ProbingDict[int, int] { return NewProbingDict[int, int](1, nil, probing) },
+			func(dict *ProbingDict[int, int], key int) any { return dict.indexOfKey(key) })
+	}
+}
+
+func TestProbingDict_RobinHood(t *testing.T) {
+	linear := NewProbingDict[int, int](1, nil, LinearProbing)
+	robinHood := NewProbingDict[int, int](1, nil, RobinHoodProbing)
+	for _, d := range []*ProbingDict[int, int]{linear, robinHood} {
+		d.SetSeed(hash.FixedSeed(1, 2))
+		for i := 0; i < 3000; i++ {
+			d.Put(i, i)
+		}
+	}
+
+	assert.LessOrEqual(t, robinHood.LongestProbe(), linear.LongestProbe())
+}
+
+func TestProbingDict_Reseed(t *testing.T) {
+	for _, probing := range probings {
+		dict := NewProbingDictKeyed[string, int](1024, hash.SipString, order.Equal[string], probing)
+		dict.SetSeed(hash.FixedSeed(1, 2))
+
+		// keys sharing a home slot under the known seed, as an attacker would pick them
+		var keys []string
+		for i := 0; len(keys) <= DefaultProbeLimit; i++ {
+			if k := strconv.Itoa(i); dict.indexOfKey(k) == 0 {
+				keys = append(keys, k)
+			}
+		}
+		for i, k := range keys {
+			dict.Put(k, i)
+		}
+
+		assert.Equal(t, 1, dict.Reseeds())
+		assert.NotEqual(t, hash.FixedSeed(1, 2), dict.Seed())
+		assert.LessOrEqual(t, dict.LongestProbe(), DefaultProbeLimit)
+		for i, k := range keys {
+			v, ok := dict.Get(k)
+			assert.True(t, ok)
+			assert.Equal(t, i, v)
+		}
+	}
+}
+
+func TestProbingDict_ReseedCollidingHasher(t *testing.T) {
+	for _, probing := range probings {
+		// all keys collide under every seed, reseeding gives up instead of rehashing on every Put
+		dict := NewProbingDict[int, int](1024, func(int) int { return 0 }, probing)
+		for i := 0; i < 300; i++ {
+			dict.Put(i, i)
+		}
+
+		assert.Equal(t, 300, dict.LongestProbe())
+		assert.LessOrEqual(t, dict.Reseeds(), 4)
+		for i := 0; i < 300; i++ {
+			v, ok := dict.Get(i)
+			assert.True(t, ok)
+			assert.Equal(t, i, v)
+		}
+	}
+}
+
+func TestProbingDict_DefaultHashesStringsWithSipHash(t *testing.T) {
+	dict := NewProbingDict[string, int](1, nil, LinearProbing)
+	dict.SetSeed(hash.FixedSeed(1, 2))
+	assert.Equal(t, hash.SipString(hash.FixedSeed(1, 2), "go"), dict.hashFn(dict.Seed(), "go"))
+}
+
+func BenchmarkProbingDict_Get(b *testing.B) {
+	benchmarkDictGet(b, NewProbingDict[int, int](1, nil, RobinHoodProbing))
+}
+
+func BenchmarkProbingDict_Put(b *testing.B) {
+	benchmarkDictPut(b, func() Dict[int, int] { return NewProbingDict[int, int](1, nil, RobinHoodProbing) })
+}
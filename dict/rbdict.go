@@ -0,0 +1,670 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/linked"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/song-flying/GoDataStructures/tree"
+)
+
+// RBDict is a red-black tree dict, rebalanced with at most two rotations per insertion and three per removal
+type RBDict[K comparable, V any] struct {
+	tree      *tree.RBTree[entry[K, V]]
+	keyComp   order.CompareFn[K]
+	size      int
+	modCount  int
+	rotations int
+}
+
+func (t *RBDict[K, V]) isOrderedWithMinMax(root *tree.RBNode[entry[K, V]]) (minKey, maxKey *K, isOrdered bool) {
+	contract.Require(t.keyComp != nil, "comparison function is not nil")
+	contract.RequireInvariant(root.IsBinaryTree, "root is a binary tree")
+
+	if root == nil {
+		return nil, nil, true
+	}
+
+	if root.Left != nil {
+		leftMin, leftMax, leftIsOrdered := t.isOrderedWithMinMax(root.Left)
+		if !leftIsOrdered || t.keyComp(*leftMax, root.Data.Key) >= 0 {
+			return nil, nil, false
+		} else {
+			minKey = leftMin
+		}
+	} else {
+		minKey = &root.Data.Key
+	}
+
+	if root.Right != nil {
+		rightMin, rightMax, rightIsOrdered := t.isOrderedWithMinMax(root.Right)
+		if !rightIsOrdered || t.keyComp(*rightMin, root.Data.Key) <= 0 {
+			return nil, nil, false
+		} else {
+			maxKey = rightMax
+		}
+	} else {
+		maxKey = &root.Data.Key
+	}
+
+	return minKey, maxKey, true
+}
+
+// isColourOKFrom checks that no red node has a red child
+func (t *RBDict[K, V]) isColourOKFrom(root *tree.RBNode[entry[K, V]]) bool {
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
+	return root == nil || t.isColourOKFrom(root.Left) && t.isColourOKFrom(root.Right) &&
+		!(root.IsRed() && (root.Left.IsRed() || root.Right.IsRed()))
+}
+
+// blackHeight counts the black nodes on every path from root down to a leaf, ok is false if they differ
+func (t *RBDict[K, V]) blackHeight(root *tree.RBNode[entry[K, V]]) (height int, ok bool) {
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
+
+	if root == nil {
+		return 0, true
+	}
+
+	leftHeight, leftOK := t.blackHeight(root.Left)
+	rightHeight, rightOK := t.blackHeight(root.Right)
+	if !leftOK || !rightOK || leftHeight != rightHeight {
+		return 0, false
+	}
+	if root.IsRed() {
+		return leftHeight, true
+	}
+	return leftHeight + 1, true
+}
+
+func (t *RBDict[K, V]) sizeOK() bool {
+	return t.size == iterator.Count[entry[K, V]](t.tree.Iterator())
+}
+
+// IsRBDict data structure invariant
+func (t *RBDict[K, V]) IsRBDict() bool {
+	return t.tree != nil && t.IsRBTree(t.tree.Root) && t.sizeOK()
+}
+
+// IsRBTree checks that root is an ordered, black red-black tree with the same black height on every path
+func (t *RBDict[K, V]) IsRBTree(root *tree.RBNode[entry[K, V]]) bool {
+	_, _, isOrdered := t.isOrderedWithMinMax(root)
+	_, isBlackHeightOK := t.blackHeight(root)
+	return t.keyComp != nil && root.IsBinaryTree() && isOrdered && !root.IsRed() && t.isColourOKFrom(root) && isBlackHeightOK
+}
+
+func NewRBDict[K comparable, V any](comp order.CompareFn[K]) (result *RBDict[K, V]) {
+	contract.Require(comp != nil, "comparison function is not nil")
+	defer func() {
+		contract.EnsureInvariant(result.IsRBDict, "red-black invariant holds")
+	}()
+
+	t := tree.NewRBTree[entry[K, V]](nil)
+
+	return &RBDict[K, V]{
+		tree:    t,
+		keyComp: comp,
+		size:    0,
+	}
+}
+
+func (t *RBDict[K, V]) Get(key K) (V, bool) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	for node := t.tree.Root; node != nil; {
+		compResult := t.keyComp(key, node.Data.Key)
+		switch {
+		case compResult == 0:
+			return node.Data.Value, true
+		case compResult < 0:
+			node = node.Left
+		default: // compResult > 0
+			node = node.Right
+		}
+	}
+
+	return *new(V), false
+}
+
+func (t *RBDict[K, V]) rotateLeft(root *tree.RBNode[entry[K, V]]) *tree.RBNode[entry[K, V]] {
+	contract.Require(root != nil && root.Right != nil, "root has a right child")
+
+	right := root.Right
+	root.Right = right.Left
+	right.Left = root
+	t.rotations++
+
+	return right
+}
+
+func (t *RBDict[K, V]) rotateRight(root *tree.RBNode[entry[K, V]]) *tree.RBNode[entry[K, V]] {
+	contract.Require(root != nil && root.Left != nil, "root has a left child")
+
+	left := root.Left
+	root.Left = left.Right
+	left.Right = root
+	t.rotations++
+
+	return left
+}
+
+// fixRed repairs a red child of root with a red child, left by an insertion below it.
+// A red sibling takes the black of root, which passes the repair up, otherwise at most two rotations end it.
+func (t *RBDict[K, V]) fixRed(root *tree.RBNode[entry[K, V]]) *tree.RBNode[entry[K, V]] {
+	switch {
+	case root.Left.IsRed() && (root.Left.Left.IsRed() || root.Left.Right.IsRed()):
+		if root.Right.IsRed() {
+			root.Red, root.Left.Red, root.Right.Red = true, false, false
+			return root
+		}
+		if root.Left.Right.IsRed() {
+			root.Left = t.rotateLeft(root.Left)
+		}
+		root = t.rotateRight(root)
+		root.Red, root.Right.Red = false, true
+	case root.Right.IsRed() && (root.Right.Right.IsRed() || root.Right.Left.IsRed()):
+		if root.Left.IsRed() {
+			root.Red, root.Left.Red, root.Right.Red = true, false, false
+			return root
+		}
+		if root.Right.Left.IsRed() {
+			root.Right = t.rotateRight(root.Right)
+		}
+		root = t.rotateLeft(root)
+		root.Red, root.Left.Red = false, true
+	}
+
+	return root
+}
+
+func (t *RBDict[K, V]) insertFrom(root *tree.RBNode[entry[K, V]], key K, value V) *tree.RBNode[entry[K, V]] {
+	if root == nil {
+		node := tree.NewRBNode[entry[K, V]](entry[K, V]{Key: key, Value: value})
+		node.Red = true
+		t.size++
+		t.modCount++
+		return &node
+	}
+
+	compResult := t.keyComp(key, root.Data.Key)
+	switch {
+	case compResult == 0:
+		root.Data.Value = value
+		return root
+	case compResult < 0:
+		root.Left = t.insertFrom(root.Left, key, value) // invariant broken (maybe two red nodes in a row)
+	default: //compResult > 0:
+		root.Right = t.insertFrom(root.Right, key, value) // invariant broken (maybe two red nodes in a row)
+	}
+
+	return t.fixRed(root) // invariant restored, up to a red root with a red child
+}
+
+func (t *RBDict[K, V]) Put(key K, value V) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsRBDict, "red-black invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := t.Get(key)
+			contract.Ensure(ok, "Get(key) finds key")
+		}
+	}()
+
+	t.tree.Root = t.insertFrom(t.tree.Root, key, value)
+	t.tree.Root.Red = false
+}
+
+// fixLeftShort repairs root after a removal took one black node off the paths through root.Left.
+// A red sibling is rotated up first, after that at most two rotations end the repair,
+// short reports whether only recolouring was possible, so that the paths through root are still one black node short.
+func (t *RBDict[K, V]) fixLeftShort(root *tree.RBNode[entry[K, V]]) (result *tree.RBNode[entry[K, V]], short bool) {
+	contract.Require(root != nil && root.Right != nil, "root has a right child")
+
+	if root.Right.IsRed() {
+		result = t.rotateLeft(root)
+		result.Red, root.Red = false, true
+		result.Left, _ = t.fixLeftShort(root) // root is red now, so the repair ends below
+		return result, false
+	}
+
+	sibling := root.Right
+	if !sibling.Left.IsRed() && !sibling.Right.IsRed() {
+		sibling.Red = true
+		short = !root.Red
+		root.Red = false
+		return root, short
+	}
+
+	if !sibling.Right.IsRed() {
+		root.Right = t.rotateRight(sibling)
+		root.Right.Red, sibling.Red = false, true
+	}
+	result = t.rotateLeft(root)
+	result.Red, root.Red, result.Right.Red = root.Red, false, false
+
+	return result, false
+}
+
+// fixRightShort mirrors fixLeftShort
+func (t *RBDict[K, V]) fixRightShort(root *tree.RBNode[entry[K, V]]) (result *tree.RBNode[entry[K, V]], short bool) {
+	contract.Require(root != nil && root.Left != nil, "root has a left child")
+
+	if root.Left.IsRed() {
+		result = t.rotateRight(root)
+		result.Red, root.Red = false, true
+		result.Right, _ = t.fixRightShort(root) // root is red now, so the repair ends below
+		return result, false
+	}
+
+	sibling := root.Left
+	if !sibling.Left.IsRed() && !sibling.Right.IsRed() {
+		sibling.Red = true
+		short = !root.Red
+		root.Red = false
+		return root, short
+	}
+
+	if !sibling.Left.IsRed() {
+		root.Left = t.rotateLeft(sibling)
+		root.Left.Red, sibling.Red = false, true
+	}
+	result = t.rotateRight(root)
+	result.Red, root.Red, result.Left.Red = root.Red, false, false
+
+	return result, false
+}
+
+// unlink removes root, which has at most one child, and returns that child
+func (t *RBDict[K, V]) unlink(root *tree.RBNode[entry[K, V]]) (result *tree.RBNode[entry[K, V]], short bool) {
+	contract.Require(root != nil && (root.Left == nil || root.Right == nil), "root has at most one child")
+
+	t.size--
+	t.modCount++
+	result = root.Left
+	if result == nil {
+		result = root.Right
+	}
+	if root.IsRed() {
+		return result, false
+	}
+	if result.IsRed() {
+		result.Red = false
+		return result, false
+	}
+
+	return result, true
+}
+
+// removeMin removes the entry with the least key below root
+func (t *RBDict[K, V]) removeMin(root *tree.RBNode[entry[K, V]]) (result *tree.RBNode[entry[K, V]], min entry[K, V], short bool) {
+	contract.Require(root != nil, "root is not nil")
+
+	if root.Left == nil {
+		result, short = t.unlink(root)
+		return result, root.Data, short
+	}
+
+	root.Left, min, short = t.removeMin(root.Left)
+	if short {
+		root, short = t.fixLeftShort(root)
+	}
+
+	return root, min, short
+}
+
+// removeNode removes the entry of root, taking the least entry on its right when it has two children
+func (t *RBDict[K, V]) removeNode(root *tree.RBNode[entry[K, V]]) (result *tree.RBNode[entry[K, V]], short bool) {
+	if root.Left == nil || root.Right == nil {
+		return t.unlink(root)
+	}
+
+	root.Right, root.Data, short = t.removeMin(root.Right)
+	if short {
+		return t.fixRightShort(root)
+	}
+
+	return root, false
+}
+
+// removeFrom removes key if it is below root, short reports whether the paths through root lost a black node
+func (t *RBDict[K, V]) removeFrom(root *tree.RBNode[entry[K, V]], key K) (result *tree.RBNode[entry[K, V]], short bool) {
+	if root == nil {
+		return nil, false
+	}
+
+	compResult := t.keyComp(key, root.Data.Key)
+	switch {
+	case compResult == 0:
+		return t.removeNode(root)
+	case compResult < 0:
+		if root.Left, short = t.removeFrom(root.Left, key); short {
+			return t.fixLeftShort(root)
+		}
+	default: // compResult > 0
+		if root.Right, short = t.removeFrom(root.Right, key); short {
+			return t.fixRightShort(root)
+		}
+	}
+
+	return root, false
+}
+
+func (t *RBDict[K, V]) Delete(key K) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsRBDict, "red-black invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := t.Get(key)
+			contract.Ensure(!ok, "Get(key) does not find key")
+		}
+	}()
+
+	t.tree.Root, _ = t.removeFrom(t.tree.Root, key)
+	if t.tree.Root != nil {
+		t.tree.Root.Red = false
+	}
+}
+
+// computeFrom inserts or removes right where key is, then repairs the tree on the way back up
+func (t *RBDict[K, V]) computeFrom(root *tree.RBNode[entry[K, V]], key K, fn func(V, bool) (V, bool)) (result *tree.RBNode[entry[K, V]], value V, present, short bool) {
+	if root == nil {
+		value, keep := fn(*new(V), false)
+		if !keep {
+			return nil, *new(V), false, false
+		}
+		node := tree.NewRBNode[entry[K, V]](entry[K, V]{Key: key, Value: value})
+		node.Red = true
+		t.size++
+		t.modCount++
+		return &node, value, true, false
+	}
+
+	compResult := t.keyComp(key, root.Data.Key)
+	switch {
+	case compResult < 0:
+		if root.Left, value, present, short = t.computeFrom(root.Left, key, fn); short {
+			root, short = t.fixLeftShort(root)
+			return root, value, present, short
+		}
+	case compResult > 0:
+		if root.Right, value, present, short = t.computeFrom(root.Right, key, fn); short {
+			root, short = t.fixRightShort(root)
+			return root, value, present, short
+		}
+	default: // compResult == 0
+		var keep bool
+		if value, keep = fn(root.Data.Value, true); keep {
+			root.Data.Value = value
+			return root, value, true, false
+		}
+		root, short = t.removeNode(root)
+		return root, *new(V), false, short
+	}
+
+	return t.fixRed(root), value, present, false
+}
+
+// Compute walks down to key once, inserting or removing right there
+func (t *RBDict[K, V]) Compute(key K, fn func(value V, found bool) (newValue V, keep bool)) (result V, present bool) {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsRBDict, "red-black invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := t.Get(key)
+			contract.Ensure(ok == present, "Get(key) finds key iff it is present")
+		}
+	}()
+
+	t.tree.Root, result, present, _ = t.computeFrom(t.tree.Root, key, fn)
+	if t.tree.Root != nil {
+		t.tree.Root.Red = false
+	}
+
+	return
+}
+
+func (t *RBDict[K, V]) Clear() {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsRBDict, "red-black invariant holds")
+		contract.Ensure(t.size == 0, "dict is empty")
+	}()
+
+	t.tree.Root = nil
+	t.size = 0
+	t.modCount++
+}
+
+func (t *RBDict[K, V]) Size() (result int) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	defer func() {
+		contract.Ensure(0 <= result, "result is non-negative")
+	}()
+
+	return t.size
+}
+
+func (t *RBDict[K, V]) GetOrDefault(key K, def V) V {
+	if value, found := t.Get(key); found {
+		return value
+	}
+
+	return def
+}
+
+func (t *RBDict[K, V]) PutIfAbsent(key K, value V) (actual V, loaded bool) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	return putIfAbsent[K, V](t, key, value)
+}
+
+func (t *RBDict[K, V]) ComputeIfAbsent(key K, fn func(K) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	return computeIfAbsent[K, V](t, key, fn)
+}
+
+func (t *RBDict[K, V]) Merge(key K, value V, fn func(old, value V) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	return merge[K, V](t, key, value, fn)
+}
+
+func (t *RBDict[K, V]) Replace(key K, value V) (previous V, replaced bool) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	return replace[K, V](t, key, value)
+}
+
+func (t *RBDict[K, V]) Keys() (result *linked.List[K]) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	defer func() {
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(result.Length() == t.size, "result has all keys")
+		}
+	}()
+
+	return keysOf(t.All(), order.Equal[K])
+}
+
+func (t *RBDict[K, V]) Values() (result []V) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == t.size, "result has all values")
+	}()
+
+	return valuesOf(t.All())
+}
+
+func (t *RBDict[K, V]) Entries() (result []Entry[K, V]) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == t.size, "result has all entries")
+	}()
+
+	return entriesOf(t.All())
+}
+
+func (t *RBDict[K, V]) walk(descending bool, afterStart, beforeEnd func(entry[K, V]) bool) *tree.OrderedIterator[entry[K, V]] {
+	return tree.NewRBOrderedIterator(t.tree.Root, descending, afterStart, beforeEnd)
+}
+
+func (t *RBDict[K, V]) compareKeys(a, b K) int {
+	return t.keyComp(a, b)
+}
+
+func (t *RBDict[K, V]) mods() int {
+	return t.modCount
+}
+
+// Iterator walks the entries in ascending key order
+func (t *RBDict[K, V]) Iterator() iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	return newTreeDictIterator[K, V](t, false, nil, nil)
+}
+
+// All walks the entries in ascending key order
+func (t *RBDict[K, V]) All() iterator.Seq2[K, V] {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	entries := t.tree.All()
+	return iterator.FailFastSeq2(func(yield func(K, V) bool) {
+		entries(func(e entry[K, V]) bool {
+			return yield(e.Key, e.Value)
+		})
+	}, t.mods)
+}
+
+// Descending walks the entries in descending key order
+func (t *RBDict[K, V]) Descending() iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	return newTreeDictIterator[K, V](t, true, nil, nil)
+}
+
+// Range walks the entries with keys within [lo, hi) in ascending key order
+func (t *RBDict[K, V]) Range(lo, hi K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	contract.Require(t.keyComp(lo, hi) <= 0, "lo <= hi")
+
+	return newTreeDictIterator[K, V](t, false, keyAtLeast[K, V](t.keyComp, lo), keyBelow[K, V](t.keyComp, hi))
+}
+
+// ReverseRange walks the entries with keys within [lo, hi) in descending key order
+func (t *RBDict[K, V]) ReverseRange(lo, hi K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	contract.Require(t.keyComp(lo, hi) <= 0, "lo <= hi")
+
+	return newTreeDictIterator[K, V](t, true, keyBelow[K, V](t.keyComp, hi), keyAtLeast[K, V](t.keyComp, lo))
+}
+
+// From walks the entries with keys not less than key in ascending key order
+func (t *RBDict[K, V]) From(key K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	return newTreeDictIterator[K, V](t, false, keyAtLeast[K, V](t.keyComp, key), nil)
+}
+
+// ReverseFrom walks the entries with keys not greater than key in descending key order
+func (t *RBDict[K, V]) ReverseFrom(key K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	return newTreeDictIterator[K, V](t, true, keyAtMost[K, V](t.keyComp, key), nil)
+}
+
+// Min returns the entry with the least key, ok is false when the dict is empty
+func (t *RBDict[K, V]) Min() (Entry[K, V], bool) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	return nodeEntry[K, V](minNode[K, V](t.tree.Root))
+}
+
+// Max returns the entry with the greatest key, ok is false when the dict is empty
+func (t *RBDict[K, V]) Max() (Entry[K, V], bool) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	return nodeEntry[K, V](maxNode[K, V](t.tree.Root))
+}
+
+// Floor returns the entry with the greatest key not greater than key
+func (t *RBDict[K, V]) Floor(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.keyComp(result.Key(), key) <= 0, "result key <= key")
+	}()
+
+	return nodeEntry[K, V](floorNode[K, V](t.tree.Root, t.keyComp, key, false))
+}
+
+// Ceiling returns the entry with the least key not less than key
+func (t *RBDict[K, V]) Ceiling(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.keyComp(result.Key(), key) >= 0, "result key >= key")
+	}()
+
+	return nodeEntry[K, V](ceilingNode[K, V](t.tree.Root, t.keyComp, key, false))
+}
+
+// Lower returns the entry with the greatest key less than key
+func (t *RBDict[K, V]) Lower(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.keyComp(result.Key(), key) < 0, "result key < key")
+	}()
+
+	return nodeEntry[K, V](floorNode[K, V](t.tree.Root, t.keyComp, key, true))
+}
+
+// Higher returns the entry with the least key greater than key
+func (t *RBDict[K, V]) Higher(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.keyComp(result.Key(), key) > 0, "result key > key")
+	}()
+
+	return nodeEntry[K, V](ceilingNode[K, V](t.tree.Root, t.keyComp, key, true))
+}
+
+// PollFirst removes and returns the entry with the least key
+func (t *RBDict[K, V]) PollFirst() (result Entry[K, V], ok bool) {
+	if result, ok = t.Min(); ok {
+		t.Delete(result.Key())
+	}
+
+	return
+}
+
+// PollLast removes and returns the entry with the greatest key
+func (t *RBDict[K, V]) PollLast() (result Entry[K, V], ok bool) {
+	if result, ok = t.Max(); ok {
+		t.Delete(result.Key())
+	}
+
+	return
+}
+
+// HeadMap returns a live view of the entries with keys less than to
+func (t *RBDict[K, V]) HeadMap(to K) *SubDict[K, V] {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	return newSubDict[K, V](t, nil, &to)
+}
+
+// TailMap returns a live view of the entries with keys not less than from
+func (t *RBDict[K, V]) TailMap(from K) *SubDict[K, V] {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+
+	return newSubDict[K, V](t, &from, nil)
+}
+
+// SubMap returns a live view of the entries with keys within [from, to)
+func (t *RBDict[K, V]) SubMap(from, to K) *SubDict[K, V] {
+	contract.RequireInvariant(t.IsRBDict, "red-black invariant holds")
+	contract.Require(t.keyComp(from, to) <= 0, "from <= to")
+
+	return newSubDict[K, V](t, &from, &to)
+}
@@ -0,0 +1,93 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestRBDict(t *testing.T) {
+	dict := NewRBDict[string, int](order.StringComp)
+
+	_, ok := dict.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, dict.Size())
+
+	dict.Put("b", 2)
+	dict.Put("a", 1)
+	dict.Put("c", 3)
+	dict.Put("a", 10)
+	assert.Equal(t, 3, dict.Size())
+	value, ok := dict.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+	assert.Equal(t, []string{"a", "b", "c"}, dict.Keys().ToArray())
+
+	dict.Delete("d")
+	dict.Delete("b")
+	assert.Equal(t, []string{"a", "c"}, dict.Keys().ToArray())
+	assert.Equal(t, []int{10, 3}, dict.Values())
+
+	dict.Clear()
+	assert.Equal(t, 0, dict.Size())
+	assert.True(t, dict.IsRBDict())
+}
+
+func TestRBDict_Random(t *testing.T) {
+	dict := NewRBDict[int, int](order.IntComp)
+	testRandomUpdates(t, dict, 23, 50, 300, dict.IsRBDict)
+}
+
+func TestRBDict_IsRBTree(t *testing.T) {
+	dict := NewRBDict[int, int](order.IntComp)
+	for k := 1; k <= 7; k++ {
+		dict.Put(k, k)
+	}
+	flip := func() { dict.tree.Root.Left.Red = !dict.tree.Root.Left.Red }
+	testInvariantBreaks(t, dict.IsRBDict, []invariantBreak{
+		{"black height differs", flip, flip},
+		{"size is off", func() { dict.size++ }, func() { dict.size-- }},
+	})
+}
+
+func TestRBDict_Rotations(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+	dict := NewRBDict[int, int](order.IntComp)
+	keys := rand.New(rand.NewSource(23)).Perm(1 << 10)
+	for i, k := range keys {
+		before := dict.rotations
+		if i%2 == 0 {
+			dict.Put(k, k)
+		} else {
+			dict.Compute(k, func(int, bool) (int, bool) { return k, true })
+		}
+		assert.LessOrEqual(t, dict.rotations-before, 2)
+	}
+	assert.True(t, dict.IsRBDict())
+	for i, k := range keys {
+		before := dict.rotations
+		if i%2 == 0 {
+			dict.Delete(k)
+		} else {
+			dict.Compute(k, func(int, bool) (int, bool) { return 0, false })
+		}
+		assert.LessOrEqual(t, dict.rotations-before, 3)
+		_, ok := dict.Get(k)
+		assert.False(t, ok)
+	}
+	assert.Equal(t, 0, dict.Size())
+}
+
+func TestRBDict_ConditionalUpdates(t *testing.T) {
+	testConditionalUpdates(t, NewRBDict[string, int](order.StringComp))
+}
+
+func TestRBDict_Navigable(t *testing.T) {
+	testNavigable(t, NewRBDict[int, string](order.IntComp))
+}
+
+func BenchmarkRBDict(b *testing.B) {
+	benchmarkTreeDict(b, func() Dict[int, int] { return NewRBDict[int, int](order.IntComp) })
+}
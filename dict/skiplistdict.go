@@ -0,0 +1,435 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/linked"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"math/rand"
+	"time"
+)
+
+// SkipListDict keeps its entries in ascending key order on level 0 of a skip list, a node is on level i with probability 2^-i,
+// which makes lookups and updates take O(log n) expected time
+type SkipListDict[K comparable, V any] struct {
+	head     *linked.SkipNode[entry[K, V]]
+	level    int // levels in use, head.Next[i] is nil from level on
+	keyComp  order.CompareFn[K]
+	size     int
+	modCount int
+	seed     int64
+	random   *rand.Rand
+}
+
+func (d *SkipListDict[K, V]) isOrdered() bool {
+	for curr := d.head.Next[0]; curr != nil && curr.Next[0] != nil; curr = curr.Next[0] {
+		if d.keyComp(curr.Data.Key, curr.Next[0].Data.Key) >= 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *SkipListDict[K, V]) isLevelOK() bool {
+	if d.level < 1 || d.level > linked.MaxSkipLevel || d.level > 1 && d.head.Next[d.level-1] == nil {
+		return false
+	}
+	for i := d.level; i < d.head.Level(); i++ {
+		if d.head.Next[i] != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *SkipListDict[K, V]) sizeOK() bool {
+	size := 0
+	for curr := d.head.Next[0]; curr != nil; curr = curr.Next[0] {
+		size++
+	}
+
+	return d.size == size
+}
+
+// IsSkipListDict data structure invariant
+func (d *SkipListDict[K, V]) IsSkipListDict() bool {
+	return d != nil && d.keyComp != nil && d.random != nil && d.head.IsSkipList() && d.head.Level() == linked.MaxSkipLevel &&
+		d.isLevelOK() && d.isOrdered() && d.sizeOK()
+}
+
+// NewSkipListDict picks the levels of nodes with a random seed, SetSeed makes them deterministic
+func NewSkipListDict[K comparable, V any](comp order.CompareFn[K]) (result *SkipListDict[K, V]) {
+	contract.Require(comp != nil, "comparison function is not nil")
+	defer func() {
+		contract.EnsureInvariant(result.IsSkipListDict, "skip list invariant holds")
+	}()
+
+	seed := time.Now().UnixNano()
+	return &SkipListDict[K, V]{
+		head:    linked.NewSkipHead[entry[K, V]](),
+		level:   1,
+		keyComp: comp,
+		seed:    seed,
+		random:  rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Seed returns the seed the levels of nodes are drawn from
+func (d *SkipListDict[K, V]) Seed() int64 {
+	return d.seed
+}
+
+// SetSeed restarts drawing the levels of nodes added from now on from seed
+func (d *SkipListDict[K, V]) SetSeed(seed int64) {
+	d.seed = seed
+	d.random = rand.New(rand.NewSource(seed))
+}
+
+// find returns the first node with a key not less than key, or nil
+func (d *SkipListDict[K, V]) find(key K, update []*linked.SkipNode[entry[K, V]]) *linked.SkipNode[entry[K, V]] {
+	return d.head.LastBefore(d.level, keyBelow[K, V](d.keyComp, key), update).Next[0]
+}
+
+// lookup returns the node of key, or nil
+func (d *SkipListDict[K, V]) lookup(key K, update []*linked.SkipNode[entry[K, V]]) *linked.SkipNode[entry[K, V]] {
+	if node := d.find(key, update); node != nil && d.keyComp(node.Data.Key, key) == 0 {
+		return node
+	}
+
+	return nil
+}
+
+func (d *SkipListDict[K, V]) Get(key K) (V, bool) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+
+	if node := d.lookup(key, nil); node != nil {
+		return node.Data.Value, true
+	}
+	return *new(V), false
+}
+
+// link adds a node of key after the last nodes before it on each level, raising the levels in use if needed
+func (d *SkipListDict[K, V]) link(key K, value V, update []*linked.SkipNode[entry[K, V]]) {
+	node := linked.NewSkipNode(entry[K, V]{Key: key, Value: value}, linked.RandomSkipLevel(d.random))
+	for ; d.level < node.Level(); d.level++ {
+		update[d.level] = d.head
+	}
+	for i := 0; i < node.Level(); i++ {
+		node.Next[i] = update[i].Next[i]
+		update[i].Next[i] = node
+	}
+
+	d.size++
+	d.modCount++
+}
+
+// unlink removes node after the last nodes before it on each level, lowering the levels in use if needed
+func (d *SkipListDict[K, V]) unlink(node *linked.SkipNode[entry[K, V]], update []*linked.SkipNode[entry[K, V]]) {
+	for i := 0; i < node.Level(); i++ {
+		update[i].Next[i] = node.Next[i]
+	}
+	for d.level > 1 && d.head.Next[d.level-1] == nil {
+		d.level--
+	}
+
+	d.size--
+	d.modCount++
+}
+
+func (d *SkipListDict[K, V]) Put(key K, value V) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsSkipListDict, "skip list invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := d.Get(key)
+			contract.Ensure(ok, "Get(key) finds key")
+		}
+	}()
+
+	update := make([]*linked.SkipNode[entry[K, V]], linked.MaxSkipLevel)
+	if node := d.lookup(key, update); node != nil {
+		node.Data.Value = value
+		return
+	}
+	d.link(key, value, update)
+}
+
+func (d *SkipListDict[K, V]) Delete(key K) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsSkipListDict, "skip list invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := d.Get(key)
+			contract.Ensure(!ok, "Get(key) does not find key")
+		}
+	}()
+
+	update := make([]*linked.SkipNode[entry[K, V]], linked.MaxSkipLevel)
+	if node := d.lookup(key, update); node != nil {
+		d.unlink(node, update)
+	}
+}
+
+// Compute walks down to key once, and unlinks or links right there
+func (d *SkipListDict[K, V]) Compute(key K, fn func(value V, found bool) (newValue V, keep bool)) (result V, present bool) {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsSkipListDict, "skip list invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := d.Get(key)
+			contract.Ensure(ok == present, "Get(key) finds key iff it is present")
+		}
+	}()
+
+	update := make([]*linked.SkipNode[entry[K, V]], linked.MaxSkipLevel)
+	if node := d.lookup(key, update); node != nil {
+		if value, keep := fn(node.Data.Value, true); keep {
+			node.Data.Value = value
+			return value, true
+		}
+		d.unlink(node, update)
+		return *new(V), false
+	}
+
+	value, keep := fn(*new(V), false)
+	if !keep {
+		return *new(V), false
+	}
+	d.link(key, value, update)
+	return value, true
+}
+
+func (d *SkipListDict[K, V]) Clear() {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsSkipListDict, "skip list invariant holds")
+		contract.Ensure(d.size == 0, "dict is empty")
+	}()
+
+	d.head = linked.NewSkipHead[entry[K, V]]()
+	d.level = 1
+	d.size = 0
+	d.modCount++
+}
+
+func (d *SkipListDict[K, V]) Size() (result int) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+	defer func() {
+		contract.Ensure(0 <= result, "result is non-negative")
+	}()
+
+	return d.size
+}
+
+func (d *SkipListDict[K, V]) GetOrDefault(key K, def V) V {
+	if value, found := d.Get(key); found {
+		return value
+	}
+
+	return def
+}
+
+func (d *SkipListDict[K, V]) PutIfAbsent(key K, value V) (actual V, loaded bool) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+
+	return putIfAbsent[K, V](d, key, value)
+}
+
+func (d *SkipListDict[K, V]) ComputeIfAbsent(key K, fn func(K) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+
+	return computeIfAbsent[K, V](d, key, fn)
+}
+
+func (d *SkipListDict[K, V]) Merge(key K, value V, fn func(old, value V) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+
+	return merge[K, V](d, key, value, fn)
+}
+
+func (d *SkipListDict[K, V]) Replace(key K, value V) (previous V, replaced bool) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+
+	return replace[K, V](d, key, value)
+}
+
+func (d *SkipListDict[K, V]) Keys() (result *linked.List[K]) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+	defer func() {
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(result.Length() == d.size, "result has all keys")
+		}
+	}()
+
+	return keysOf(d.All(), order.Equal[K])
+}
+
+func (d *SkipListDict[K, V]) Values() (result []V) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == d.size, "result has all values")
+	}()
+
+	return valuesOf(d.All())
+}
+
+func (d *SkipListDict[K, V]) Entries() (result []Entry[K, V]) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == d.size, "result has all entries")
+	}()
+
+	return entriesOf(d.All())
+}
+
+// entryOf returns the entry of node, ok is false for nil or the head
+func (d *SkipListDict[K, V]) entryOf(node *linked.SkipNode[entry[K, V]]) (Entry[K, V], bool) {
+	if node == nil || node == d.head {
+		return nil, false
+	}
+
+	return keyValue[K, V]{key: node.Data.Key, value: node.Data.Value}, true
+}
+
+// Min returns the entry with the least key, ok is false when the dict is empty
+func (d *SkipListDict[K, V]) Min() (Entry[K, V], bool) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+
+	return d.entryOf(d.head.Next[0])
+}
+
+// Max returns the entry with the greatest key, ok is false when the dict is empty
+func (d *SkipListDict[K, V]) Max() (Entry[K, V], bool) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+
+	return d.entryOf(d.head.LastBefore(d.level, func(entry[K, V]) bool { return true }, nil))
+}
+
+// Floor returns the entry with the greatest key not greater than key
+func (d *SkipListDict[K, V]) Floor(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+	defer func() {
+		contract.Ensure(!ok || d.keyComp(result.Key(), key) <= 0, "result key <= key")
+	}()
+
+	return d.entryOf(d.head.LastBefore(d.level, keyAtMost[K, V](d.keyComp, key), nil))
+}
+
+// Ceiling returns the entry with the least key not less than key
+func (d *SkipListDict[K, V]) Ceiling(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+	defer func() {
+		contract.Ensure(!ok || d.keyComp(result.Key(), key) >= 0, "result key >= key")
+	}()
+
+	return d.entryOf(d.find(key, nil))
+}
+
+// Lower returns the entry with the greatest key less than key
+func (d *SkipListDict[K, V]) Lower(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+	defer func() {
+		contract.Ensure(!ok || d.keyComp(result.Key(), key) < 0, "result key < key")
+	}()
+
+	return d.entryOf(d.head.LastBefore(d.level, keyBelow[K, V](d.keyComp, key), nil))
+}
+
+// Higher returns the entry with the least key greater than key
+func (d *SkipListDict[K, V]) Higher(key K) (result Entry[K, V], ok bool) {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+	defer func() {
+		contract.Ensure(!ok || d.keyComp(result.Key(), key) > 0, "result key > key")
+	}()
+
+	return d.entryOf(d.head.LastBefore(d.level, keyAtMost[K, V](d.keyComp, key), nil).Next[0])
+}
+
+func (d *SkipListDict[K, V]) mods() int {
+	return d.modCount
+}
+
+// skipListDictIterator walks level 0 from next up to the first entry beforeEnd rejects
+type skipListDictIterator[K comparable, V any] struct {
+	dict      *SkipListDict[K, V]
+	next      *linked.SkipNode[entry[K, V]]
+	beforeEnd func(entry[K, V]) bool
+	last      K
+	canRemove bool
+	modCount  int
+}
+
+func newSkipListDictIterator[K comparable, V any](dict *SkipListDict[K, V], start *linked.SkipNode[entry[K, V]], beforeEnd func(entry[K, V]) bool) *skipListDictIterator[K, V] {
+	return &skipListDictIterator[K, V]{
+		dict:      dict,
+		next:      start,
+		beforeEnd: beforeEnd,
+		modCount:  dict.modCount,
+	}
+}
+
+func (it *skipListDictIterator[K, V]) HasNext() bool {
+	iterator.CheckMods(it.dict.modCount, it.modCount)
+
+	return it.next != nil && (it.beforeEnd == nil || it.beforeEnd(it.next.Data))
+}
+
+func (it *skipListDictIterator[K, V]) Next() Entry[K, V] {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	node := it.next
+	it.next = node.Next[0]
+	it.last, it.canRemove = node.Data.Key, true
+
+	return keyValue[K, V]{key: node.Data.Key, value: node.Data.Value}
+}
+
+// Remove deletes the entry last returned by Next, which leaves the node after it in place
+func (it *skipListDictIterator[K, V]) Remove() {
+	iterator.CheckMods(it.dict.modCount, it.modCount)
+	contract.Require(it.canRemove, "Next is called before Remove, and Remove at most once per Next")
+
+	it.dict.Delete(it.last)
+	it.canRemove = false
+	it.modCount = it.dict.modCount
+}
+
+// Iterator walks the entries in ascending key order
+func (d *SkipListDict[K, V]) Iterator() iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+
+	return newSkipListDictIterator[K, V](d, d.head.Next[0], nil)
+}
+
+// All walks the entries in ascending key order
+func (d *SkipListDict[K, V]) All() iterator.Seq2[K, V] {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+
+	return iterator.FailFastSeq2(func(yield func(K, V) bool) {
+		for curr := d.head.Next[0]; curr != nil; curr = curr.Next[0] {
+			if !yield(curr.Data.Key, curr.Data.Value) {
+				return
+			}
+		}
+	}, d.mods)
+}
+
+// Range walks the entries with keys within [lo, hi) in ascending key order
+func (d *SkipListDict[K, V]) Range(lo, hi K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+	contract.Require(d.keyComp(lo, hi) <= 0, "lo <= hi")
+
+	return newSkipListDictIterator[K, V](d, d.find(lo, nil), keyBelow[K, V](d.keyComp, hi))
+}
+
+// From walks the entries with keys not less than key in ascending key order
+func (d *SkipListDict[K, V]) From(key K) iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(d.IsSkipListDict, "skip list invariant holds")
+
+	return newSkipListDictIterator[K, V](d, d.find(key, nil), nil)
+}
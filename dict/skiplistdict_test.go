@@ -0,0 +1,134 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSkipListDict(t *testing.T) {
+	dict := NewSkipListDict[string, int](order.StringComp)
+
+	_, ok := dict.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, dict.Size())
+
+	dict.Put("b", 2)
+	dict.Put("a", 1)
+	dict.Put("c", 3)
+	dict.Put("a", 10)
+	assert.Equal(t, 3, dict.Size())
+	value, ok := dict.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+	assert.Equal(t, []string{"a", "b", "c"}, dict.Keys().ToArray())
+
+	dict.Delete("d")
+	dict.Delete("b")
+	assert.Equal(t, []string{"a", "c"}, dict.Keys().ToArray())
+	assert.Equal(t, []int{10, 3}, dict.Values())
+
+	dict.Clear()
+	assert.Equal(t, 0, dict.Size())
+	assert.True(t, dict.IsSkipListDict())
+}
+
+func TestSkipListDict_Random(t *testing.T) {
+	dict := NewSkipListDict[int, int](order.IntComp)
+	dict.SetSeed(25)
+	testRandomUpdates(t, dict, 25, 50, 300, dict.IsSkipListDict)
+}
+
+func TestSkipListDict_Seed(t *testing.T) {
+	levels := func(seed int64) (result []int) {
+		dict := NewSkipListDict[int, int](order.IntComp)
+		dict.SetSeed(seed)
+		for k := 0; k < 32; k++ {
+			dict.Put(k, k)
+		}
+		assert.Equal(t, seed, dict.Seed())
+		for curr := dict.head.Next[0]; curr != nil; curr = curr.Next[0] {
+			result = append(result, curr.Level())
+		}
+		return
+	}
+
+	assert.Equal(t, levels(3), levels(3))
+	assert.NotEqual(t, levels(3), levels(4))
+}
+
+func TestSkipListDict_Navigable(t *testing.T) {
+	dict := NewSkipListDict[int, string](order.IntComp)
+	assert.Equal(t, -1, keyOf(dict.Min()))
+	assert.Equal(t, -1, keyOf(dict.Max()))
+
+	for k := 10; k <= 50; k += 10 {
+		dict.Put(k, "v")
+	}
+	assert.Equal(t, 10, keyOf(dict.Min()))
+	assert.Equal(t, 50, keyOf(dict.Max()))
+	assert.Equal(t, 20, keyOf(dict.Floor(25)))
+	assert.Equal(t, 20, keyOf(dict.Floor(20)))
+	assert.Equal(t, -1, keyOf(dict.Floor(5)))
+	assert.Equal(t, 30, keyOf(dict.Ceiling(25)))
+	assert.Equal(t, 30, keyOf(dict.Ceiling(30)))
+	assert.Equal(t, -1, keyOf(dict.Ceiling(55)))
+	assert.Equal(t, 20, keyOf(dict.Lower(30)))
+	assert.Equal(t, -1, keyOf(dict.Lower(10)))
+	assert.Equal(t, 40, keyOf(dict.Higher(30)))
+	assert.Equal(t, -1, keyOf(dict.Higher(50)))
+}
+
+func TestSkipListDict_Range(t *testing.T) {
+	dict := NewSkipListDict[int, int](order.IntComp)
+	for k := 0; k < 20; k++ {
+		dict.Put(k, -k)
+	}
+
+	var keys []int
+	for it := dict.Range(5, 8); it.HasNext(); {
+		e := it.Next()
+		assert.Equal(t, -e.Key(), e.Value())
+		keys = append(keys, e.Key())
+	}
+	assert.Equal(t, []int{5, 6, 7}, keys)
+	assert.False(t, dict.Range(5, 5).HasNext())
+
+	keys = keys[:0]
+	for it := dict.From(17); it.HasNext(); {
+		keys = append(keys, it.Next().Key())
+	}
+	assert.Equal(t, []int{17, 18, 19}, keys)
+
+	for it := dict.Range(2, 18); it.HasNext(); {
+		if it.Next().Key()%4 != 0 {
+			it.Remove()
+		}
+	}
+	assert.Equal(t, []int{0, 1, 4, 8, 12, 16, 18, 19}, dict.Keys().ToArray())
+
+	it := dict.Iterator()
+	dict.Put(100, 100)
+	assert.Panics(t, func() { it.HasNext() })
+}
+
+func TestSkipListDict_IsSkipListDict(t *testing.T) {
+	dict := NewSkipListDict[int, int](order.IntComp)
+	for k := 0; k < 8; k++ {
+		dict.Put(k, k)
+	}
+	first := dict.head.Next[0]
+	testInvariantBreaks(t, dict.IsSkipListDict, []invariantBreak{
+		{"keys are unsorted", func() { first.Data.Key = 100 }, func() { first.Data.Key = 0 }},
+		{"size is off", func() { dict.size++ }, func() { dict.size-- }},
+		{"level is off", func() { dict.level++ }, func() { dict.level-- }},
+	})
+}
+
+func TestSkipListDict_ConditionalUpdates(t *testing.T) {
+	testConditionalUpdates(t, NewSkipListDict[string, int](order.StringComp))
+}
+
+func BenchmarkSkipListDict(b *testing.B) {
+	benchmarkTreeDict(b, func() Dict[int, int] { return NewSkipListDict[int, int](order.IntComp) })
+}
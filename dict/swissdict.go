@@ -0,0 +1,600 @@
+package dict
+
+import (
+	"encoding/binary"
+	"github.com/song-flying/GoDataStructures/linked"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/hash"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"math/bits"
+)
+
+const groupSize = 16
+
+// DefaultGroupProbeLimit is the count of groups an insertion probes past which a SwissDict picks a fresh seed
+const DefaultGroupProbeLimit = 8
+
+// control bytes, a full slot holds the 7 low bits of the hash of its key as tag
+const (
+	ctrlEmpty   byte = 0b1000_0000
+	ctrlDeleted byte = 0b1111_1110
+)
+
+const (
+	lsbs = 0x0101010101010101
+	msbs = 0x8080808080808080
+)
+
+// group holds the control bytes of its slots apart, so that 8 of them at a time fit into a word
+type group[K any, V any] struct {
+	ctrl  [groupSize]byte
+	slots [groupSize]entry[K, V]
+}
+
+// pack gathers the high bit of each byte of m into one bit per byte
+func pack(m uint64) uint16 {
+	return uint16((m >> 7 & lsbs) * 0x0102040810204080 >> 56)
+}
+
+// match returns one bit per slot whose control byte may equal tag, false positives only hit full slots
+func (g *group[K, V]) match(tag byte) uint16 {
+	matchWord := func(w uint64) uint16 {
+		x := w ^ lsbs*uint64(tag)
+		return pack((x - lsbs) &^ x & msbs)
+	}
+
+	return matchWord(binary.LittleEndian.Uint64(g.ctrl[:8])) | matchWord(binary.LittleEndian.Uint64(g.ctrl[8:]))<<8
+}
+
+// matchEmpty returns one bit per empty slot, deleted slots have the bit 1 set unlike empty ones
+func (g *group[K, V]) matchEmpty() uint16 {
+	matchWord := func(w uint64) uint16 {
+		return pack(w &^ (w << 6) & msbs)
+	}
+
+	return matchWord(binary.LittleEndian.Uint64(g.ctrl[:8])) | matchWord(binary.LittleEndian.Uint64(g.ctrl[8:]))<<8
+}
+
+// matchFree returns one bit per empty or deleted slot
+func (g *group[K, V]) matchFree() uint16 {
+	return pack(binary.LittleEndian.Uint64(g.ctrl[:8])&msbs) | pack(binary.LittleEndian.Uint64(g.ctrl[8:])&msbs)<<8
+}
+
+// SwissDict keeps groups of 16 slots with one control byte each, a lookup compares 7 bits of the hash
+// against all control bytes of a group at once and only compares keys whose tag matches.
+// Groups are probed quadratically, deleted slots stay as tombstones until the next rehash
+// unless their group still has an empty slot.
+type SwissDict[K any, V any] struct {
+	size       int
+	tombstones int
+	groups     []group[K, V]
+	hashFn     hash.KeyedHasher[K]
+	seed       hash.Seed
+	equalFn    order.Equaler[K]
+	modCount   int
+	probeLimit int
+	reseeds    int
+}
+
+func (d *SwissDict[K, V]) maxFill() int {
+	return len(d.groups) * groupSize * 7 / 8
+}
+
+func (d *SwissDict[K, V]) countOK() bool {
+	size, tombstones := 0, 0
+	for g := range d.groups {
+		for _, c := range d.groups[g].ctrl {
+			if c == ctrlDeleted {
+				tombstones++
+			} else if c != ctrlEmpty {
+				size++
+			}
+		}
+	}
+
+	return d.size == size && d.tombstones == tombstones
+}
+
+// probeOK holds when every key has its tag and is reached by probing from its home group without passing an empty slot
+func (d *SwissDict[K, V]) probeOK() bool {
+	for g := range d.groups {
+		for i, c := range d.groups[g].ctrl {
+			if c == ctrlEmpty || c == ctrlDeleted {
+				continue
+			}
+
+			start, tag := d.hashOfKey(d.groups[g].slots[i].Key)
+			if c != tag {
+				return false
+			}
+			for p := d.probe(start); p.index != g; p.next() {
+				if d.groups[p.index].matchEmpty() != 0 {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// IsSwissDict data structure invariant
+func (d *SwissDict[K, V]) IsSwissDict() bool {
+	return d != nil && 0 < len(d.groups) && len(d.groups)&(len(d.groups)-1) == 0 && d.hashFn != nil && d.equalFn != nil && 0 < d.probeLimit &&
+		0 <= d.size && 0 <= d.tombstones && d.size+d.tombstones <= d.maxFill() && d.countOK() && d.probeOK()
+}
+
+// NewSwissDict compares keys with ==, a nil hashFn stands for hash.OfKeyed[K](), which hashes strings with SipHash.
+func NewSwissDict[K comparable, V any](capacity int, hashFn HashFn[K]) (result *SwissDict[K, V]) {
+	if hashFn == nil {
+		return NewSwissDictKeyed[K, V](capacity, hash.OfKeyed[K](), order.Equal[K])
+	}
+
+	return NewSwissDictFunc[K, V](capacity, hash.Hasher[K](hashFn), order.Equal[K])
+}
+
+// NewSwissDictFunc compares keys with equalFn, hashFn must agree with it.
+// The dict holds capacity entries before it first grows.
+func NewSwissDictFunc[K any, V any](capacity int, hashFn hash.Hasher[K], equalFn order.Equaler[K]) (result *SwissDict[K, V]) {
+	contract.Require(hashFn != nil, "hash function is not nil")
+
+	return NewSwissDictKeyed[K, V](capacity, hash.Keyed(hashFn), equalFn)
+}
+
+// NewSwissDictKeyed hashes with a KeyedHasher like hash.SipString, so that probes stay short even for keys chosen by an attacker.
+// Whenever an insertion probes more than DefaultGroupProbeLimit groups, all keys are rehashed with a fresh random seed.
+func NewSwissDictKeyed[K any, V any](capacity int, hashFn hash.KeyedHasher[K], equalFn order.Equaler[K]) (result *SwissDict[K, V]) {
+	contract.Require(0 < capacity, "capacity is positive")
+	contract.Require(hashFn != nil, "hash function is not nil")
+	contract.Require(equalFn != nil, "equality function is not nil")
+	defer func() {
+		contract.EnsureInvariant(result.IsSwissDict, "swiss dict invariant holds")
+	}()
+
+	groups := 1
+	for groups*groupSize*7/8 < capacity {
+		groups *= 2
+	}
+
+	return &SwissDict[K, V]{
+		groups:     newGroups[K, V](groups),
+		hashFn:     hashFn,
+		seed:       hash.RandomSeed(),
+		equalFn:    equalFn,
+		probeLimit: DefaultGroupProbeLimit,
+	}
+}
+
+func newGroups[K any, V any](n int) []group[K, V] {
+	groups := make([]group[K, V], n)
+	for g := range groups {
+		for i := range groups[g].ctrl {
+			groups[g].ctrl[i] = ctrlEmpty
+		}
+	}
+
+	return groups
+}
+
+// hashOfKey splits the hash of key into the 57 bits choosing the home group and the 7 bit tag
+func (d *SwissDict[K, V]) hashOfKey(key K) (start uint64, tag byte) {
+	h := uint64(d.hashFn(d.seed, key))
+	return h >> 7, byte(h & 0x7F)
+}
+
+// probeSeq visits the groups at triangular offsets from the home group, which covers all of a power of two of groups
+type probeSeq struct {
+	mask   int
+	index  int
+	stride int
+}
+
+func (d *SwissDict[K, V]) probe(start uint64) probeSeq {
+	mask := len(d.groups) - 1
+	return probeSeq{mask: mask, index: int(start & uint64(mask))}
+}
+
+func (p *probeSeq) next() {
+	p.stride++
+	p.index = (p.index + p.stride) & p.mask
+}
+
+// find returns the group and slot holding key, it stops at the first group with an empty slot
+func (d *SwissDict[K, V]) find(key K) (g, i int, found bool) {
+	start, tag := d.hashOfKey(key)
+	for p := d.probe(start); ; p.next() {
+		grp := &d.groups[p.index]
+		for m := grp.match(tag); m != 0; m &= m - 1 {
+			i := bits.TrailingZeros16(m)
+			if d.equalFn(grp.slots[i].Key, key) {
+				return p.index, i, true
+			}
+		}
+		if grp.matchEmpty() != 0 {
+			return -1, -1, false
+		}
+	}
+}
+
+// place stores e, whose key is not in d, in the first free slot along its probe sequence,
+// probed counts the groups visited up to that slot
+func (d *SwissDict[K, V]) place(e entry[K, V]) (probed int) {
+	start, tag := d.hashOfKey(e.Key)
+	for p := d.probe(start); ; p.next() {
+		probed++
+		grp := &d.groups[p.index]
+		if m := grp.matchFree(); m != 0 {
+			i := bits.TrailingZeros16(m)
+			if grp.ctrl[i] == ctrlDeleted {
+				d.tombstones--
+			}
+			grp.ctrl[i] = tag
+			grp.slots[i] = e
+			d.size++
+			return probed
+		}
+	}
+}
+
+func (d *SwissDict[K, V]) Get(key K) (V, bool) {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+
+	if g, i, found := d.find(key); found {
+		return d.groups[g].slots[i].Value, true
+	}
+
+	return *new(V), false
+}
+
+func (d *SwissDict[K, V]) Put(key K, value V) {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsSwissDict, "swiss dict invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := d.Get(key)
+			contract.Ensure(ok, "Get(key) finds key")
+		}
+	}()
+
+	if g, i, found := d.find(key); found {
+		d.groups[g].slots[i].Value = value
+		return
+	}
+
+	d.insert(key, value)
+}
+
+// insert places key, which is not in d, growing or dropping tombstones first if needed
+func (d *SwissDict[K, V]) insert(key K, value V) {
+	if d.size+d.tombstones >= d.maxFill() {
+		// a table mostly filled with tombstones is only cleaned up
+		if 2*(d.size+1) > d.maxFill() {
+			d.resize(2 * len(d.groups))
+		} else {
+			d.resize(len(d.groups))
+		}
+	}
+	probed := d.place(entry[K, V]{Key: key, Value: value})
+	d.modCount++
+
+	if probed > d.probeLimit {
+		d.reseed()
+	}
+}
+
+// reseed rehashes with a fresh random seed once a probe got too long, which takes bad luck or an attack.
+// If a probe is still too long, its keys collide under every seed, so probeLimit is doubled to not rehash on every insertion.
+func (d *SwissDict[K, V]) reseed() {
+	d.seed = hash.RandomSeed()
+	d.rehash(len(d.groups))
+	d.reseeds++
+
+	if d.longestProbe() > d.probeLimit {
+		d.probeLimit *= 2
+	}
+}
+
+// longestProbe returns the most groups a lookup of a present key visits
+func (d *SwissDict[K, V]) longestProbe() (result int) {
+	for g := range d.groups {
+		for i, c := range d.groups[g].ctrl {
+			if c == ctrlEmpty || c == ctrlDeleted {
+				continue
+			}
+
+			start, _ := d.hashOfKey(d.groups[g].slots[i].Key)
+			probed := 1
+			for p := d.probe(start); p.index != g; p.next() {
+				probed++
+			}
+			if probed > result {
+				result = probed
+			}
+		}
+	}
+
+	return
+}
+
+func (d *SwissDict[K, V]) Delete(key K) {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsSwissDict, "swiss dict invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := d.Get(key)
+			contract.Ensure(!ok, "Get() returns no value for key")
+		}
+	}()
+
+	g, i, found := d.find(key)
+	if !found {
+		return
+	}
+
+	d.removeAt(g, i)
+	d.shrink()
+}
+
+func (d *SwissDict[K, V]) shrink() {
+	if len(d.groups) > 1 && 16*d.size <= len(d.groups)*groupSize {
+		d.resize(len(d.groups) / 2)
+	}
+}
+
+func (d *SwissDict[K, V]) Compute(key K, fn func(value V, found bool) (newValue V, keep bool)) (result V, present bool) {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsSwissDict, "swiss dict invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			_, ok := d.Get(key)
+			contract.Ensure(ok == present, "Get(key) finds key iff it is present")
+		}
+	}()
+
+	if g, i, found := d.find(key); found {
+		if value, keep := fn(d.groups[g].slots[i].Value, true); keep {
+			d.groups[g].slots[i].Value = value
+			return value, true
+		}
+		d.removeAt(g, i)
+		d.shrink()
+		return *new(V), false
+	}
+
+	value, keep := fn(*new(V), false)
+	if !keep {
+		return *new(V), false
+	}
+	d.insert(key, value)
+	return value, true
+}
+
+// Clear keeps the capacity
+func (d *SwissDict[K, V]) Clear() {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+	defer func() {
+		contract.EnsureInvariant(d.IsSwissDict, "swiss dict invariant holds")
+		contract.Ensure(d.size == 0, "dict is empty")
+	}()
+
+	d.groups = newGroups[K, V](len(d.groups))
+	d.size = 0
+	d.tombstones = 0
+	d.modCount++
+}
+
+// removeAt frees slot i of group g, a group with an empty slot ends every probe sequence reaching it,
+// so only a full group needs a tombstone. It does not shrink the table, so that iterators can remove too.
+func (d *SwissDict[K, V]) removeAt(g, i int) {
+	grp := &d.groups[g]
+	if grp.matchEmpty() != 0 {
+		grp.ctrl[i] = ctrlEmpty
+	} else {
+		grp.ctrl[i] = ctrlDeleted
+		d.tombstones++
+	}
+	grp.slots[i] = entry[K, V]{}
+	d.size--
+	d.modCount++
+}
+
+func (d *SwissDict[K, V]) resize(newGroups int) {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+	contract.Require(0 < newGroups && newGroups&(newGroups-1) == 0, "new count of groups is a power of two")
+	defer func(oldSize int) {
+		contract.EnsureInvariant(d.IsSwissDict, "swiss dict invariant holds")
+		contract.Ensure(oldSize == d.size, "resize does not change count of entries")
+		contract.Ensure(d.tombstones == 0, "resize drops tombstones")
+	}(d.size)
+
+	d.rehash(newGroups)
+}
+
+// rehash places all entries into n new groups, by the current seed
+func (d *SwissDict[K, V]) rehash(n int) {
+	oldGroups := d.groups
+	d.groups = newGroups[K, V](n)
+	d.size = 0
+	d.tombstones = 0
+
+	for g := range oldGroups {
+		for i, c := range oldGroups[g].ctrl {
+			if c != ctrlEmpty && c != ctrlDeleted {
+				d.place(oldGroups[g].slots[i])
+			}
+		}
+	}
+	d.modCount++
+}
+
+func (d *SwissDict[K, V]) Size() (result int) {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+	defer func() {
+		contract.Ensure(result >= 0, "result is non-negative")
+	}()
+
+	return d.size
+}
+
+func (d *SwissDict[K, V]) GetOrDefault(key K, def V) V {
+	if value, found := d.Get(key); found {
+		return value
+	}
+
+	return def
+}
+
+func (d *SwissDict[K, V]) PutIfAbsent(key K, value V) (actual V, loaded bool) {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+
+	return putIfAbsent[K, V](d, key, value)
+}
+
+func (d *SwissDict[K, V]) ComputeIfAbsent(key K, fn func(K) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+
+	return computeIfAbsent[K, V](d, key, fn)
+}
+
+func (d *SwissDict[K, V]) Merge(key K, value V, fn func(old, value V) V) V {
+	contract.Require(fn != nil, "fn is not nil")
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+
+	return merge[K, V](d, key, value, fn)
+}
+
+func (d *SwissDict[K, V]) Replace(key K, value V) (previous V, replaced bool) {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+
+	return replace[K, V](d, key, value)
+}
+
+func (d *SwissDict[K, V]) Keys() (result *linked.List[K]) {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+	defer func() {
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(result.Length() == d.size, "result has all keys")
+		}
+	}()
+
+	return keysOf(d.All(), d.equalFn)
+}
+
+func (d *SwissDict[K, V]) Values() (result []V) {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == d.size, "result has all values")
+	}()
+
+	return valuesOf(d.All())
+}
+
+func (d *SwissDict[K, V]) Entries() (result []Entry[K, V]) {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+	defer func() {
+		contract.Ensure(len(result) == d.size, "result has all entries")
+	}()
+
+	return entriesOf(d.All())
+}
+
+// Seed returns the seed keying the hash function of this swiss dict
+func (d *SwissDict[K, V]) Seed() hash.Seed {
+	return d.seed
+}
+
+// Reseeds returns how often a too long probe made this swiss dict pick a fresh seed
+func (d *SwissDict[K, V]) Reseeds() int {
+	return d.reseeds
+}
+
+// SetSeed rehashes all entries with seed, which is random by default
+func (d *SwissDict[K, V]) SetSeed(seed hash.Seed) {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+	defer func(oldSize int) {
+		contract.EnsureInvariant(d.IsSwissDict, "swiss dict invariant holds")
+		contract.Ensure(oldSize == d.size, "rehashing does not change count of entries")
+		contract.Ensure(d.seed == seed, "rehashing changes seed")
+	}(d.size)
+
+	d.seed = seed
+	d.rehash(len(d.groups))
+}
+
+type swissDictIterator[K any, V any] struct {
+	d        *SwissDict[K, V]
+	index    int
+	last     int
+	modCount int
+}
+
+func (it *swissDictIterator[K, V]) skipFreeSlots() {
+	for it.index < len(it.d.groups)*groupSize {
+		if c := it.d.groups[it.index/groupSize].ctrl[it.index%groupSize]; c != ctrlEmpty && c != ctrlDeleted {
+			return
+		}
+		it.index++
+	}
+}
+
+func (it *swissDictIterator[K, V]) HasNext() bool {
+	iterator.CheckMods(it.d.modCount, it.modCount)
+
+	return it.index < len(it.d.groups)*groupSize
+}
+
+func (it *swissDictIterator[K, V]) Next() Entry[K, V] {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	it.last = it.index
+	it.index++
+	it.skipFreeSlots()
+
+	e := it.d.groups[it.last/groupSize].slots[it.last%groupSize]
+	return keyValue[K, V]{key: e.Key, value: e.Value}
+}
+
+// Remove deletes the entry last returned by Next, the table is not shrunk until the next Delete
+func (it *swissDictIterator[K, V]) Remove() {
+	iterator.CheckMods(it.d.modCount, it.modCount)
+	contract.Require(it.last >= 0, "Next is called after the last Remove")
+	defer func() {
+		contract.EnsureInvariant(it.d.IsSwissDict, "swiss dict invariant holds")
+	}()
+
+	it.d.removeAt(it.last/groupSize, it.last%groupSize)
+	it.last = -1
+	it.modCount = it.d.modCount
+}
+
+// Iterator walks the entries in no particular order
+func (d *SwissDict[K, V]) Iterator() iterator.MutableIterator[Entry[K, V]] {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+
+	it := &swissDictIterator[K, V]{
+		d:        d,
+		last:     -1,
+		modCount: d.modCount,
+	}
+	it.skipFreeSlots()
+
+	return it
+}
+
+// All walks the entries in no particular order
+func (d *SwissDict[K, V]) All() iterator.Seq2[K, V] {
+	contract.RequireInvariant(d.IsSwissDict, "swiss dict invariant holds")
+
+	return iterator.FailFastSeq2(func(yield func(K, V) bool) {
+		for g := range d.groups {
+			for i, c := range d.groups[g].ctrl {
+				if c != ctrlEmpty && c != ctrlDeleted && !yield(d.groups[g].slots[i].Key, d.groups[g].slots[i].Value) {
+					return
+				}
+			}
+		}
+	}, func() int { return d.modCount })
+}
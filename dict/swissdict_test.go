@@ -0,0 +1,206 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/hash"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func TestGroup_Match(t *testing.T) {
+	var g group[int, int]
+	for i := range g.ctrl {
+		g.ctrl[i] = ctrlEmpty
+	}
+	g.ctrl[0], g.ctrl[3], g.ctrl[9], g.ctrl[15] = 0x11, ctrlDeleted, 0x11, 0x7F
+
+	assert.Equal(t, uint16(1<<0|1<<9), g.match(0x11))
+	assert.Equal(t, uint16(1<<15), g.match(0x7F))
+	assert.Zero(t, g.match(0x22))
+	assert.Equal(t, ^uint16(1<<0|1<<3|1<<9|1<<15), g.matchEmpty())
+	assert.Equal(t, ^uint16(1<<0|1<<9|1<<15), g.matchFree())
+}
+
+func TestSwissDict(t *testing.T) {
+	dict := NewSwissDict[string, int](1, hash.String)
+	v, ok := dict.Get("hello")
+	assert.False(t, ok)
+	assert.Equal(t, 0, v)
+	assert.Equal(t, 0, dict.Size())
+
+	dict.Put("hello", 1)
+	v, ok = dict.Get("hello")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 1, dict.Size())
+
+	dict.Put("world", 2)
+	v, ok = dict.Get("world")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 2, dict.Size())
+
+	dict.Put("hello", 3)
+	v, ok = dict.Get("hello")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	assert.Equal(t, 2, dict.Size())
+
+	dict.Delete("world")
+	v, ok = dict.Get("world")
+	assert.False(t, ok)
+	assert.Equal(t, 1, dict.Size())
+
+	dict.Delete("hello")
+	assert.Equal(t, 0, dict.Size())
+
+	for i := 0; i < 100; i++ {
+		dict.Put(strconv.Itoa(i), i)
+	}
+	assert.Equal(t, 8, len(dict.groups))
+
+	for i := 0; i < 95; i++ {
+		dict.Delete(strconv.Itoa(i))
+	}
+	assert.Equal(t, 4, len(dict.groups))
+}
+
+func TestSwissDict_Random(t *testing.T) {
+	dict := NewSwissDict[int, int](1, nil)
+	// few distinct hashes fill whole groups, so that probing and tombstones come into play
+	dict.hashFn = func(s hash.Seed, x int) int { return s.Mix(x%8) &^ 0x7F }
+	testRandomUpdates(t, dict, 42, 200, 3000, dict.IsSwissDict)
+}
+
+func TestSwissDict_Iterator(t *testing.T) {
+	var dict Dict[string, int] = NewSwissDict[string, int](1, hash.String)
+	assert.False(t, dict.Iterator().HasNext())
+
+	var keys []string
+	for i := 0; i < 40; i++ {
+		keys = append(keys, strconv.Itoa(i))
+		dict.Put(strconv.Itoa(i), i)
+	}
+
+	var fromIterator []string
+	for it := dict.Iterator(); it.HasNext(); {
+		e := it.Next()
+		assert.Equal(t, e.Key(), strconv.Itoa(e.Value()))
+		fromIterator = append(fromIterator, e.Key())
+	}
+	assert.ElementsMatch(t, keys, fromIterator)
+
+	var fromAll []string
+	dict.All()(func(k string, v int) bool {
+		fromAll = append(fromAll, k)
+		return len(fromAll) < 3
+	})
+	assert.Equal(t, fromIterator[:3], fromAll)
+}
+
+func TestSwissDict_IteratorRemove(t *testing.T) {
+	dict := NewSwissDict[int, string](1, nil)
+	for i := 0; i < 40; i++ {
+		dict.Put(i, strconv.Itoa(i))
+	}
+
+	for it := dict.Iterator(); it.HasNext(); {
+		if it.Next().Key()%2 == 1 {
+			it.Remove()
+		}
+	}
+	var keys []int
+	dict.All()(func(k int, v string) bool {
+		keys = append(keys, k)
+		return true
+	})
+	assert.Len(t, keys, 20)
+	for _, k := range keys {
+		assert.Equal(t, 0, k%2)
+	}
+	assert.Equal(t, 20, dict.Size())
+
+	it := dict.Iterator()
+	it.Next()
+	dict.Put(40, "40")
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.Remove() })
+
+	dict.Put(40, "forty")
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.Next() })
+}
+
+func TestSwissDict_CustomEquality(t *testing.T) {
+	words := NewSwissDictFunc[string, int](1, hash.CaseInsensitiveString, order.Equivalent(order.CaseInsensitiveComp))
+	for _, w := range []string{"Go", "go", "GO", "rust"} {
+		n, _ := words.Get(w)
+		words.Put(w, n+1)
+	}
+	n, _ := words.Get("gO")
+	assert.Equal(t, 3, n)
+	assert.Equal(t, 2, words.Size())
+}
+
+func TestSwissDict_Seed(t *testing.T) {
+	testSeed(t, func() *SwissDict[int, int] { return NewSwissDict[int, int](1, nil) },
+		func(dict *SwissDict[int, int], key int) any {
+			start, tag := dict.hashOfKey(key)
+			return [2]any{start, tag}
+		})
+}
+
+func TestSwissDict_Reseed(t *testing.T) {
+	dict := NewSwissDictKeyed[string, int](4096, hash.SipString, order.Equal[string])
+	dict.SetSeed(hash.FixedSeed(1, 2))
+
+	// keys sharing a home group under the known seed, as an attacker would pick them
+	var keys []string
+	for i := 0; len(keys) <= DefaultGroupProbeLimit*groupSize; i++ {
+		k := strconv.Itoa(i)
+		if start, _ := dict.hashOfKey(k); dict.probe(start).index == 0 {
+			keys = append(keys, k)
+		}
+	}
+	for i, k := range keys {
+		dict.Put(k, i)
+	}
+
+	assert.Equal(t, 1, dict.Reseeds())
+	assert.NotEqual(t, hash.FixedSeed(1, 2), dict.Seed())
+	assert.LessOrEqual(t, dict.longestProbe(), DefaultGroupProbeLimit)
+	for i, k := range keys {
+		v, ok := dict.Get(k)
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+}
+
+func TestSwissDict_ReseedCollidingHasher(t *testing.T) {
+	// all keys collide under every seed, reseeding gives up instead of rehashing on every Put
+	dict := NewSwissDict[int, int](1024, func(int) int { return 0 })
+	for i := 0; i < 1000; i++ {
+		dict.Put(i, i)
+	}
+
+	assert.LessOrEqual(t, dict.Reseeds(), 4)
+	for i := 0; i < 1000; i++ {
+		v, ok := dict.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+}
+
+func TestSwissDict_DefaultHashesStringsWithSipHash(t *testing.T) {
+	dict := NewSwissDict[string, int](1, nil)
+	dict.SetSeed(hash.FixedSeed(1, 2))
+	assert.Equal(t, hash.SipString(hash.FixedSeed(1, 2), "go"), dict.hashFn(dict.Seed(), "go"))
+}
+
+func BenchmarkSwissDict_Get(b *testing.B) {
+	benchmarkDictGet(b, NewSwissDict[int, int](1, nil))
+}
+
+func BenchmarkSwissDict_Put(b *testing.B) {
+	benchmarkDictPut(b, func() Dict[int, int] { return NewSwissDict[int, int](1, nil) })
+}
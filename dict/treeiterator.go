@@ -0,0 +1,79 @@
+package dict
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/tree"
+)
+
+// treeDict is what treeDictIterator and SubDict need from AVLDict, BSTDict and RBDict
+type treeDict[K comparable, V any] interface {
+	walk(descending bool, afterStart, beforeEnd func(entry[K, V]) bool) *tree.OrderedIterator[entry[K, V]]
+	compareKeys(a, b K) int
+	mods() int
+	Get(key K) (V, bool)
+	Put(key K, value V)
+	Delete(key K)
+	Min() (Entry[K, V], bool)
+	Max() (Entry[K, V], bool)
+	Floor(key K) (Entry[K, V], bool)
+	Ceiling(key K) (Entry[K, V], bool)
+	Lower(key K) (Entry[K, V], bool)
+	Higher(key K) (Entry[K, V], bool)
+}
+
+// treeDictIterator makes the ordered walks of AVLDict, BSTDict and RBDict fail-fast and able to remove entries
+type treeDictIterator[K comparable, V any] struct {
+	dict       treeDict[K, V]
+	descending bool
+	beforeEnd  func(entry[K, V]) bool
+	walk       *tree.OrderedIterator[entry[K, V]]
+	last       entry[K, V]
+	canRemove  bool
+	modCount   int
+}
+
+func newTreeDictIterator[K comparable, V any](dict treeDict[K, V], descending bool, afterStart, beforeEnd func(entry[K, V]) bool) *treeDictIterator[K, V] {
+	return &treeDictIterator[K, V]{
+		dict:       dict,
+		descending: descending,
+		beforeEnd:  beforeEnd,
+		walk:       dict.walk(descending, afterStart, beforeEnd),
+		modCount:   dict.mods(),
+	}
+}
+
+func (it *treeDictIterator[K, V]) HasNext() bool {
+	iterator.CheckMods(it.dict.mods(), it.modCount)
+
+	return it.walk.HasNext()
+}
+
+func (it *treeDictIterator[K, V]) Next() Entry[K, V] {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	it.last = it.walk.Next()
+	it.canRemove = true
+
+	return keyValue[K, V]{key: it.last.Key, value: it.last.Value}
+}
+
+// Remove deletes the entry last returned by Next,
+// the walk then restarts right after it in O(log n) as deleting may restructure the tree
+func (it *treeDictIterator[K, V]) Remove() {
+	iterator.CheckMods(it.dict.mods(), it.modCount)
+	contract.Require(it.canRemove, "Next is called after the last Remove")
+
+	it.dict.Delete(it.last.Key)
+	it.canRemove = false
+	it.modCount = it.dict.mods()
+
+	last, descending := it.last.Key, it.descending
+	afterLast := func(e entry[K, V]) bool {
+		if descending {
+			return it.dict.compareKeys(e.Key, last) < 0
+		}
+		return it.dict.compareKeys(e.Key, last) > 0
+	}
+	it.walk = it.dict.walk(descending, afterLast, it.beforeEnd)
+}
@@ -1,15 +1,41 @@
 package dict
 
+import (
+	"github.com/song-flying/GoDataStructures/linked"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+)
+
 type (
 	Entry[K any, V any] interface {
 		Key() K
 		Value() V
 	}
 
-	Dict[K comparable, V comparable] interface {
+	// Dict implements every conditional update in a single traversal, their fn must not modify the dict
+	Dict[K any, V any] interface {
 		Get(key K) (V, bool)
+		// GetOrDefault returns def if key is absent
+		GetOrDefault(key K, def V) V
 		Put(key K, value V)
+		// PutIfAbsent stores value unless key is present, actual is the value stored for key afterwards
+		PutIfAbsent(key K, value V) (actual V, loaded bool)
+		// Compute stores the value fn returns for key, or removes key if keep is false,
+		// fn gets the current value and whether key is present, the result is the value stored for key afterwards
+		Compute(key K, fn func(value V, found bool) (newValue V, keep bool)) (V, bool)
+		// ComputeIfAbsent stores fn(key) unless key is present, the result is the value stored for key afterwards
+		ComputeIfAbsent(key K, fn func(K) V) V
+		// Merge stores value if key is absent, else fn of the current value and value
+		Merge(key K, value V, fn func(old, value V) V) V
+		// Replace stores value only if key is present
+		Replace(key K, value V) (previous V, replaced bool)
 		Delete(key K)
+		Clear()
 		Size() int
+		// Keys, Values and Entries list the dict in iteration order
+		Keys() *linked.List[K]
+		Values() []V
+		Entries() []Entry[K, V]
+		Iterator() iterator.MutableIterator[Entry[K, V]]
+		All() iterator.Seq2[K, V]
 	}
 )
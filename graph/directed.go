@@ -5,10 +5,13 @@ import (
 	"github.com/song-flying/GoDataStructures/linked"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
 	"github.com/song-flying/GoDataStructures/pkg/hash"
+	"github.com/song-flying/GoDataStructures/pkg/order"
 )
 
-type DirectedGraph[V comparable] struct {
+type DirectedGraph[V any] struct {
 	adjDict *dict.HashDict[V, *linked.List[V]]
+	hashFn  hash.Hasher[V]
+	equalFn order.Equaler[V]
 }
 
 func (g *DirectedGraph[V]) hasVertex(v V) bool {
@@ -33,24 +36,33 @@ func (g *DirectedGraph[V]) IsDirectedGraph() bool {
 	return true
 }
 
+// NewDirectedGraph compares vertices with ==
 func NewDirectedGraph[V comparable](vertices []V) (result *DirectedGraph[V]) {
+	return NewDirectedGraphFunc(vertices, hash.Of[V](), order.Equal[V])
+}
+
+// NewDirectedGraphFunc compares vertices with equalFn, hashFn must agree with it
+func NewDirectedGraphFunc[V any](vertices []V, hashFn hash.Hasher[V], equalFn order.Equaler[V]) (result *DirectedGraph[V]) {
 	contract.Require(len(vertices) > 0, "vertices is not empty")
+	contract.Require(hashFn != nil && equalFn != nil, "hash and equality functions are not nil")
 	defer func() {
-		contract.Ensure(result.IsDirectedGraph(), "graph invariant holds")
+		contract.EnsureInvariant(result.IsDirectedGraph, "graph invariant holds")
 	}()
 
-	adjDict := dict.NewHashDict[V, *linked.List[V]](1, hash.Universal[V], 1)
+	adjDict := dict.NewHashDictFunc[V, *linked.List[V]](1, hashFn, equalFn, 1)
 	for _, v := range vertices {
-		adjDict.Put(v, linked.NewEmptyList[V]())
+		adjDict.Put(v, linked.NewEmptyListFunc(equalFn))
 	}
 
 	return &DirectedGraph[V]{
 		adjDict: adjDict,
+		hashFn:  hashFn,
+		equalFn: equalFn,
 	}
 }
 
 func (g *DirectedGraph[V]) ContainsEdge(v, w V) bool {
-	contract.Require(g.IsDirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsDirectedGraph, "graph invariant holds")
 	contract.Require(g.hasVertex(v) && g.hasVertex(w), "g contains v and w")
 
 	vNeighbors, _ := g.adjDict.Get(v)
@@ -59,11 +71,11 @@ func (g *DirectedGraph[V]) ContainsEdge(v, w V) bool {
 }
 
 func (g *DirectedGraph[V]) AddEdge(v, w V) {
-	contract.Require(g.IsDirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsDirectedGraph, "graph invariant holds")
 	contract.Require(g.hasVertex(v) && g.hasVertex(w), "g contains v and w")
-	contract.Require(v != w && !g.ContainsEdge(v, w), "g does not contain edge (v,w)")
+	contract.Require(!g.equalFn(v, w) && !g.ContainsEdge(v, w), "g does not contain edge (v,w)")
 	defer func() {
-		contract.Ensure(g.IsDirectedGraph(), "graph invariant holds")
+		contract.EnsureInvariant(g.IsDirectedGraph, "graph invariant holds")
 		contract.Ensure(g.ContainsEdge(v, w), "g contains edge (v,w)")
 	}()
 
@@ -72,7 +84,7 @@ func (g *DirectedGraph[V]) AddEdge(v, w V) {
 }
 
 func (g *DirectedGraph[V]) GetNeighbors(v V) (result *linked.List[V]) {
-	contract.Require(g.IsDirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsDirectedGraph, "graph invariant holds")
 	contract.Require(g.hasVertex(v), "g contains v")
 
 	neighbors, _ := g.adjDict.Get(v)
@@ -80,29 +92,40 @@ func (g *DirectedGraph[V]) GetNeighbors(v V) (result *linked.List[V]) {
 	return neighbors
 }
 
+// Vertices returns the vertices in no particular order
 func (g *DirectedGraph[V]) Vertices() *linked.List[V] {
-	contract.Require(g.IsDirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsDirectedGraph, "graph invariant holds")
 
 	return g.adjDict.Keys()
 }
 
+// VertexHasher returns the hash function of vertices, e.g. for sets of vertices
+func (g *DirectedGraph[V]) VertexHasher() hash.Hasher[V] {
+	return g.hashFn
+}
+
+// VertexEqualer returns the equality function of vertices
+func (g *DirectedGraph[V]) VertexEqualer() order.Equaler[V] {
+	return g.equalFn
+}
+
 func (g *DirectedGraph[V]) Size() int {
-	contract.Require(g.IsDirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsDirectedGraph, "graph invariant holds")
 
 	return g.adjDict.Size()
 }
 
 func (g *DirectedGraph[V]) Contains(v V) bool {
-	contract.Require(g.IsDirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsDirectedGraph, "graph invariant holds")
 
 	_, ok := g.adjDict.Get(v)
 	return ok
 }
 
 func (g *DirectedGraph[V]) Reverse() Graph[V] {
-	contract.Require(g.IsDirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsDirectedGraph, "graph invariant holds")
 
-	gReverse := NewDirectedGraph(g.Vertices().ToArray())
+	gReverse := NewDirectedGraphFunc(g.Vertices().ToArray(), g.hashFn, g.equalFn)
 
 	vertices := g.Vertices().Iterator()
 	for vertices.HasNext() {
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"github.com/song-flying/GoDataStructures/pkg/hash"
 	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -31,3 +32,34 @@ func TestDirectedGraph(t *testing.T) {
 	checkNeighbors[string](t, g, order.StringComp, "E", []string{"A"})
 	checkNeighbors[string](t, g, order.StringComp, "F", nil)
 }
+
+func TestDirectedGraph_CustomEquality(t *testing.T) {
+	g := NewDirectedGraphFunc([]string{"a", "b", "c"}, hash.CaseInsensitiveString, order.Equivalent(order.CaseInsensitiveComp))
+
+	g.AddEdge("A", "b")
+	g.AddEdge("B", "C")
+	assert.True(t, g.ContainsEdge("a", "B"))
+	assert.False(t, g.ContainsEdge("b", "A"))
+	assert.True(t, g.Contains("C"))
+	assert.True(t, g.Reverse().ContainsEdge("C", "b"))
+	assert.False(t, HasCycleDirected(g))
+
+	g.AddEdge("c", "A")
+	assert.True(t, HasCycleDirected(g))
+}
+
+func TestDirectedGraph_SliceVertices(t *testing.T) {
+	a, b, c := []int{1}, []int{1, 2}, []int{3}
+	g := NewDirectedGraphFunc([][]int{a, b, c}, hash.Slice(hash.Int[int]), order.Equivalent(order.Lexicographic(order.IntComp)))
+
+	g.AddEdge(a, b)
+	g.AddEdge([]int{1, 2}, []int{3})
+	assert.True(t, g.ContainsEdge([]int{1}, []int{1, 2}))
+	assert.Equal(t, [][]int{c}, g.GetNeighbors(b).ToArray())
+	assert.Equal(t, 3, g.Vertices().Length())
+	assert.False(t, HasCycleDirected(g))
+
+	g.AddEdge(c, a)
+	assert.True(t, HasCycleDirected(g))
+	assert.True(t, g.Reverse().ContainsEdge(a, c))
+}
@@ -4,11 +4,10 @@ import (
 	"github.com/song-flying/GoDataStructures/dict"
 	"github.com/song-flying/GoDataStructures/graph"
 	"github.com/song-flying/GoDataStructures/linked"
-	"github.com/song-flying/GoDataStructures/pkg/hash"
 	"github.com/song-flying/GoDataStructures/set"
 )
 
-type TopologicalSorter[V comparable] struct {
+type TopologicalSorter[V any] struct {
 	graph    *graph.DirectedGraph[V]
 	visited  *set.HashSet[V]
 	dfsStack *set.HashSet[V]
@@ -18,15 +17,15 @@ type TopologicalSorter[V comparable] struct {
 	from     dict.Dict[V, V]
 }
 
-func NewTopologicalSorter[V comparable](g *graph.DirectedGraph[V]) TopologicalSorter[V] {
+func NewTopologicalSorter[V any](g *graph.DirectedGraph[V]) TopologicalSorter[V] {
 	return TopologicalSorter[V]{
 		graph:    g,
-		visited:  set.NewHashSet[V](1, hash.Universal[V], 1),
-		dfsStack: set.NewHashSet[V](1, hash.Universal[V], 1),
-		sorted:   linked.NewEmptyList[V](),
+		visited:  set.NewHashSetFunc(1, g.VertexHasher(), g.VertexEqualer(), 1),
+		dfsStack: set.NewHashSetFunc(1, g.VertexHasher(), g.VertexEqualer(), 1),
+		sorted:   linked.NewEmptyListFunc(g.VertexEqualer()),
 		hasCycle: false,
-		cycle:    linked.NewEmptyList[V](),
-		from:     dict.NewHashDict[V, V](1, hash.Universal[V], 1),
+		cycle:    linked.NewEmptyListFunc(g.VertexEqualer()),
+		from:     dict.NewHashDictFunc[V, V](1, g.VertexHasher(), g.VertexEqualer(), 1),
 	}
 }
 
@@ -88,7 +87,7 @@ func (s *TopologicalSorter[V]) buildCycle(w V) {
 	s.cycle.Add(v)
 	for v, ok = s.from.Get(v); ok; {
 		s.cycle.Add(v)
-		if v == w {
+		if s.graph.VertexEqualer()(v, w) {
 			break
 		}
 		v, ok = s.from.Get(v)
@@ -34,3 +34,29 @@ func TestTopologicalSorter(t *testing.T) {
 	assert.True(t, sorter.Sort())
 	t.Log(sorter.Cycle())
 }
+
+func TestTopologicalSorter_SortedFollowsEdges(t *testing.T) {
+	vertices := []string{"A", "B", "C", "D", "E", "F", "G"}
+
+	g := graph.NewDirectedGraph[string](vertices)
+	g.AddEdge("E", "G")
+	g.AddEdge("D", "F")
+	g.AddEdge("B", "C")
+	g.AddEdge("A", "D")
+	g.AddEdge("C", "D")
+	g.AddEdge("D", "E")
+	sorter := NewTopologicalSorter[string](g)
+
+	assert.False(t, sorter.Sort())
+	sorted := sorter.Sorted()
+	assert.Len(t, sorted, len(vertices))
+	position := map[string]int{}
+	for i, v := range sorted {
+		position[v] = i
+	}
+	for _, v := range vertices {
+		for _, w := range g.GetNeighbors(v).ToArray() {
+			assert.Less(t, position[v], position[w], "%s comes before %s", v, w)
+		}
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"github.com/song-flying/GoDataStructures/linked"
 )
 
-type Graph[V comparable] interface {
+type Graph[V any] interface {
 	ContainsEdge(v, w V) bool
 	AddEdge(v, w V)
 	GetNeighbors(v V) (result *linked.List[V])
@@ -5,10 +5,13 @@ import (
 	"github.com/song-flying/GoDataStructures/linked"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
 	"github.com/song-flying/GoDataStructures/pkg/hash"
+	"github.com/song-flying/GoDataStructures/pkg/order"
 )
 
-type UndirectedGraph[V comparable] struct {
+type UndirectedGraph[V any] struct {
 	adjDict *dict.HashDict[V, *linked.List[V]]
+	hashFn  hash.Hasher[V]
+	equalFn order.Equaler[V]
 }
 
 func (g *UndirectedGraph[V]) hasVertex(v V) bool {
@@ -33,24 +36,33 @@ func (g *UndirectedGraph[V]) IsUndirectedGraph() bool {
 	return true
 }
 
+// NewUndirectedGraph compares vertices with ==
 func NewUndirectedGraph[V comparable](vertices []V) (result *UndirectedGraph[V]) {
+	return NewUndirectedGraphFunc(vertices, hash.Of[V](), order.Equal[V])
+}
+
+// NewUndirectedGraphFunc compares vertices with equalFn, hashFn must agree with it
+func NewUndirectedGraphFunc[V any](vertices []V, hashFn hash.Hasher[V], equalFn order.Equaler[V]) (result *UndirectedGraph[V]) {
 	contract.Require(len(vertices) > 0, "vertices is not empty")
+	contract.Require(hashFn != nil && equalFn != nil, "hash and equality functions are not nil")
 	defer func() {
-		contract.Ensure(result.IsUndirectedGraph(), "graph invariant holds")
+		contract.EnsureInvariant(result.IsUndirectedGraph, "graph invariant holds")
 	}()
 
-	adjDict := dict.NewHashDict[V, *linked.List[V]](1, hash.Universal[V], 1)
+	adjDict := dict.NewHashDictFunc[V, *linked.List[V]](1, hashFn, equalFn, 1)
 	for _, v := range vertices {
-		adjDict.Put(v, linked.NewEmptyList[V]())
+		adjDict.Put(v, linked.NewEmptyListFunc(equalFn))
 	}
 
 	return &UndirectedGraph[V]{
 		adjDict: adjDict,
+		hashFn:  hashFn,
+		equalFn: equalFn,
 	}
 }
 
 func (g *UndirectedGraph[V]) ContainsEdge(v, w V) bool {
-	contract.Require(g.IsUndirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsUndirectedGraph, "graph invariant holds")
 	contract.Require(g.hasVertex(v) && g.hasVertex(w), "g contains v and w")
 
 	vNeighbors, _ := g.adjDict.Get(v)
@@ -59,11 +71,11 @@ func (g *UndirectedGraph[V]) ContainsEdge(v, w V) bool {
 }
 
 func (g *UndirectedGraph[V]) AddEdge(v, w V) {
-	contract.Require(g.IsUndirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsUndirectedGraph, "graph invariant holds")
 	contract.Require(g.hasVertex(v) && g.hasVertex(w), "g contains v and w")
-	contract.Require(v != w && !g.ContainsEdge(v, w), "g does not contain edge (v,w)")
+	contract.Require(!g.equalFn(v, w) && !g.ContainsEdge(v, w), "g does not contain edge (v,w)")
 	defer func() {
-		contract.Ensure(g.IsUndirectedGraph(), "graph invariant holds")
+		contract.EnsureInvariant(g.IsUndirectedGraph, "graph invariant holds")
 		contract.Ensure(g.ContainsEdge(v, w), "g contains edge (v,w)")
 	}()
 
@@ -75,7 +87,7 @@ func (g *UndirectedGraph[V]) AddEdge(v, w V) {
 }
 
 func (g *UndirectedGraph[V]) GetNeighbors(v V) (result *linked.List[V]) {
-	contract.Require(g.IsUndirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsUndirectedGraph, "graph invariant holds")
 	contract.Require(g.hasVertex(v), "g contains v")
 
 	neighbors, _ := g.adjDict.Get(v)
@@ -83,29 +95,40 @@ func (g *UndirectedGraph[V]) GetNeighbors(v V) (result *linked.List[V]) {
 	return neighbors
 }
 
+// Vertices returns the vertices in no particular order
 func (g *UndirectedGraph[V]) Vertices() *linked.List[V] {
-	contract.Require(g.IsUndirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsUndirectedGraph, "graph invariant holds")
 
 	return g.adjDict.Keys()
 }
 
+// VertexHasher returns the hash function of vertices, e.g. for sets of vertices
+func (g *UndirectedGraph[V]) VertexHasher() hash.Hasher[V] {
+	return g.hashFn
+}
+
+// VertexEqualer returns the equality function of vertices
+func (g *UndirectedGraph[V]) VertexEqualer() order.Equaler[V] {
+	return g.equalFn
+}
+
 func (g *UndirectedGraph[V]) Size() int {
-	contract.Require(g.IsUndirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsUndirectedGraph, "graph invariant holds")
 
 	return g.adjDict.Size()
 }
 
 func (g *UndirectedGraph[V]) Contains(v V) bool {
-	contract.Require(g.IsUndirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsUndirectedGraph, "graph invariant holds")
 
 	_, ok := g.adjDict.Get(v)
 	return ok
 }
 
 func (g *UndirectedGraph[V]) Reverse() Graph[V] {
-	contract.Require(g.IsUndirectedGraph(), "graph invariant holds")
+	contract.RequireInvariant(g.IsUndirectedGraph, "graph invariant holds")
 
-	gReverse := NewUndirectedGraph(g.Vertices().ToArray())
+	gReverse := NewUndirectedGraphFunc(g.Vertices().ToArray(), g.hashFn, g.equalFn)
 
 	vertices := g.Vertices().Iterator()
 	for vertices.HasNext() {
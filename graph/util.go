@@ -3,16 +3,15 @@ package graph
 import (
 	"github.com/song-flying/GoDataStructures/dict"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
-	"github.com/song-flying/GoDataStructures/pkg/hash"
 	"github.com/song-flying/GoDataStructures/queue"
 	"github.com/song-flying/GoDataStructures/set"
 )
 
-func ShortestDistances[V comparable](g *UndirectedGraph[V], start V) (result dict.Dict[V, int]) {
+func ShortestDistances[V any](g *UndirectedGraph[V], start V) (result dict.Dict[V, int]) {
 	contract.Require(g != nil, "g is not nil")
 	contract.Require(g.Contains(start), "g contains start")
 
-	distances := dict.NewHashDict[V, int](1, hash.Universal[V], 1)
+	distances := dict.NewHashDictFunc[V, int](1, g.VertexHasher(), g.VertexEqualer(), 1)
 	q := queue.NewLinkedQueue[V]()
 	q.Enqueue(start)
 
@@ -29,20 +28,22 @@ func ShortestDistances[V comparable](g *UndirectedGraph[V], start V) (result dic
 
 		for curr := neighbors.Head; curr != nil; curr = curr.Next {
 			w := curr.Data
-			distW, _ := distances.Get(w)
-			if distW == -1 {
-				q.Enqueue(w)
-				distances.Put(w, distV+1)
-			}
+			distances.Compute(w, func(distW int, _ bool) (int, bool) {
+				if distW == -1 {
+					q.Enqueue(w)
+					return distV + 1, true
+				}
+				return distW, true
+			})
 		}
 	}
 
 	return distances
 }
 
-func HasCycleUndirected[V comparable](g *UndirectedGraph[V]) bool {
+func HasCycleUndirected[V any](g *UndirectedGraph[V]) bool {
 	vertices := g.Vertices().Iterator()
-	marked := set.NewHashSet[V](g.Size(), hash.Universal[V], 1)
+	marked := set.NewHashSetFunc(g.Size(), g.VertexHasher(), g.VertexEqualer(), 1)
 	for vertices.HasNext() {
 		v := vertices.Next()
 		if !marked.Contains(v) {
@@ -55,7 +56,7 @@ func HasCycleUndirected[V comparable](g *UndirectedGraph[V]) bool {
 	return false
 }
 
-func dfsHasCycleUndirected[V comparable](g *UndirectedGraph[V], v, from V, marked set.Set[V]) bool {
+func dfsHasCycleUndirected[V any](g *UndirectedGraph[V], v, from V, marked set.Set[V]) bool {
 	marked.Add(v)
 	neighbors := g.GetNeighbors(v).Iterator()
 	for neighbors.HasNext() {
@@ -64,7 +65,7 @@ func dfsHasCycleUndirected[V comparable](g *UndirectedGraph[V], v, from V, marke
 			if dfsHasCycleUndirected(g, w, v, marked) {
 				return true
 			}
-		} else if w != from {
+		} else if !g.VertexEqualer()(w, from) {
 			return true
 		}
 	}
@@ -72,10 +73,10 @@ func dfsHasCycleUndirected[V comparable](g *UndirectedGraph[V], v, from V, marke
 	return false
 }
 
-func HasCycleDirected[V comparable](g *DirectedGraph[V]) bool {
+func HasCycleDirected[V any](g *DirectedGraph[V]) bool {
 	vertices := g.Vertices().Iterator()
-	marked := set.NewHashSet[V](g.Size(), hash.Universal[V], 1)
-	callStack := set.NewHashSet[V](g.Size(), hash.Universal[V], 1)
+	marked := set.NewHashSetFunc(g.Size(), g.VertexHasher(), g.VertexEqualer(), 1)
+	callStack := set.NewHashSetFunc(g.Size(), g.VertexHasher(), g.VertexEqualer(), 1)
 	for vertices.HasNext() {
 		v := vertices.Next()
 		if !marked.Contains(v) {
@@ -88,7 +89,7 @@ func HasCycleDirected[V comparable](g *DirectedGraph[V]) bool {
 	return false
 }
 
-func dfsHasCycleDirected[V comparable](g *DirectedGraph[V], v V, marked set.Set[V], callStack set.Set[V]) bool {
+func dfsHasCycleDirected[V any](g *DirectedGraph[V], v V, marked set.Set[V], callStack set.Set[V]) bool {
 	callStack.Add(v)
 	marked.Add(v)
 
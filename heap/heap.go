@@ -3,6 +3,7 @@ package heap
 import (
 	"github.com/song-flying/GoDataStructures/array"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
 	"github.com/song-flying/GoDataStructures/pkg/order"
 )
 
@@ -11,6 +12,7 @@ type Heap[E comparable] struct {
 	capacity int
 	data     []E
 	comp     order.CompareFn[E]
+	modCount int
 }
 
 func (h *Heap[E]) isHeapSafe() bool {
@@ -30,7 +32,7 @@ func (h *Heap[E]) isHeapOrdered() bool {
 }
 
 func (h *Heap[E]) Contains(element E) bool {
-	contract.Require(h.IsHeap(), "heap invariant holds")
+	contract.RequireInvariant(h.IsHeap, "heap invariant holds")
 	return array.RangeContains(element, h.data, 1, h.next)
 }
 
@@ -90,7 +92,7 @@ func NewHeap[E comparable](userCapacity int, lessFn order.CompareFn[E]) (result
 	contract.Require(0 < userCapacity, "userCapacity is positive")
 	contract.Require(lessFn != nil, "priority function is not nil")
 	defer func() {
-		contract.Ensure(result.IsHeap(), "heap invariant holds")
+		contract.EnsureInvariant(result.IsHeap, "heap invariant holds")
 	}()
 
 	return &Heap[E]{
@@ -141,19 +143,25 @@ func (h *Heap[E]) checkAboveAndBelow(i int) bool {
 }
 
 func (h *Heap[E]) Add(element E) {
-	contract.Require(h.IsHeap(), "heap invariant holds")
+	contract.RequireInvariant(h.IsHeap, "heap invariant holds")
 	contract.Require(!h.IsFull(), "heap is not full")
 	defer func() {
-		contract.Ensure(h.IsHeap(), "heap invariant holds")
-		contract.Ensure(h.Contains(element), "heap contains element")
+		contract.EnsureInvariant(h.IsHeap, "heap invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(h.Contains(element), "heap contains element")
+		}
 	}()
 
 	h.data[h.next] = element
 	h.next++
+	h.modCount++
 
 	loopInv := func(i int) bool {
+		if !contract.Enabled(contract.LevelFull) {
+			return true
+		}
 		contract.Invariant(1 <= i && i < h.next, "i is within bound")
-		contract.Invariant(h.isHeapExceptUp(i), "heap invariant holds except for node i")
+		contract.CheckInvariant(func() bool { return h.isHeapExceptUp(i) }, "heap invariant holds except for node i")
 		contract.Invariant(h.checkAboveAndBelow(i), "i's parent has no lower priority than i's children")
 		return true
 	}
@@ -196,15 +204,18 @@ func (h *Heap[E]) selectChildToSwap(i int) (result int) {
 }
 
 func (h *Heap[E]) Delete() (result E) {
-	contract.Require(h.IsHeap(), "heap invariant holds")
+	contract.RequireInvariant(h.IsHeap, "heap invariant holds")
 	contract.Require(!h.IsEmpty(), "heap is not empty")
 	defer func() {
-		contract.Ensure(h.IsHeap(), "heap invariant holds")
-		contract.Ensure(!h.Contains(result), "heap does not contain element")
+		contract.EnsureInvariant(h.IsHeap, "heap invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(!h.Contains(result), "heap does not contain element")
+		}
 	}()
 
 	result = h.data[1]
 	h.next--
+	h.modCount++
 
 	if h.next == 1 {
 		return
@@ -214,8 +225,11 @@ func (h *Heap[E]) Delete() (result E) {
 	h.data[1] = last
 
 	loopInv := func(i int) bool {
+		if !contract.Enabled(contract.LevelFull) {
+			return true
+		}
 		contract.Invariant(1 <= i && i < h.next, "i is within bound")
-		contract.Invariant(h.isHeapExceptDown(i), "heap invariant holds except for node i")
+		contract.CheckInvariant(func() bool { return h.isHeapExceptDown(i) }, "heap invariant holds except for node i")
 		contract.Invariant(h.checkAboveAndBelow(i), "i's parent has no lower priority than i's children")
 		return true
 	}
@@ -229,6 +243,131 @@ func (h *Heap[E]) Delete() (result E) {
 }
 
 func (h *Heap[E]) Size() int {
-	contract.Require(h.IsHeap(), "heap invariant holds")
+	contract.RequireInvariant(h.IsHeap, "heap invariant holds")
 	return h.next - 1
 }
+
+func (h *Heap[E]) indexOf(element E) (result int) {
+	contract.Require(h.Contains(element), "heap contains element")
+	defer func() {
+		contract.Ensure(h.data[result] == element, "element is at result")
+	}()
+
+	result = 1
+	for h.data[result] != element {
+		result++
+	}
+	return
+}
+
+// removeAt deletes the element at index i, moving the last element into its place and sifting it down or up.
+// movedUp reports whether moved, the last element, ends up above i.
+func (h *Heap[E]) removeAt(i int) (moved E, movedUp bool) {
+	contract.RequireInvariant(h.IsHeap, "heap invariant holds")
+	contract.Require(1 <= i && i < h.next, "i is within bound")
+	defer func() {
+		contract.EnsureInvariant(h.IsHeap, "heap invariant holds")
+	}()
+
+	h.next--
+	h.modCount++
+	if i == h.next {
+		return
+	}
+
+	moved = h.data[h.next]
+	h.data[i] = moved
+	j := i
+	for left(j) < h.next && !h.belowOK(j) {
+		child := h.selectChildToSwap(j)
+		h.swapUp(child)
+		j = child
+	}
+	for j > 1 && !h.aboveOK(j) {
+		h.swapUp(j)
+		j = up(j)
+	}
+
+	return moved, j < i
+}
+
+// heapIterator walks the heap in level order. Remove may move an element not yet returned above the cursor,
+// such forgotten elements are returned after the walk, so that every element is returned once.
+type heapIterator[E comparable] struct {
+	h         *Heap[E]
+	i         int
+	forgotten []E
+	last      int
+	lastValue E
+	removable bool
+	modCount  int
+}
+
+func (it *heapIterator[E]) HasNext() bool {
+	iterator.CheckMods(it.h.modCount, it.modCount)
+
+	return it.i < it.h.next || len(it.forgotten) > 0
+}
+
+func (it *heapIterator[E]) Next() (result E) {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	if it.i < it.h.next {
+		it.last = it.i
+		it.i++
+		result = it.h.data[it.last]
+	} else {
+		it.last = 0
+		result = it.forgotten[0]
+		it.forgotten = it.forgotten[1:]
+	}
+	it.lastValue = result
+	it.removable = true
+	return
+}
+
+// Remove deletes the element last returned by Next in O(log n)
+func (it *heapIterator[E]) Remove() {
+	iterator.CheckMods(it.h.modCount, it.modCount)
+	contract.Require(it.removable, "Next is called after the last Remove")
+
+	if it.last == 0 {
+		// the walk is over, so the heap holds no element to be returned but the forgotten ones
+		it.h.removeAt(it.h.indexOf(it.lastValue))
+	} else if moved, movedUp := it.h.removeAt(it.last); movedUp {
+		it.forgotten = append(it.forgotten, moved)
+	} else {
+		// the element now at the cursor is not returned yet
+		it.i--
+	}
+	it.removable = false
+	it.modCount = it.h.modCount
+}
+
+func (h *Heap[E]) mods() int {
+	return h.modCount
+}
+
+// Iterator walks the elements in level order, which is not priority order, removing them re-sifts the heap
+func (h *Heap[E]) Iterator() iterator.MutableIterator[E] {
+	contract.RequireInvariant(h.IsHeap, "heap invariant holds")
+
+	return &heapIterator[E]{
+		h:        h,
+		i:        1,
+		modCount: h.modCount,
+	}
+}
+
+// All walks the elements in level order, which is not priority order
+func (h *Heap[E]) All() iterator.Seq[E] {
+	contract.RequireInvariant(h.IsHeap, "heap invariant holds")
+
+	return iterator.FailFastSeq(func(yield func(E) bool) {
+		for i := 1; i < h.next; i++ {
+			if !yield(h.data[i]) {
+				return
+			}
+		}
+	}, h.mods)
+}
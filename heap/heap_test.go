@@ -1,8 +1,12 @@
 package heap
 
 import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
 	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
 	"testing"
 )
 
@@ -25,3 +29,105 @@ func TestHeap(t *testing.T) {
 		assert.Equal(t, len(b)-i-1, h.Size())
 	}
 }
+
+func TestHeap_Iterator(t *testing.T) {
+	h := NewHeap[int](5, order.IntComp)
+	assert.False(t, h.Iterator().HasNext())
+
+	for _, v := range []int{3, 1, 2} {
+		h.Add(v)
+	}
+
+	var a []int
+	for it := h.Iterator(); it.HasNext(); {
+		a = append(a, it.Next())
+	}
+	assert.ElementsMatch(t, []int{1, 2, 3}, a)
+	assert.Equal(t, 1, a[0])
+
+	var b []int
+	h.All()(func(x int) bool {
+		b = append(b, x)
+		return true
+	})
+	assert.Equal(t, a, b)
+}
+
+func TestHeap_IteratorFailFast(t *testing.T) {
+	h := NewHeap[int](5, order.IntComp)
+	h.Add(1)
+
+	it := h.Iterator()
+	h.Add(2)
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.HasNext() })
+}
+
+func TestHeap_IteratorRemove(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+	h := NewHeap[int](10, order.IntComp)
+	for _, v := range []int{1, 10, 2, 11, 12, 3, 4} {
+		h.Add(v)
+	}
+
+	// removing 11 moves 4 above the cursor, so it is returned last
+	var a []int
+	it := h.Iterator()
+	for it.HasNext() {
+		x := it.Next()
+		a = append(a, x)
+		if x == 11 || x == 4 {
+			it.Remove()
+		}
+	}
+	assert.Equal(t, []int{1, 10, 2, 11, 12, 3, 4}, a)
+	assert.Panics(t, func() { it.Remove() })
+	assert.True(t, h.IsHeap())
+
+	var b []int
+	for !h.IsEmpty() {
+		b = append(b, h.Delete())
+	}
+	assert.Equal(t, []int{1, 2, 3, 10, 12}, b)
+}
+
+func TestHeap_IteratorRemoveRandom(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		values := r.Perm(64)
+		h := NewHeap[int](len(values), order.IntComp)
+		for _, v := range values {
+			h.Add(v)
+		}
+
+		var returned, kept []int
+		for it := h.Iterator(); it.HasNext(); {
+			x := it.Next()
+			returned = append(returned, x)
+			if r.Intn(2) == 0 {
+				it.Remove()
+			} else {
+				kept = append(kept, x)
+			}
+		}
+		assert.ElementsMatch(t, values, returned)
+		assert.True(t, h.IsHeap())
+
+		sort.Ints(kept)
+		var deleted []int
+		for !h.IsEmpty() {
+			deleted = append(deleted, h.Delete())
+		}
+		assert.Equal(t, kept, deleted)
+	}
+}
+
+func TestHeap_ReverseOrder(t *testing.T) {
+	h := NewHeap[int](5, order.Reverse(order.IntComp))
+	for _, v := range []int{3, 1, 4, 2} {
+		h.Add(v)
+	}
+
+	for _, v := range []int{4, 3, 2, 1} {
+		assert.Equal(t, v, h.Delete())
+	}
+}
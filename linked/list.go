@@ -3,26 +3,29 @@ package linked
 import (
 	"fmt"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"reflect"
 	"strings"
 )
 
-type Node[T comparable] struct {
+type Node[T any] struct {
 	Data T
 	Next *Node[T]
 }
 
-func NewNode[T comparable](data T) Node[T] {
+func NewNode[T any](data T) Node[T] {
 	return Node[T]{
 		Data: data,
 		Next: nil,
 	}
 }
 
-func NewDummyNode[T comparable]() Node[T] {
+func NewDummyNode[T any]() Node[T] {
 	return Node[T]{}
 }
 
-func Nil[T comparable]() *Node[T] {
+func Nil[T any]() *Node[T] {
 	return nil
 }
 
@@ -31,8 +34,12 @@ func (n *Node[T]) IsList() bool {
 	return !HasCycle(n)
 }
 
-type List[T comparable] struct {
-	Head *Node[T]
+// List compares elements with equalFn, a List made as a literal compares them with ==,
+// which requires them to be comparable
+type List[T any] struct {
+	Head     *Node[T]
+	equalFn  order.Equaler[T]
+	modCount int
 }
 
 // IsList data structure invariant
@@ -41,31 +48,50 @@ func (l *List[T]) IsList() bool {
 }
 
 func NewEmptyList[T comparable]() (result *List[T]) {
-	defer func() {
-		contract.Ensure(result.IsList(), "list invariant holds")
-	}()
+	return NewEmptyListFunc(order.Equal[T])
+}
 
-	return &List[T]{}
+// NewEmptyListFunc compares elements with equalFn
+func NewEmptyListFunc[T any](equalFn order.Equaler[T]) (result *List[T]) {
+	return NewListFunc(nil, equalFn)
 }
 
 func NewList[T comparable](head *Node[T]) (result *List[T]) {
+	return NewListFunc(head, order.Equal[T])
+}
+
+// NewListFunc compares elements with equalFn
+func NewListFunc[T any](head *Node[T], equalFn order.Equaler[T]) (result *List[T]) {
+	contract.Require(equalFn != nil, "equality function is not nil")
 	defer func() {
-		contract.Ensure(result.IsList(), "list invariant holds")
+		contract.EnsureInvariant(result.IsList, "list invariant holds")
 	}()
 
 	return &List[T]{
-		Head: head,
+		Head:    head,
+		equalFn: equalFn,
 	}
 }
 
+// equal compares with equalFn, or with == for a List made as a literal,
+// which is decided on every comparison rather than stored, so that reading a List never writes to it
+func (l *List[T]) equal(a, b T) bool {
+	if l.equalFn != nil {
+		return l.equalFn(a, b)
+	}
+
+	contract.Require(reflect.TypeOf((*T)(nil)).Elem().Comparable(), "a List made as a literal has comparable elements, others are made by NewListFunc")
+	return any(a) == any(b)
+}
+
 func (l *List[T]) IsEmpty() bool {
-	contract.Require(l.IsList(), "list invariant holds")
+	contract.RequireInvariant(l.IsList, "list invariant holds")
 
 	return l.Head == nil
 }
 
 func (l *List[T]) Length() (result int) {
-	contract.Require(l.IsList(), "list invariant holds")
+	contract.RequireInvariant(l.IsList, "list invariant holds")
 	contract.Ensure(0 <= result, "result is non-negative")
 
 	if l.Head == nil {
@@ -76,17 +102,18 @@ func (l *List[T]) Length() (result int) {
 }
 
 func (l *List[T]) Ith(i int) T {
-	contract.Require(l.IsList(), "list invariant holds")
+	contract.RequireInvariant(l.IsList, "list invariant holds")
 	contract.Require(0 <= i && i < l.Length(), "i is within bound")
 
 	return IthSegment(l.Head, i)
 }
 
 func (l *List[T]) Add(element T) {
-	contract.Require(l.IsList(), "list invariant holds")
+	contract.RequireInvariant(l.IsList, "list invariant holds")
 	node := NewNode(element)
 	node.Next = l.Head
 	l.Head = &node
+	l.modCount++
 }
 
 func (l *List[T]) Contains(element T) bool {
@@ -94,9 +121,9 @@ func (l *List[T]) Contains(element T) bool {
 }
 
 func (l *List[T]) containsFrom(start *Node[T], element T) bool {
-	contract.Require(l.IsList(), "list invariant holds")
+	contract.RequireInvariant(l.IsList, "list invariant holds")
 	for curr := start; curr != nil; curr = curr.Next {
-		if curr.Data == element {
+		if l.equal(curr.Data, element) {
 			return true
 		}
 	}
@@ -105,7 +132,7 @@ func (l *List[T]) containsFrom(start *Node[T], element T) bool {
 }
 
 func (l *List[T]) ToArray() (result []T) {
-	contract.Require(l.IsList(), "list invariant holds")
+	contract.RequireInvariant(l.IsList, "list invariant holds")
 
 	for curr := l.Head; curr != nil; curr = curr.Next {
 		result = append(result, curr.Data)
@@ -114,36 +141,79 @@ func (l *List[T]) ToArray() (result []T) {
 	return
 }
 
-type ListIterator[T comparable] struct {
-	curr *Node[T]
+// ListIterator walks a list from head to tail, it fails fast when the list is modified other than by Remove
+type ListIterator[T any] struct {
+	l        *List[T]
+	prev     *Node[T]
+	last     *Node[T]
+	curr     *Node[T]
+	modCount int
 }
 
-func NewListIterator[T comparable](l *List[T]) *ListIterator[T] {
+func NewListIterator[T any](l *List[T]) *ListIterator[T] {
+	contract.RequireInvariant(l.IsList, "list invariant holds")
+
 	return &ListIterator[T]{
-		l.Head,
+		l:        l,
+		curr:     l.Head,
+		modCount: l.modCount,
 	}
 }
 
 func (it *ListIterator[T]) HasNext() bool {
+	iterator.CheckMods(it.l.modCount, it.modCount)
+
 	return it.curr != nil
 }
 
 func (it *ListIterator[T]) Next() (result T) {
-	result = it.curr.Data
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	if it.last != nil {
+		it.prev = it.last
+	}
+	it.last = it.curr
 	it.curr = it.curr.Next
-	return
+
+	return it.last.Data
+}
+
+// Remove unlinks the node last returned by Next
+func (it *ListIterator[T]) Remove() {
+	iterator.CheckMods(it.l.modCount, it.modCount)
+	contract.Require(it.last != nil, "Next is called after the last Remove")
+	defer func() {
+		contract.EnsureInvariant(it.l.IsList, "list invariant holds")
+	}()
+
+	if it.prev == nil {
+		it.l.Head = it.curr
+	} else {
+		it.prev.Next = it.curr
+	}
+	it.last.Next = nil
+	it.last = nil
+
+	it.l.modCount++
+	it.modCount = it.l.modCount
 }
 
 func (l *List[T]) Iterator() *ListIterator[T] {
 	return NewListIterator[T](l)
 }
 
+func (l *List[T]) All() iterator.Seq[T] {
+	contract.RequireInvariant(l.IsList, "list invariant holds")
+
+	return iterator.FailFastSeq(AllSegment(l.Head, nil), func() int { return l.modCount })
+}
+
 func (l *List[T]) IsDistinct() bool {
 	return l.isDistinctFrom(l.Head)
 }
 
 func (l *List[T]) isDistinctFrom(start *Node[T]) bool {
-	contract.Require(l.IsList(), "list invariant holds")
+	contract.RequireInvariant(l.IsList, "list invariant holds")
 
 	if start == nil {
 		return true
@@ -153,7 +223,7 @@ func (l *List[T]) isDistinctFrom(start *Node[T]) bool {
 }
 
 func (l *List[T]) Reverse() {
-	contract.Require(l.IsList(), "list invariant holds")
+	contract.RequireInvariant(l.IsList, "list invariant holds")
 
 	var newHead *Node[T] = nil
 	var node *Node[T]
@@ -165,6 +235,7 @@ func (l *List[T]) Reverse() {
 	}
 
 	l.Head = newHead
+	l.modCount++
 }
 
 func (l *List[T]) String() string {
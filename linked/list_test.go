@@ -1,6 +1,9 @@
 package linked
 
 import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -33,3 +36,69 @@ func TestList(t *testing.T) {
 	l.Reverse()
 	t.Logf("l = %s", l.String())
 }
+
+func TestList_All(t *testing.T) {
+	l := NewEmptyList[int]()
+	for i := 1; i <= 5; i++ {
+		l.Add(i)
+	}
+
+	var a []int
+	l.All()(func(x int) bool {
+		a = append(a, x)
+		return x > 3
+	})
+	assert.Equal(t, []int{5, 4, 3}, a)
+
+	var b []int
+	for it := NewSegmentIterator(l.Head, l.Head.Next.Next); it.HasNext(); {
+		b = append(b, it.Next())
+	}
+	assert.Equal(t, []int{5, 4}, b)
+}
+
+func TestListIterator_Remove(t *testing.T) {
+	l := NewEmptyList[int]()
+	for i := 6; i >= 1; i-- {
+		l.Add(i)
+	}
+
+	for it := l.Iterator(); it.HasNext(); {
+		if it.Next()%2 == 1 {
+			it.Remove()
+		}
+	}
+	assert.Equal(t, []int{2, 4, 6}, l.ToArray())
+
+	it := l.Iterator()
+	assert.Panics(t, func() { it.Remove() })
+	it.Next()
+	it.Remove()
+	assert.Panics(t, func() { it.Remove() })
+	assert.Equal(t, []int{4, 6}, l.ToArray())
+
+	l.Add(2)
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.Next() })
+}
+
+func TestListFunc(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+	l := NewEmptyListFunc(order.Equivalent(order.CaseInsensitiveComp))
+	l.Add("a")
+	l.Add("B")
+	assert.True(t, l.Contains("A"))
+	assert.True(t, l.Contains("b"))
+	assert.True(t, l.IsDistinct())
+
+	l.Add("b")
+	assert.False(t, l.IsDistinct())
+
+	literal := &List[int]{Head: &Node[int]{Data: 42}}
+	assert.True(t, literal.Contains(42), "lists made as literals compare with ==")
+	assert.Nil(t, literal.equalFn, "reading a list made as a literal does not write to it")
+
+	slices := &List[[]int]{Head: &Node[[]int]{Data: []int{42}}}
+	err := contract.Catch(func() { slices.Contains([]int{42}) })
+	assert.ErrorContains(t, err, "NewListFunc")
+	assert.True(t, NewListFunc(slices.Head, func(a, b []int) bool { return a[0] == b[0] }).Contains([]int{42}))
+}
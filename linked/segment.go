@@ -2,10 +2,11 @@ package linked
 
 import (
 	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
 	"golang.org/x/exp/constraints"
 )
 
-func HasCycle[T comparable](l *Node[T]) bool {
+func HasCycle[T any](l *Node[T]) bool {
 	if l == nil {
 		return false
 	}
@@ -14,6 +15,9 @@ func HasCycle[T comparable](l *Node[T]) bool {
 	fast := l.Next
 
 	loopInv := func(i int) bool {
+		if !contract.Enabled(contract.LevelFull) {
+			return true
+		}
 		contract.Invariant(fast == nil || slow != nil, "fast /= nil => slow /= nil")
 		contract.Invariant(fast == nil || isReachableWith(l, slow, i) && isReachableWith(l, fast, 2*i+1), "speeds of fast and slow are OK")
 		return true
@@ -34,7 +38,7 @@ func HasCycle[T comparable](l *Node[T]) bool {
 	}
 }
 
-func next[T comparable](start *Node[T], n int) (result *Node[T]) {
+func next[T any](start *Node[T], n int) (result *Node[T]) {
 	contract.Require(n >= 0, "n is non-negative")
 	defer func() {
 		contract.Ensure(result == nil || isReachableWith(start, result, n), "result is reachable from start with n steps")
@@ -50,7 +54,7 @@ func next[T comparable](start *Node[T], n int) (result *Node[T]) {
 }
 
 // specification function
-func isReachableWith[T comparable](src, dst *Node[T], hops int) bool {
+func isReachableWith[T any](src, dst *Node[T], hops int) bool {
 	contract.Require(hops >= 0, "hops is non-negative")
 
 	curr := src
@@ -64,8 +68,8 @@ func isReachableWith[T comparable](src, dst *Node[T], hops int) bool {
 	return curr == dst
 }
 
-func IsSegment[T comparable](start, end *Node[T]) bool {
-	contract.Require(!HasCycle(start), "start node leads to no cycle")
+func IsSegment[T any](start, end *Node[T]) bool {
+	contract.RequireInvariant(func() bool { return !HasCycle(start) }, "start node leads to no cycle")
 
 	for curr := start; curr != nil; curr = curr.Next {
 		if curr == end {
@@ -76,8 +80,8 @@ func IsSegment[T comparable](start, end *Node[T]) bool {
 	return start != nil && end == nil
 }
 
-func LengthOfSegment[T comparable](start, end *Node[T]) int {
-	contract.Require(IsSegment(start, end), "start and end forms a segment")
+func LengthOfSegment[T any](start, end *Node[T]) int {
+	contract.RequireInvariant(func() bool { return IsSegment(start, end) }, "start and end forms a segment")
 
 	count := 0
 	for curr := start; curr != end; curr = curr.Next {
@@ -88,7 +92,7 @@ func LengthOfSegment[T comparable](start, end *Node[T]) int {
 }
 
 func IsInSegment[T comparable](x T, start, end *Node[T]) (result bool) {
-	contract.Require(IsSegment(start, end), "start and end forms a segment")
+	contract.RequireInvariant(func() bool { return IsSegment(start, end) }, "start and end forms a segment")
 
 	for curr := start; curr != end; curr = curr.Next {
 		if x == curr.Data {
@@ -100,7 +104,7 @@ func IsInSegment[T comparable](x T, start, end *Node[T]) (result bool) {
 }
 
 func IsSegmentSorted[T constraints.Ordered](start, end *Node[T]) bool {
-	contract.Require(IsSegment(start, end), "start and ends forms a segment")
+	contract.RequireInvariant(func() bool { return IsSegment(start, end) }, "start and ends forms a segment")
 
 	if start == end {
 		return true
@@ -115,7 +119,7 @@ func IsSegmentSorted[T constraints.Ordered](start, end *Node[T]) bool {
 	return true
 }
 
-func IthSegment[T comparable](start *Node[T], i int) T {
+func IthSegment[T any](start *Node[T], i int) T {
 	contract.Require(0 <= i && i < LengthOfSegment(start, nil), "i is within bound")
 
 	curr := start
@@ -126,3 +130,42 @@ func IthSegment[T comparable](start *Node[T], i int) T {
 
 	return curr.Data
 }
+
+// SegmentIterator walks the nodes of segment [start, end)
+type SegmentIterator[T any] struct {
+	curr *Node[T]
+	end  *Node[T]
+}
+
+func NewSegmentIterator[T any](start, end *Node[T]) *SegmentIterator[T] {
+	contract.RequireInvariant(func() bool { return start == end || IsSegment(start, end) }, "start and end forms a segment")
+
+	return &SegmentIterator[T]{
+		curr: start,
+		end:  end,
+	}
+}
+
+func (it *SegmentIterator[T]) HasNext() bool {
+	return it.curr != it.end
+}
+
+func (it *SegmentIterator[T]) Next() (result T) {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	result = it.curr.Data
+	it.curr = it.curr.Next
+	return
+}
+
+func AllSegment[T any](start, end *Node[T]) iterator.Seq[T] {
+	contract.RequireInvariant(func() bool { return start == end || IsSegment(start, end) }, "start and end forms a segment")
+
+	return func(yield func(T) bool) {
+		for curr := start; curr != end; curr = curr.Next {
+			if !yield(curr.Data) {
+				return
+			}
+		}
+	}
+}
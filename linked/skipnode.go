@@ -0,0 +1,103 @@
+package linked
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"math/rand"
+)
+
+// MaxSkipLevel bounds the levels of a skip list, enough for 2^32 nodes
+const MaxSkipLevel = 32
+
+// SkipNode is a Node linked on Level() levels: level 0 links every node of a skip list,
+// and each level above links a sublist of the one below it
+type SkipNode[T any] struct {
+	Data T
+	Next []*SkipNode[T]
+}
+
+func NewSkipNode[T any](data T, level int) *SkipNode[T] {
+	contract.Require(1 <= level && level <= MaxSkipLevel, "level is within bound")
+
+	return &SkipNode[T]{
+		Data: data,
+		Next: make([]*SkipNode[T], level),
+	}
+}
+
+// NewSkipHead returns a dummy node linking every level of a skip list
+func NewSkipHead[T any]() *SkipNode[T] {
+	return NewSkipNode(*new(T), MaxSkipLevel)
+}
+
+func (n *SkipNode[T]) Level() int {
+	return len(n.Next)
+}
+
+// IsSkipList data structure invariant of the skip list starting at the dummy node head:
+// level 0 is acyclic, and every other level links, in the same order, some of the nodes linked by the level below it
+func (n *SkipNode[T]) IsSkipList() bool {
+	if n == nil {
+		return false
+	}
+
+	seen := map[*SkipNode[T]]bool{n: true}
+	for curr := n.Next[0]; curr != nil; curr = curr.Next[0] {
+		if seen[curr] || curr.Level() < 1 {
+			return false
+		}
+		seen[curr] = true
+	}
+
+	for i := 1; i < n.Level(); i++ {
+		below := n.Next[i-1]
+		for curr := n.Next[i]; curr != nil; curr = curr.Next[i] {
+			if curr.Level() <= i {
+				return false
+			}
+			for below != nil && below != curr {
+				below = below.Next[i-1]
+			}
+			if below == nil {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// LastBefore walks down the lowest levels levels from the dummy node head, and returns the last node whose data is before,
+// which holds for a prefix of the list, or head if there is none. update, if not nil, receives that last node of every level.
+func (n *SkipNode[T]) LastBefore(levels int, before func(T) bool, update []*SkipNode[T]) (result *SkipNode[T]) {
+	contract.Require(1 <= levels && levels <= n.Level(), "levels is within bound")
+	contract.Require(update == nil || len(update) >= levels, "update has a slot per level")
+	defer func() {
+		contract.Ensure(result.Next[0] == nil || !before(result.Next[0].Data), "result is the last node before")
+	}()
+
+	result = n
+	for i := levels - 1; i >= 0; i-- {
+		for result.Next[i] != nil && before(result.Next[i].Data) {
+			result = result.Next[i]
+		}
+		if update != nil {
+			update[i] = result
+		}
+	}
+
+	return
+}
+
+// RandomSkipLevel returns the level of a new node, i with probability 2^-i
+func RandomSkipLevel(r *rand.Rand) (result int) {
+	defer func() {
+		contract.Ensure(1 <= result && result <= MaxSkipLevel, "result is within bound")
+	}()
+
+	result = 1
+	for bits := r.Uint32(); result < MaxSkipLevel && bits&1 == 1; bits >>= 1 {
+		result++
+	}
+
+	return
+}
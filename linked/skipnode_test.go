@@ -0,0 +1,74 @@
+package linked
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// skipList links data in order below a new head, a node of data[i] on levels[i] levels
+func skipList(data []int, levels []int) *SkipNode[int] {
+	head := NewSkipHead[int]()
+	last := make([]*SkipNode[int], MaxSkipLevel)
+	for i := range last {
+		last[i] = head
+	}
+	for i, d := range data {
+		node := NewSkipNode(d, levels[i])
+		for l := 0; l < levels[i]; l++ {
+			last[l].Next[l] = node
+			last[l] = node
+		}
+	}
+
+	return head
+}
+
+func TestSkipNode_IsSkipList(t *testing.T) {
+	assert.False(t, (*SkipNode[int])(nil).IsSkipList())
+	assert.True(t, NewSkipHead[int]().IsSkipList())
+
+	head := skipList([]int{1, 2, 3, 4}, []int{1, 3, 1, 2})
+	assert.True(t, head.IsSkipList())
+
+	head.Next[1].Next[1] = head.Next[0]
+	assert.False(t, head.IsSkipList(), "level 1 goes back")
+	head.Next[1].Next[1] = head.Next[0].Next[0].Next[0].Next[0]
+
+	head.Next[0].Next[0].Next[0].Next[0].Next[0] = head.Next[0]
+	assert.False(t, head.IsSkipList(), "level 0 has a cycle")
+}
+
+func TestSkipNode_LastBefore(t *testing.T) {
+	head := skipList([]int{1, 3, 5, 7, 9}, []int{2, 1, 3, 1, 2})
+	update := make([]*SkipNode[int], 3)
+
+	last := head.LastBefore(3, func(d int) bool { return d < 6 }, update)
+	assert.Equal(t, 5, last.Data)
+	assert.Equal(t, []int{5, 5, 5}, []int{update[0].Data, update[1].Data, update[2].Data})
+
+	last = head.LastBefore(3, func(d int) bool { return d < 4 }, update)
+	assert.Equal(t, 3, last.Data)
+	assert.Equal(t, 1, update[1].Data)
+	assert.Same(t, head, update[2])
+
+	assert.Same(t, head, head.LastBefore(1, func(d int) bool { return d < 1 }, nil))
+	assert.Equal(t, 9, head.LastBefore(2, func(int) bool { return true }, nil).Data)
+}
+
+func TestRandomSkipLevel(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	counts := make([]int, MaxSkipLevel+1)
+	for i := 0; i < 1<<12; i++ {
+		counts[RandomSkipLevel(r)]++
+	}
+
+	assert.Equal(t, 0, counts[0])
+	assert.InDelta(t, 1<<11, counts[1], 1<<8)
+	assert.InDelta(t, 1<<10, counts[2], 1<<7)
+
+	r1, r2 := rand.New(rand.NewSource(7)), rand.New(rand.NewSource(7))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, RandomSkipLevel(r1), RandomSkipLevel(r2))
+	}
+}
@@ -2,56 +2,78 @@ package contract
 
 import "fmt"
 
-const On = true
-
 func Require(b bool, msg string) {
-	if On && !b {
-		panic("pre-condition violation: " + msg)
+	if Enabled(LevelPre) && !b {
+		violate(KindPreCondition, msg)
 	}
 }
 
 // Requiref can be used for debugging to print the actual values
 func Requiref(b bool, msg string, args ...any) {
-	if On && !b {
-		panic(fmt.Sprintf("pre-condition violation: "+msg, args...))
+	if Enabled(LevelPre) && !b {
+		violate(KindPreCondition, fmt.Sprintf(msg, args...))
+	}
+}
+
+// RequireInvariant checks a data structure invariant as pre-condition,
+// inv is only evaluated at LevelFull, when sampled, since such invariants are usually O(n)
+func RequireInvariant(inv func() bool, msg string) {
+	if Enabled(LevelFull) && !holds(inv) {
+		violate(KindPreCondition, msg)
 	}
 }
 
 func Ensure(b bool, msg string) {
-	if On && !b {
-		panic("post-condition violation: " + msg)
+	if Enabled(LevelPost) && !b {
+		violate(KindPostCondition, msg)
 	}
 }
 
 // Ensuref can be used for debugging to print the actual values
 func Ensuref(b bool, msg string, args ...any) {
-	if On && !b {
-		panic(fmt.Sprintf("post-condition violation: "+msg, args...))
+	if Enabled(LevelPost) && !b {
+		violate(KindPostCondition, fmt.Sprintf(msg, args...))
+	}
+}
+
+// EnsureInvariant checks a data structure invariant as post-condition,
+// inv is only evaluated at LevelFull, when sampled, since such invariants are usually O(n)
+func EnsureInvariant(inv func() bool, msg string) {
+	if Enabled(LevelFull) && !holds(inv) {
+		violate(KindPostCondition, msg)
 	}
 }
 
 func Invariant(b bool, msg string) {
-	if On && !b {
-		panic("invariant violation: " + msg)
+	if Enabled(LevelFull) && !b {
+		violate(KindInvariant, msg)
+	}
+}
+
+// CheckInvariant checks a data structure invariant that has to hold within an operation, e.g. in a loop,
+// inv is only evaluated at LevelFull, when sampled, since such invariants are usually O(n)
+func CheckInvariant(inv func() bool, msg string) {
+	if Enabled(LevelFull) && !holds(inv) {
+		violate(KindInvariant, msg)
 	}
 }
 
 // Invariantf can be used for debugging to print the actual values
 func Invariantf(b bool, msg string, args ...any) {
-	if On && !b {
-		panic(fmt.Sprintf("invariant violation: "+msg, args...))
+	if Enabled(LevelFull) && !b {
+		violate(KindInvariant, fmt.Sprintf(msg, args...))
 	}
 }
 
 func Assert(b bool, msg string) {
-	if On && !b {
-		panic("assertion violation: " + msg)
+	if Enabled(LevelFull) && !b {
+		violate(KindAssertion, msg)
 	}
 }
 
 // Assertf can be used for debugging to print the actual values
 func Assertf(b bool, msg string, args ...any) {
-	if On && !b {
-		panic(fmt.Sprintf("assertion violation: "+msg, args...))
+	if Enabled(LevelFull) && !b {
+		violate(KindAssertion, fmt.Sprintf(msg, args...))
 	}
 }
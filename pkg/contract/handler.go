@@ -0,0 +1,187 @@
+package contract
+
+import (
+	"bytes"
+	"log"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// Handler is called with every violation before panicking,
+// when it returns true the violation counts as handled and the program continues instead
+type Handler func(v *Violation) (handled bool)
+
+var (
+	globalHandler atomic.Value // holds a Handler, nil Handler when unset
+
+	goroutineHandlersMu sync.Mutex
+	goroutineHandlers   = map[uint64]Handler{}
+	goroutineHandlerCnt int32
+)
+
+// SetHandler installs h for all goroutines that have no handler of their own and returns the previous one,
+// a nil h restores plain panicking
+func SetHandler(h Handler) (old Handler) {
+	old, _ = globalHandler.Swap(h).(Handler)
+	return old
+}
+
+// WithHandler installs h for the calling goroutine while f runs
+func WithHandler(h Handler, f func()) {
+	id := goroutineID()
+
+	goroutineHandlersMu.Lock()
+	old, hadOld := goroutineHandlers[id]
+	goroutineHandlers[id] = h
+	if !hadOld {
+		atomic.AddInt32(&goroutineHandlerCnt, 1)
+	}
+	goroutineHandlersMu.Unlock()
+
+	defer func() {
+		goroutineHandlersMu.Lock()
+		if hadOld {
+			goroutineHandlers[id] = old
+		} else {
+			delete(goroutineHandlers, id)
+			atomic.AddInt32(&goroutineHandlerCnt, -1)
+		}
+		goroutineHandlersMu.Unlock()
+	}()
+
+	f()
+}
+
+// currentHandler prefers the handler of the calling goroutine over the global one
+func currentHandler() Handler {
+	if atomic.LoadInt32(&goroutineHandlerCnt) > 0 {
+		id := goroutineID()
+		goroutineHandlersMu.Lock()
+		h, ok := goroutineHandlers[id]
+		goroutineHandlersMu.Unlock()
+		if ok {
+			return h
+		}
+	}
+
+	h, _ := globalHandler.Load().(Handler)
+	return h
+}
+
+// goroutineID parses the id from the "goroutine N [status]:" header of the stack trace,
+// it is only called when handlers are installed per goroutine
+func goroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	s := bytes.TrimPrefix(buf[:n], []byte("goroutine "))
+	s = s[:bytes.IndexByte(s, ' ')]
+	id, err := strconv.ParseUint(string(s), 10, 64)
+	if err != nil {
+		panic("contract: cannot parse goroutine id: " + err.Error())
+	}
+	return id
+}
+
+// Panicking wraps h so that violations are still panicked with after h has seen them
+func Panicking(h Handler) Handler {
+	return func(v *Violation) bool {
+		h(v)
+		return false
+	}
+}
+
+// LogHandler logs violations to logger, or the standard logger when nil, and continues
+func LogHandler(logger *log.Logger) Handler {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return func(v *Violation) bool {
+		logger.Print(v.Error())
+		return true
+	}
+}
+
+// Collector keeps every violation it handles, mostly useful in tests
+type Collector struct {
+	mu         sync.Mutex
+	violations []*Violation
+}
+
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+func (c *Collector) Handle(v *Violation) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.violations = append(c.violations, v)
+	return true
+}
+
+// Violations returns a copy of the violations handled so far
+func (c *Collector) Violations() []*Violation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]*Violation(nil), c.violations...)
+}
+
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.violations = nil
+}
+
+// Counter counts violations per message, e.g. to export them as metrics
+type Counter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func NewCounter() *Counter {
+	return &Counter{
+		counts: map[string]int{},
+	}
+}
+
+func (c *Counter) Handle(v *Violation) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.counts[v.Message]++
+	return true
+}
+
+func (c *Counter) Count(msg string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.counts[msg]
+}
+
+// Counts returns a copy of the counts per message
+func (c *Counter) Counts() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make(map[string]int, len(c.counts))
+	for msg, n := range c.counts {
+		result[msg] = n
+	}
+	return result
+}
+
+func (c *Counter) Total() (result int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, n := range c.counts {
+		result += n
+	}
+	return
+}
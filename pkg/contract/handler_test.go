@@ -0,0 +1,80 @@
+package contract
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"sync"
+	"testing"
+)
+
+func TestSetHandler(t *testing.T) {
+	defer SetLevel(SetLevel(LevelFull))
+	counter := NewCounter()
+	old := SetHandler(counter.Handle)
+	defer SetHandler(old)
+
+	assert.NotPanics(t, func() {
+		Require(false, "x is positive")
+		Require(false, "x is positive")
+		Ensure(false, "result is sorted")
+	})
+	assert.Equal(t, 2, counter.Count("x is positive"))
+	assert.Equal(t, 1, counter.Count("result is sorted"))
+	assert.Equal(t, 3, counter.Total())
+	assert.Equal(t, map[string]int{"x is positive": 2, "result is sorted": 1}, counter.Counts())
+
+	SetHandler(Panicking(counter.Handle))
+	assert.Panics(t, func() { Require(false, "x is positive") })
+	assert.Equal(t, 3, counter.Count("x is positive"))
+
+	SetHandler(nil)
+	assert.Panics(t, func() { Require(false, "x is positive") })
+	assert.Equal(t, 3, counter.Count("x is positive"))
+}
+
+func TestWithHandler(t *testing.T) {
+	defer SetLevel(SetLevel(LevelFull))
+	collector := NewCollector()
+
+	WithHandler(collector.Handle, func() {
+		Invariant(false, "outer")
+
+		inner := NewCollector()
+		WithHandler(inner.Handle, func() {
+			Assert(false, "inner")
+		})
+		assert.Len(t, inner.Violations(), 1)
+
+		// other goroutines still panic
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Error(t, Catch(func() { Require(false, "other goroutine") }))
+		}()
+		wg.Wait()
+
+		Invariant(false, "outer again")
+	})
+
+	violations := collector.Violations()
+	assert.Len(t, violations, 2)
+	assert.Equal(t, "outer", violations[0].Message)
+	assert.Equal(t, "outer again", violations[1].Message)
+
+	collector.Reset()
+	assert.Empty(t, collector.Violations())
+	assert.Panics(t, func() { Require(false, "no handler") })
+}
+
+func TestLogHandler(t *testing.T) {
+	defer SetLevel(SetLevel(LevelFull))
+	var buf bytes.Buffer
+
+	WithHandler(LogHandler(log.New(&buf, "", 0)), func() {
+		Require(false, "x is positive")
+	})
+
+	assert.Contains(t, buf.String(), "pre-condition violation: x is positive")
+}
@@ -0,0 +1,88 @@
+package contract
+
+import (
+	"os"
+	"sync/atomic"
+)
+
+// Level controls which kinds of contracts are checked at run time
+type Level int32
+
+const (
+	// LevelOff disables all contract checking
+	LevelOff Level = iota
+	// LevelPre checks pre-conditions only
+	LevelPre
+	// LevelPost checks pre-conditions and post-conditions
+	LevelPost
+	// LevelFull additionally checks invariants and assertions,
+	// including data structure invariants
+	LevelFull
+)
+
+// LevelEnv is the environment variable read at init to override the level chosen by build tags
+const LevelEnv = "CONTRACT_LEVEL"
+
+// On tells whether the build checks pre-conditions by default, it stays a constant for existing callers.
+//
+// Deprecated: On ignores SetLevel and CONTRACT_LEVEL, use Enabled(LevelPre).
+const On = defaultLevel >= LevelPre
+
+var level = int32(defaultLevel)
+
+func init() {
+	s, ok := os.LookupEnv(LevelEnv)
+	if !ok || s == "" {
+		return
+	}
+
+	l, ok := ParseLevel(s)
+	if !ok {
+		panic("contract: unknown " + LevelEnv + " value " + s)
+	}
+	SetLevel(l)
+}
+
+func (l Level) String() string {
+	switch l {
+	case LevelOff:
+		return "off"
+	case LevelPre:
+		return "pre"
+	case LevelPost:
+		return "post"
+	case LevelFull:
+		return "full"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLevel accepts the names returned by Level.String
+func ParseLevel(s string) (Level, bool) {
+	for l := LevelOff; l <= LevelFull; l++ {
+		if l.String() == s {
+			return l, true
+		}
+	}
+
+	return LevelOff, false
+}
+
+// CurrentLevel returns the level contracts are checked at
+func CurrentLevel() Level {
+	return Level(atomic.LoadInt32(&level))
+}
+
+// SetLevel changes the level contracts are checked at and returns the previous one,
+// it is meant to be called at program start or from tests
+func SetLevel(l Level) (old Level) {
+	Require(LevelOff <= l && l <= LevelFull, "level is valid")
+
+	return Level(atomic.SwapInt32(&level, int32(l)))
+}
+
+// Enabled reports whether contracts of level l are checked
+func Enabled(l Level) bool {
+	return CurrentLevel() >= l
+}
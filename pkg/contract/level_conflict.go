@@ -0,0 +1,9 @@
+//go:build (contract_off && contract_pre) || (contract_off && contract_post) || (contract_pre && contract_post)
+
+package contract
+
+// defaultLevel keeps the package otherwise compiling, so that the error below is the only one reported
+const defaultLevel = LevelFull
+
+// at most one of the contract_off, contract_pre and contract_post build tags may be set
+var _ = contract_off_contract_pre_and_contract_post_build_tags_are_mutually_exclusive
@@ -0,0 +1,5 @@
+//go:build contract_off && !contract_pre && !contract_post
+
+package contract
+
+const defaultLevel = LevelOff
@@ -0,0 +1,5 @@
+//go:build contract_post && !contract_off && !contract_pre
+
+package contract
+
+const defaultLevel = LevelPost
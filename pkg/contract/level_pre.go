@@ -0,0 +1,5 @@
+//go:build contract_pre && !contract_off && !contract_post
+
+package contract
+
+const defaultLevel = LevelPre
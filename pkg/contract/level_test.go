@@ -0,0 +1,58 @@
+package contract
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	for l := LevelOff; l <= LevelFull; l++ {
+		parsed, ok := ParseLevel(l.String())
+		assert.True(t, ok)
+		assert.Equal(t, l, parsed)
+	}
+
+	_, ok := ParseLevel("everything")
+	assert.False(t, ok)
+}
+
+func TestLevels(t *testing.T) {
+	old := SetLevel(LevelOff)
+	defer SetLevel(old)
+
+	evaluated := false
+	inv := func() bool {
+		evaluated = true
+		return false
+	}
+
+	assert.NotPanics(t, func() { Require(false, "off") })
+	assert.NotPanics(t, func() { Ensure(false, "off") })
+	assert.NotPanics(t, func() { Invariant(false, "off") })
+	assert.NotPanics(t, func() { Assert(false, "off") })
+
+	SetLevel(LevelPre)
+	assert.Panics(t, func() { Require(false, "pre") })
+	assert.NotPanics(t, func() { Ensure(false, "pre") })
+	assert.NotPanics(t, func() { RequireInvariant(inv, "pre") })
+	assert.False(t, evaluated)
+
+	SetLevel(LevelPost)
+	assert.Panics(t, func() { Require(false, "post") })
+	assert.Panics(t, func() { Ensure(false, "post") })
+	assert.NotPanics(t, func() { Invariant(false, "post") })
+	assert.NotPanics(t, func() { EnsureInvariant(inv, "post") })
+	assert.False(t, evaluated)
+
+	SetLevel(LevelFull)
+	assert.Panics(t, func() { Invariant(false, "full") })
+	assert.Panics(t, func() { Assert(false, "full") })
+	assert.Panics(t, func() { RequireInvariant(inv, "full") })
+	assert.True(t, evaluated)
+}
+
+func TestOn(t *testing.T) {
+	defer SetLevel(SetLevel(defaultLevel))
+
+	assert.Equal(t, Enabled(LevelPre), On)
+}
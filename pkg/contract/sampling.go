@@ -0,0 +1,130 @@
+package contract
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sampler decides which evaluations of expensive data structure invariants,
+// i.e. those given to RequireInvariant and EnsureInvariant, actually happen
+type Sampler interface {
+	// Sample reports whether the invariant at hand is evaluated
+	Sample() bool
+	// Done reports the time spent evaluating a sampled invariant
+	Done(elapsed time.Duration)
+}
+
+type samplerHolder struct {
+	s Sampler
+}
+
+var (
+	sampler atomic.Value // holds a *samplerHolder
+
+	evaluating    sync.Map // goroutine ids evaluating a sampled invariant
+	evaluatingCnt int32
+)
+
+// SetSampler installs s for all goroutines and returns the previous one,
+// a nil s evaluates every invariant
+func SetSampler(s Sampler) (old Sampler) {
+	holder, _ := sampler.Swap(&samplerHolder{s: s}).(*samplerHolder)
+	if holder == nil {
+		return nil
+	}
+	return holder.s
+}
+
+// holds evaluates inv when the current sampler asks for it, skipped invariants count as holding.
+// While a goroutine evaluates a sampled invariant, its nested invariants are evaluated without asking the sampler again,
+// so that they neither use up samples nor have their time counted twice, other goroutines keep being sampled.
+// The goroutine id is only looked up while some goroutine evaluates a sampled invariant, which is O(n) anyway.
+func holds(inv func() bool) bool {
+	holder, _ := sampler.Load().(*samplerHolder)
+	if holder == nil || holder.s == nil {
+		return inv()
+	}
+
+	if atomic.LoadInt32(&evaluatingCnt) > 0 {
+		if _, nested := evaluating.Load(goroutineID()); nested {
+			return inv()
+		}
+	}
+
+	if !holder.s.Sample() {
+		return true
+	}
+
+	id := goroutineID()
+	evaluating.Store(id, struct{}{})
+	atomic.AddInt32(&evaluatingCnt, 1)
+	defer func() {
+		atomic.AddInt32(&evaluatingCnt, -1)
+		evaluating.Delete(id)
+	}()
+
+	start := time.Now()
+	result := inv()
+	holder.s.Done(time.Since(start))
+
+	return result
+}
+
+type everyN struct {
+	n     uint64
+	calls uint64
+}
+
+// EveryN samples 1 in n invariant evaluations, starting with the first one
+func EveryN(n int) Sampler {
+	Require(0 < n, "n is positive")
+
+	return &everyN{
+		n: uint64(n),
+	}
+}
+
+func (s *everyN) Sample() bool {
+	return (atomic.AddUint64(&s.calls, 1)-1)%s.n == 0
+}
+
+func (s *everyN) Done(time.Duration) {}
+
+type timeBudget struct {
+	mu          sync.Mutex
+	budget      time.Duration
+	windowStart time.Time
+	spent       time.Duration
+	now         func() time.Time
+}
+
+// TimeBudget samples invariant evaluations as long as less than budget was spent on them in the current second
+func TimeBudget(budget time.Duration) Sampler {
+	Require(0 < budget && budget <= time.Second, "budget is within (0, 1s]")
+
+	return &timeBudget{
+		budget: budget,
+		now:    time.Now,
+	}
+}
+
+func (s *timeBudget) Sample() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.windowStart) >= time.Second {
+		s.windowStart = now
+		s.spent = 0
+	}
+
+	return s.spent < s.budget
+}
+
+func (s *timeBudget) Done(elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.spent += elapsed
+}
@@ -0,0 +1,129 @@
+package contract
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEveryN(t *testing.T) {
+	defer SetLevel(SetLevel(LevelFull))
+	old := SetSampler(EveryN(3))
+	defer SetSampler(old)
+
+	evaluations := 0
+	inv := func() bool {
+		evaluations++
+		return true
+	}
+
+	for i := 0; i < 9; i++ {
+		RequireInvariant(inv, "sampled")
+	}
+	assert.Equal(t, 3, evaluations)
+
+	SetSampler(nil)
+	RequireInvariant(inv, "not sampled")
+	assert.Equal(t, 4, evaluations)
+}
+
+func TestEveryNStillCatchesViolations(t *testing.T) {
+	defer SetLevel(SetLevel(LevelFull))
+	old := SetSampler(EveryN(2))
+	defer SetSampler(old)
+
+	broken := func() bool { return false }
+	assert.Panics(t, func() { EnsureInvariant(broken, "first call is sampled") })
+	assert.NotPanics(t, func() { EnsureInvariant(broken, "second call is skipped") })
+	assert.Panics(t, func() { EnsureInvariant(broken, "third call is sampled") })
+}
+
+func TestNestedInvariantsAreNotSampledAgain(t *testing.T) {
+	defer SetLevel(SetLevel(LevelFull))
+	old := SetSampler(EveryN(2))
+	defer SetSampler(old)
+
+	inner := 0
+	outer := func() bool {
+		RequireInvariant(func() bool {
+			inner++
+			return true
+		}, "nested")
+		return true
+	}
+
+	for i := 0; i < 4; i++ {
+		EnsureInvariant(outer, "outer")
+	}
+	assert.Equal(t, 2, inner, "every sampled outer invariant evaluates the nested one")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&evaluatingCnt))
+}
+
+func TestOtherGoroutinesAreSampledWhileEvaluating(t *testing.T) {
+	defer SetLevel(SetLevel(LevelFull))
+	s := &recordingSampler{}
+	old := SetSampler(s)
+	defer SetSampler(old)
+
+	entered, release, finished := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(finished)
+		EnsureInvariant(func() bool {
+			close(entered)
+			<-release
+			return true
+		}, "outer")
+	}()
+
+	<-entered
+	RequireInvariant(func() bool { return true }, "other goroutine")
+	assert.Equal(t, 2, s.samples, "the sampler is asked for invariants of other goroutines")
+	close(release)
+	<-finished
+}
+
+func TestTimeBudgetCountsNestedTimeOnce(t *testing.T) {
+	defer SetLevel(SetLevel(LevelFull))
+	s := &recordingSampler{}
+	old := SetSampler(s)
+	defer SetSampler(old)
+
+	EnsureInvariant(func() bool {
+		RequireInvariant(func() bool { return true }, "nested")
+		return true
+	}, "outer")
+	assert.Equal(t, 1, s.samples)
+	assert.Equal(t, 1, s.done)
+}
+
+type recordingSampler struct {
+	samples, done int
+}
+
+func (s *recordingSampler) Sample() bool {
+	s.samples++
+	return true
+}
+
+func (s *recordingSampler) Done(time.Duration) {
+	s.done++
+}
+
+func TestTimeBudget(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := TimeBudget(10 * time.Millisecond).(*timeBudget)
+	s.now = func() time.Time { return now }
+
+	assert.True(t, s.Sample())
+	s.Done(6 * time.Millisecond)
+	assert.True(t, s.Sample())
+	s.Done(6 * time.Millisecond)
+	assert.False(t, s.Sample(), "budget is used up")
+
+	now = now.Add(500 * time.Millisecond)
+	assert.False(t, s.Sample(), "still in the same second")
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, s.Sample(), "budget is renewed every second")
+}
@@ -0,0 +1,92 @@
+package contract
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Kind tells which kind of contract is violated
+type Kind int
+
+const (
+	KindPreCondition Kind = iota
+	KindPostCondition
+	KindInvariant
+	KindAssertion
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindPreCondition:
+		return "pre-condition"
+	case KindPostCondition:
+		return "post-condition"
+	case KindInvariant:
+		return "invariant"
+	case KindAssertion:
+		return "assertion"
+	default:
+		return "unknown"
+	}
+}
+
+// Violation is the value contracts panic with when they do not hold,
+// File, Line and Function locate the code that states the contract
+type Violation struct {
+	Kind     Kind
+	Message  string
+	File     string
+	Line     int
+	Function string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("%s violation: %s (%s at %s:%d)", v.Kind, v.Message, v.Function, v.File, v.Line)
+}
+
+// newViolation records the caller skip frames above itself
+func newViolation(kind Kind, msg string, skip int) *Violation {
+	v := &Violation{
+		Kind:    kind,
+		Message: msg,
+	}
+
+	pc, file, line, ok := runtime.Caller(skip + 1)
+	if ok {
+		v.File = file
+		v.Line = line
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			v.Function = fn.Name()
+		}
+	}
+
+	return v
+}
+
+// violate is called by the checking functions, so the code stating the contract is 2 frames above it
+func violate(kind Kind, msg string) {
+	v := newViolation(kind, msg, 2)
+	if h := currentHandler(); h != nil && h(v) {
+		return
+	}
+
+	panic(v)
+}
+
+// Catch runs f and returns the Violation it panics with as an error, other panics are propagated.
+// The data structure f works on may be left in an inconsistent state and should be discarded.
+func Catch(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			v, ok := r.(*Violation)
+			if !ok {
+				panic(r)
+			}
+			err = v
+		}
+	}()
+
+	f()
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package contract
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCatch(t *testing.T) {
+	defer SetLevel(SetLevel(LevelFull))
+	assert.NoError(t, Catch(func() { Require(true, "holds") }))
+
+	err := Catch(func() { Require(false, "x is positive") })
+	var v *Violation
+	assert.True(t, errors.As(err, &v))
+	assert.Equal(t, KindPreCondition, v.Kind)
+	assert.Equal(t, "x is positive", v.Message)
+	assert.True(t, strings.HasSuffix(v.File, "violation_test.go"))
+	assert.True(t, strings.HasPrefix(err.Error(), "pre-condition violation: x is positive"))
+	assert.Contains(t, v.Function, "TestCatch")
+
+	err = Catch(func() { Ensuref(false, "result is %d", 42) })
+	assert.True(t, errors.As(err, &v))
+	assert.Equal(t, KindPostCondition, v.Kind)
+	assert.Equal(t, "result is 42", v.Message)
+
+	err = Catch(func() { Invariant(false, "i is within bound") })
+	assert.True(t, errors.As(err, &v))
+	assert.Equal(t, KindInvariant, v.Kind)
+
+	err = Catch(func() { CheckInvariant(func() bool { return false }, "heap invariant holds except for node i") })
+	assert.True(t, errors.As(err, &v))
+	assert.Equal(t, KindInvariant, v.Kind)
+
+	err = Catch(func() { Assert(false, "w is not nil") })
+	assert.True(t, errors.As(err, &v))
+	assert.Equal(t, KindAssertion, v.Kind)
+
+	assert.PanicsWithValue(t, "not a violation", func() {
+		_ = Catch(func() { panic("not a violation") })
+	})
+}
@@ -1,18 +1,86 @@
 package hash
 
 import (
-	"fmt"
-	"hash/maphash"
+	"golang.org/x/exp/constraints"
+	"math"
 )
 
-var stringHash maphash.Hash
+// mixing constants of xxHash64 and the finalizer of splitmix64
+const (
+	prime1 = 0x9E3779B185EBCA87
+	prime2 = 0xC2B2AE3D27D4EB4F
+)
+
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xBF58476D1CE4E5B9
+	x ^= x >> 27
+	x *= 0x94D049BB133111EB
+	x ^= x >> 31
+	return x
+}
+
+func Int[T constraints.Integer](x T) int {
+	return int(mix(uint64(x) * prime1))
+}
+
+// Float hashes 0 and -0 alike, as they are ==
+func Float[T constraints.Float](x T) int {
+	f := float64(x)
+	if f == 0 {
+		f = 0
+	}
+
+	return int(mix(math.Float64bits(f) * prime1))
+}
+
+func Bool(b bool) int {
+	if b {
+		return Int(1)
+	}
+
+	return Int(0)
+}
 
 func String(s string) int {
-	_, _ = stringHash.WriteString(s)
-	defer stringHash.Reset()
-	return int(stringHash.Sum64())
+	return int(hashBytes(s))
+}
+
+// Bytes hashes b like String hashes string(b)
+func Bytes(b []byte) int {
+	return int(hashBytes(b))
+}
+
+func hashBytes[B string | []byte](b B) uint64 {
+	h := uint64(len(b)) * prime1
+	for len(b) >= 8 {
+		w := uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+			uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56
+		h = (h ^ mix(w)) * prime2
+		b = b[8:]
+	}
+
+	var tail uint64
+	for i := 0; i < len(b); i++ {
+		tail |= uint64(b[i]) << (8 * i)
+	}
+
+	return mix((h ^ mix(tail)) * prime2)
+}
+
+// Combine hashes a struct from the hashes of its fields, the order of hashes matters
+func Combine(hashes ...int) int {
+	h := uint64(len(hashes)) * prime1
+	for _, x := range hashes {
+		h = (h ^ uint64(x)) * prime2
+	}
+
+	return int(mix(h))
 }
 
+// Universal hashes a by its dynamic type, formatting struct and array values that do not implement Hashable with fmt.
+//
+// Deprecated: use Of, which picks the hash function once and never formats.
 func Universal[T any](a T) int {
-	return String(fmt.Sprint(a))
+	return hashAny(any(a), false)
 }
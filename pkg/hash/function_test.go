@@ -0,0 +1,158 @@
+package hash
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestTypedHashes(t *testing.T) {
+	assert.Equal(t, String("hello, world"), Bytes([]byte("hello, world")))
+	assert.NotEqual(t, String("hello, world"), String("hello, World"))
+	assert.NotEqual(t, String(""), String("\x00"))
+	assert.NotEqual(t, Int(1), Int(2))
+	assert.Equal(t, Float(0.0), Float(math.Copysign(0, -1)))
+	assert.Equal(t, Float(float32(1.5)), Float(1.5))
+	assert.NotEqual(t, Bool(true), Bool(false))
+	assert.NotEqual(t, Combine(Int(1), Int(2)), Combine(Int(2), Int(1)))
+}
+
+func TestTypedHashes_NoAllocs(t *testing.T) {
+	s := "a string long enough to take a few rounds"
+	b := []byte(s)
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		String(s)
+		Bytes(b)
+		Int(42)
+		Float(4.2)
+		Combine(Int(1), String(s))
+	}))
+
+	ofInt, ofString, ofID, ofName := Of[int](), Of[string](), Of[id](), Of[name]()
+	var boxed any = name(s)
+	var p *int
+	ofPointer := Of[*int]()
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		ofInt(42)
+		ofString(s)
+		ofID(42)
+		ofName(name(s))
+		ofPointer(p)
+		hashAny(boxed, true)
+	}))
+}
+
+type point struct {
+	x, y int
+}
+
+func (p point) Hash() int {
+	return Combine(Int(p.x), Int(p.y))
+}
+
+type celsius float64
+
+type id int
+
+type name string
+
+func TestOf(t *testing.T) {
+	assert.Equal(t, Int(42), Of[int]()(42))
+	assert.Equal(t, Int(uint8(42)), Of[uint8]()(42))
+	assert.Equal(t, String("42"), Of[string]()("42"))
+	assert.Equal(t, Float(4.2), Of[float64]()(4.2))
+	assert.Equal(t, point{1, 2}.Hash(), Of[point]()(point{1, 2}))
+	assert.Equal(t, Of[celsius]()(21.5), Of[celsius]()(21.5))
+	assert.NotEqual(t, Of[celsius]()(21.5), Of[celsius]()(22.5))
+
+	assert.Equal(t, Int(42), Of[id]()(42))
+	assert.Equal(t, String("42"), Of[name]()("42"))
+	x, y := 1, 1
+	assert.Equal(t, Of[*int]()(&x), Of[*int]()(&x))
+	assert.NotEqual(t, Of[*int]()(&x), Of[*int]()(&y))
+
+	assert.Equal(t, hashAny(id(42), true), hashAny(42, true), "named types hash like their underlying type")
+	assert.NotEqual(t, hashAny(name("1"), true), hashAny(id(1), true))
+	assert.Equal(t, point{1, 2}.Hash(), hashAny(point{1, 2}, true))
+
+	type pair struct{ a, b int }
+	assert.Equal(t, Combine(Int(1), Int(2)), Of[pair]()(pair{1, 2}), "structs hash like Combine of their fields")
+	assert.NotEqual(t, Of[pair]()(pair{1, 2}), Of[pair]()(pair{2, 1}))
+	assert.Equal(t, Of[pair]()(pair{1, 2}), Of[[2]int]()([2]int{1, 2}))
+	assert.Equal(t, Combine(tagOther, Of[pair]()(pair{1, 2})), hashAny(pair{1, 2}, true))
+
+	assert.NotEqual(t, Universal[any](1), Universal[any]("1"))
+	assert.Equal(t, point{1, 2}.Hash(), Universal[any](point{1, 2}))
+	assert.Equal(t, Universal(pair{1, 2}), Universal(pair{1, 2}), "Universal still formats structs")
+	assert.NotEqual(t, Universal(pair{1, 2}), Universal(pair{2, 1}))
+}
+
+type account struct {
+	owner   name
+	balance float64
+	_       int
+	where   struct {
+		city string
+		zip  [2]uint16
+	}
+	origin point
+}
+
+func TestOf_Structs(t *testing.T) {
+	of := Of[account]()
+	a := account{owner: "ann", balance: 1.5, origin: point{1, 2}}
+	a.where.city, a.where.zip = "oslo", [2]uint16{1, 50}
+
+	b := a
+	assert.Equal(t, of(a), of(b))
+	assert.Equal(t, Combine(String("ann"), Float(1.5), String("oslo"), Int(uint16(1)), Int(uint16(50)),
+		point{1, 2}.Hash()), of(a), "nested fields are flattened, blank ones ignored")
+
+	for _, change := range []func(*account){
+		func(x *account) { x.owner = "bob" },
+		func(x *account) { x.balance = 2.5 },
+		func(x *account) { x.where.zip[1] = 51 },
+		func(x *account) { x.origin.y = 3 },
+	} {
+		c := a
+		change(&c)
+		assert.NotEqual(t, of(a), of(c))
+	}
+
+	type tagged struct{ tag any }
+	assert.Equal(t, Combine(tagOther, Combine(hashAny(id(7), true))), hashAny(tagged{id(7)}, true), "fields of interface types hash by their dynamic type")
+	assert.NotEqual(t, hashAny(tagged{id(7)}, true), hashAny(tagged{"7"}, true))
+
+	type pair struct{ a, b int }
+	ofPair := Of[pair]()
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		ofPair(pair{1, 2})
+	}))
+}
+
+func BenchmarkUniversal(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Universal(i)
+	}
+}
+
+func BenchmarkOf_Named(b *testing.B) {
+	h := Of[name]()
+	for i := 0; i < b.N; i++ {
+		h("a name")
+	}
+}
+
+func BenchmarkOf(b *testing.B) {
+	h := Of[int]()
+	for i := 0; i < b.N; i++ {
+		h(i)
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	s := "a string long enough to take a few rounds"
+	for i := 0; i < b.N; i++ {
+		String(s)
+	}
+}
@@ -0,0 +1,41 @@
+package hash
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"strings"
+)
+
+// Hasher must map values which the order.Equaler used alongside it treats as the same to the same hash
+type Hasher[T any] func(T) int
+
+// KeyedHasher is a Hasher whose hashes also depend on a Seed, like SipString
+type KeyedHasher[T any] func(Seed, T) int
+
+// CaseInsensitiveString goes along with order.Equivalent(order.CaseInsensitiveComp)
+func CaseInsensitiveString(s string) int {
+	return String(strings.Map(order.FoldRune, s))
+}
+
+// ByKey hashes the keys extract returns, it goes along with order.ByKey
+func ByKey[T any, K any](extract func(T) K, h Hasher[K]) Hasher[T] {
+	contract.Require(extract != nil, "extract is not nil")
+	contract.Require(h != nil, "hasher is not nil")
+
+	return func(x T) int {
+		return h(extract(x))
+	}
+}
+
+// Slice combines the hashes of the elements in order, it goes along with order.Lexicographic
+func Slice[T any](h Hasher[T]) Hasher[[]T] {
+	contract.Require(h != nil, "hasher is not nil")
+
+	return func(a []T) int {
+		hashes := make([]int, len(a))
+		for i, x := range a {
+			hashes[i] = h(x)
+		}
+		return Combine(hashes...)
+	}
+}
@@ -0,0 +1,24 @@
+package hash
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCaseInsensitiveString(t *testing.T) {
+	assert.Equal(t, CaseInsensitiveString("Hello"), CaseInsensitiveString("hELLO"))
+	assert.Equal(t, CaseInsensitiveString("k"), CaseInsensitiveString("K")) // Kelvin sign
+}
+
+func TestByKeySlice(t *testing.T) {
+	type point struct {
+		x, y  int
+		label string
+	}
+	h := ByKey(func(p point) []int { return []int{p.x, p.y} }, Slice(Universal[int]))
+
+	assert.Equal(t, h(point{1, 2, "a"}), h(point{1, 2, "b"}))
+	assert.Equal(t, Slice(String)(nil), Slice(String)([]string{}))
+	assert.Equal(t, Combine(Int(1), Int(2)), Slice(Int[int])([]int{1, 2}), "slices hash like Combine of their elements")
+	assert.NotEqual(t, Slice(Int[int])([]int{1, 2}), Slice(Int[int])([]int{2, 1}))
+}
@@ -0,0 +1,308 @@
+package hash
+
+import (
+	"fmt"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// Hashable is implemented by types which hash themselves, e.g. structs combining the hashes of their fields with Combine
+type Hashable interface {
+	Hash() int
+}
+
+// Of picks the hash function of T once, so that hashing neither reflects nor allocates: Hashable.Hash when T implements it,
+// the typed hash functions when T is an integer, float, bool or string type, named or not, the address when T is
+// a pointer or channel type, and a per value choice tagged with the dynamic type when T is an interface type.
+// Struct and array types not implementing Hashable hash like Combine of the hashes of their fields and elements,
+// nested ones flattened, whose offsets are looked up once here. Only fields of interface types or implementing Hashable
+// are hashed through reflect.
+func Of[T comparable]() Hasher[T] {
+	var zero T
+	switch any(zero).(type) {
+	case nil: // T is an interface type
+		return func(x T) int { return hashAny(any(x), true) }
+	case Hashable:
+		return func(x T) int { return any(x).(Hashable).Hash() }
+	case int:
+		return as[T](Int[int])
+	case int8:
+		return as[T](Int[int8])
+	case int16:
+		return as[T](Int[int16])
+	case int32:
+		return as[T](Int[int32])
+	case int64:
+		return as[T](Int[int64])
+	case uint:
+		return as[T](Int[uint])
+	case uint8:
+		return as[T](Int[uint8])
+	case uint16:
+		return as[T](Int[uint16])
+	case uint32:
+		return as[T](Int[uint32])
+	case uint64:
+		return as[T](Int[uint64])
+	case uintptr:
+		return as[T](Int[uintptr])
+	case float32:
+		return as[T](Float[float32])
+	case float64:
+		return as[T](Float[float64])
+	case bool:
+		return as[T](Bool)
+	case string:
+		return as[T](String)
+	default:
+		return ofKind[T]()
+	}
+}
+
+// ofKind picks the hash function of T by its underlying kind, looked up once
+func ofKind[T comparable]() Hasher[T] {
+	switch typ := reflect.TypeOf((*T)(nil)).Elem(); typ.Kind() {
+	case reflect.Int:
+		return as[T](Int[int])
+	case reflect.Int8:
+		return as[T](Int[int8])
+	case reflect.Int16:
+		return as[T](Int[int16])
+	case reflect.Int32:
+		return as[T](Int[int32])
+	case reflect.Int64:
+		return as[T](Int[int64])
+	case reflect.Uint:
+		return as[T](Int[uint])
+	case reflect.Uint8:
+		return as[T](Int[uint8])
+	case reflect.Uint16:
+		return as[T](Int[uint16])
+	case reflect.Uint32:
+		return as[T](Int[uint32])
+	case reflect.Uint64:
+		return as[T](Int[uint64])
+	case reflect.Uintptr, reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return as[T](Int[uintptr])
+	case reflect.Float32:
+		return as[T](Float[float32])
+	case reflect.Float64:
+		return as[T](Float[float64])
+	case reflect.Bool:
+		return as[T](Bool)
+	case reflect.String:
+		return as[T](String)
+	default:
+		leaves := leavesOf(typ, 0, nil)
+		for _, l := range leaves {
+			if l.typ != nil {
+				return func(x T) int { return hashLeavesReflect(leaves, unsafe.Pointer(&x)) }
+			}
+		}
+		return func(x T) int { return hashLeaves(leaves, unsafe.Pointer(&x)) }
+	}
+}
+
+// leaf is a field of a struct, or an element of an array, hashed on its own, nested structs and arrays are flattened
+type leaf struct {
+	offset uintptr
+	kind   reflect.Kind
+	typ    reflect.Type // only set for leaves hashed through reflect, i.e. of interface types or implementing Hashable
+}
+
+var hashableType = reflect.TypeOf((*Hashable)(nil)).Elem()
+
+// leavesOf appends the leaves of typ, which is at offset in the outermost value, to leaves
+func leavesOf(typ reflect.Type, offset uintptr, leaves []leaf) []leaf {
+	contract.Requiref(typ.Comparable(), "%v is comparable", typ)
+
+	switch {
+	case typ.Implements(hashableType) || typ.Kind() == reflect.Interface:
+		return append(leaves, leaf{offset: offset, kind: typ.Kind(), typ: typ})
+	case typ.Kind() == reflect.Array:
+		for i := 0; i < typ.Len(); i++ {
+			leaves = leavesOf(typ.Elem(), offset+uintptr(i)*typ.Elem().Size(), leaves)
+		}
+		return leaves
+	case typ.Kind() == reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			// == ignores blank fields
+			if f := typ.Field(i); f.Name != "_" {
+				leaves = leavesOf(f.Type, offset+f.Offset, leaves)
+			}
+		}
+		return leaves
+	default:
+		return append(leaves, leaf{offset: offset, kind: typ.Kind()})
+	}
+}
+
+// hashLeaves combines the hashes of the leaves of the value p points to like Combine,
+// p does not escape so that hashing a local value does not allocate
+func hashLeaves(leaves []leaf, p unsafe.Pointer) int {
+	h := uint64(len(leaves)) * prime1
+	for _, l := range leaves {
+		h = (h ^ uint64(hashLeaf(l.kind, unsafe.Add(p, l.offset)))) * prime2
+	}
+
+	return int(mix(h))
+}
+
+// hashLeavesReflect is hashLeaves for values with leaves of interface types or implementing Hashable
+func hashLeavesReflect(leaves []leaf, p unsafe.Pointer) int {
+	h := uint64(len(leaves)) * prime1
+	for _, l := range leaves {
+		q := unsafe.Add(p, l.offset)
+		x := 0
+		if l.typ == nil {
+			x = hashLeaf(l.kind, q)
+		} else {
+			x = hashAny(reflect.NewAt(l.typ, q).Elem().Interface(), true)
+		}
+		h = (h ^ uint64(x)) * prime2
+	}
+
+	return int(mix(h))
+}
+
+func hashLeaf(kind reflect.Kind, p unsafe.Pointer) int {
+	switch kind {
+	case reflect.Int:
+		return Int(*(*int)(p))
+	case reflect.Int8:
+		return Int(*(*int8)(p))
+	case reflect.Int16:
+		return Int(*(*int16)(p))
+	case reflect.Int32:
+		return Int(*(*int32)(p))
+	case reflect.Int64:
+		return Int(*(*int64)(p))
+	case reflect.Uint:
+		return Int(*(*uint)(p))
+	case reflect.Uint8:
+		return Int(*(*uint8)(p))
+	case reflect.Uint16:
+		return Int(*(*uint16)(p))
+	case reflect.Uint32:
+		return Int(*(*uint32)(p))
+	case reflect.Uint64:
+		return Int(*(*uint64)(p))
+	case reflect.Uintptr, reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return Int(*(*uintptr)(p))
+	case reflect.Float32:
+		return Float(*(*float32)(p))
+	case reflect.Float64:
+		return Float(*(*float64)(p))
+	case reflect.Complex64:
+		c := *(*complex64)(p)
+		return Combine(Float(real(c)), Float(imag(c)))
+	case reflect.Complex128:
+		c := *(*complex128)(p)
+		return Combine(Float(real(c)), Float(imag(c)))
+	case reflect.Bool:
+		return Bool(*(*bool)(p))
+	default: // reflect.String
+		return String(*(*string)(p))
+	}
+}
+
+var typeLeaves sync.Map // reflect.Type to []leaf, for the dynamic types of interface values
+
+// hashByFields hashes the struct or array value v like Of does, the leaves of its type are looked up on first use
+func hashByFields(v reflect.Value) int {
+	leaves, ok := typeLeaves.Load(v.Type())
+	if !ok {
+		leaves, _ = typeLeaves.LoadOrStore(v.Type(), leavesOf(v.Type(), 0, nil))
+	}
+
+	copied := reflect.New(v.Type())
+	copied.Elem().Set(v)
+	return hashLeavesReflect(leaves.([]leaf), unsafe.Pointer(copied.Pointer()))
+}
+
+// as reinterprets T as U, which Of has checked to be the same type
+func as[T any, U any](h func(U) int) Hasher[T] {
+	return func(x T) int {
+		return h(*(*U)(unsafe.Pointer(&x)))
+	}
+}
+
+// type tags of hashAny, so that e.g. 1 and "1" differ
+const (
+	tagInt = iota + 1
+	tagUint
+	tagFloat
+	tagBool
+	tagString
+	tagPointer
+	tagOther
+)
+
+// hashAny hashes v by its dynamic type, byFields hashes struct and array values not implementing Hashable
+// like Of does, instead of formatting them
+func hashAny(v any, byFields bool) int {
+	switch x := v.(type) {
+	case nil:
+		return 0
+	case Hashable:
+		return x.Hash()
+	case int:
+		return Combine(tagInt, Int(x))
+	case int8:
+		return Combine(tagInt, Int(x))
+	case int16:
+		return Combine(tagInt, Int(x))
+	case int32:
+		return Combine(tagInt, Int(x))
+	case int64:
+		return Combine(tagInt, Int(x))
+	case uint:
+		return Combine(tagUint, Int(x))
+	case uint8:
+		return Combine(tagUint, Int(x))
+	case uint16:
+		return Combine(tagUint, Int(x))
+	case uint32:
+		return Combine(tagUint, Int(x))
+	case uint64:
+		return Combine(tagUint, Int(x))
+	case uintptr:
+		return Combine(tagUint, Int(x))
+	case float32:
+		return Combine(tagFloat, Float(x))
+	case float64:
+		return Combine(tagFloat, Float(x))
+	case bool:
+		return Combine(tagBool, Bool(x))
+	case string:
+		return Combine(tagString, String(x))
+	default:
+		return hashValue(reflect.ValueOf(v), byFields)
+	}
+}
+
+// hashValue hashes the dynamic values of named types by their underlying kind, reading them through reflect.Value
+// so as not to allocate. Struct and array values not implementing Hashable are hashed by fields or formatted with fmt.
+func hashValue(v reflect.Value, byFields bool) int {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Combine(tagInt, Int(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Combine(tagUint, Int(v.Uint()))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return Combine(tagPointer, Int(v.Pointer()))
+	case reflect.Float32, reflect.Float64:
+		return Combine(tagFloat, Float(v.Float()))
+	case reflect.Bool:
+		return Combine(tagBool, Bool(v.Bool()))
+	case reflect.String:
+		return Combine(tagString, String(v.String()))
+	default:
+		if byFields {
+			return Combine(tagOther, hashByFields(v))
+		}
+		return Combine(tagOther, String(fmt.Sprintf("%T %#v", v.Interface(), v.Interface())))
+	}
+}
@@ -0,0 +1,76 @@
+package hash
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"reflect"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// DefaultChainLimit times maxLoad is the chain length past which a chained hash table picks a fresh seed
+const DefaultChainLimit = 8
+
+// Seed keys the hashing of one container, so that equal hashes in one container do not imply equal hashes in another.
+// All functions of this package are pure, so they are safe for concurrent use with any seeds.
+type Seed struct {
+	k0, k1 uint64
+}
+
+// FixedSeed makes a Seed from two keys, e.g. for reproducible tests
+func FixedSeed(k0, k1 uint64) Seed {
+	return Seed{k0: k0, k1: k1}
+}
+
+var seedCounter uint64
+
+// RandomSeed makes a fresh unpredictable Seed
+func RandomSeed() Seed {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// fall back on the clock, which is predictable but still differs per call
+		n := atomic.AddUint64(&seedCounter, 1)
+		return Seed{
+			k0: mix(uint64(time.Now().UnixNano()) ^ n*prime1),
+			k1: mix(n * prime2),
+		}
+	}
+
+	return Seed{
+		k0: binary.LittleEndian.Uint64(b[:8]),
+		k1: binary.LittleEndian.Uint64(b[8:]),
+	}
+}
+
+// Keys returns the keys the seed is made of
+func (s Seed) Keys() (k0, k1 uint64) {
+	return s.k0, s.k1
+}
+
+// Mix keys an unseeded hash with s
+func (s Seed) Mix(h int) int {
+	return int(mix(mix(uint64(h)^s.k0) ^ s.k1))
+}
+
+// Keyed seeds h by mixing its hashes with the seed, values h maps to the same hash still collide under every seed
+func Keyed[T any](h Hasher[T]) KeyedHasher[T] {
+	contract.Require(h != nil, "hasher is not nil")
+
+	return func(s Seed, x T) int {
+		return s.Mix(h(x))
+	}
+}
+
+// OfKeyed is the KeyedHasher of T the hash containers default to: SipString when T is a string type, named or not,
+// so that a fresh seed also breaks up collisions of keys chosen by an attacker, and Keyed(Of[T]()) otherwise
+func OfKeyed[T comparable]() KeyedHasher[T] {
+	if reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.String {
+		return func(s Seed, x T) int {
+			return SipString(s, *(*string)(unsafe.Pointer(&x)))
+		}
+	}
+
+	return Keyed(Of[T]())
+}
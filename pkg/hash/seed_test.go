@@ -0,0 +1,77 @@
+package hash
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestSeed(t *testing.T) {
+	s := FixedSeed(1, 2)
+	k0, k1 := s.Keys()
+	assert.Equal(t, uint64(1), k0)
+	assert.Equal(t, uint64(2), k1)
+	assert.Equal(t, s.Mix(String("go")), FixedSeed(1, 2).Mix(String("go")))
+	assert.NotEqual(t, s.Mix(String("go")), FixedSeed(2, 1).Mix(String("go")))
+	assert.NotEqual(t, RandomSeed(), RandomSeed())
+}
+
+// run with -race, every hash is computed concurrently and compared against a sequential run
+func TestHash_Concurrent(t *testing.T) {
+	const goroutines, n = 8, 1000
+
+	seeds := []Seed{FixedSeed(0, 0), FixedSeed(1, 2), RandomSeed()}
+	of := Of[string]()
+	want := make([][]int, len(seeds))
+	for i, s := range seeds {
+		for j := 0; j < n; j++ {
+			want[i] = append(want[i], s.Mix(of(strconv.Itoa(j)))^s.Mix(Universal(j)))
+		}
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i, s := range seeds {
+				for j := 0; j < n; j++ {
+					assert.Equal(t, want[i][j], s.Mix(of(strconv.Itoa(j)))^s.Mix(Universal(j)))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRandomSeed_Concurrent(t *testing.T) {
+	const goroutines = 8
+
+	var wg sync.WaitGroup
+	seeds := make([]Seed, goroutines)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			seeds[g] = RandomSeed()
+		}(g)
+	}
+	wg.Wait()
+
+	for i := range seeds {
+		for j := i + 1; j < len(seeds); j++ {
+			assert.NotEqual(t, seeds[i], seeds[j])
+		}
+	}
+}
+
+func TestOfKeyed(t *testing.T) {
+	type name string
+	s := FixedSeed(1, 2)
+
+	assert.Equal(t, SipString(s, "go"), OfKeyed[string]()(s, "go"))
+	assert.Equal(t, SipString(s, "go"), OfKeyed[name]()(s, "go"))
+	assert.NotEqual(t, OfKeyed[string]()(s, "go"), OfKeyed[string]()(FixedSeed(2, 1), "go"))
+	assert.Equal(t, s.Mix(Int(42)), OfKeyed[int]()(s, 42))
+}
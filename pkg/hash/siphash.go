@@ -0,0 +1,64 @@
+package hash
+
+import "math/bits"
+
+// SipString is SipHash-2-4 keyed by s, unlike String it withstands collision attacks by anyone not knowing the seed
+func SipString(s Seed, x string) int {
+	return int(sipHash(s, x))
+}
+
+// SipBytes hashes b like SipString hashes string(b)
+func SipBytes(s Seed, b []byte) int {
+	return int(sipHash(s, b))
+}
+
+func sipHash[B string | []byte](s Seed, b B) uint64 {
+	v0 := s.k0 ^ 0x736f6d6570736575
+	v1 := s.k1 ^ 0x646f72616e646f6d
+	v2 := s.k0 ^ 0x6c7967656e657261
+	v3 := s.k1 ^ 0x7465646279746573
+
+	round := func() {
+		v0 += v1
+		v1 = bits.RotateLeft64(v1, 13)
+		v1 ^= v0
+		v0 = bits.RotateLeft64(v0, 32)
+		v2 += v3
+		v3 = bits.RotateLeft64(v3, 16)
+		v3 ^= v2
+		v0 += v3
+		v3 = bits.RotateLeft64(v3, 21)
+		v3 ^= v0
+		v2 += v1
+		v1 = bits.RotateLeft64(v1, 17)
+		v1 ^= v2
+		v2 = bits.RotateLeft64(v2, 32)
+	}
+
+	last := uint64(len(b)) << 56
+	for len(b) >= 8 {
+		m := uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+			uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56
+		v3 ^= m
+		round()
+		round()
+		v0 ^= m
+		b = b[8:]
+	}
+
+	for i := 0; i < len(b); i++ {
+		last |= uint64(b[i]) << (8 * i)
+	}
+	v3 ^= last
+	round()
+	round()
+	v0 ^= last
+
+	v2 ^= 0xff
+	round()
+	round()
+	round()
+	round()
+
+	return v0 ^ v1 ^ v2 ^ v3
+}
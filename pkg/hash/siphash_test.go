@@ -0,0 +1,37 @@
+package hash
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSipHash(t *testing.T) {
+	// test vectors of the SipHash paper
+	s := FixedSeed(0x0706050403020100, 0x0f0e0d0c0b0a0908)
+	b := make([]byte, 15)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	assert.Equal(t, uint64(0x726fdb47dd0e0e31), uint64(SipBytes(s, nil)))
+	assert.Equal(t, uint64(0xa129ca6149be45e5), uint64(SipBytes(s, b)))
+
+	assert.Equal(t, SipString(s, "hello, world"), SipBytes(s, []byte("hello, world")))
+	assert.NotEqual(t, SipString(s, "hello, world"), SipString(FixedSeed(1, 2), "hello, world"))
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		SipString(s, "a string long enough to take a few rounds")
+	}))
+}
+
+func TestKeyed(t *testing.T) {
+	h := Keyed(String)
+	s := FixedSeed(1, 2)
+	assert.Equal(t, s.Mix(String("go")), h(s, "go"))
+	assert.NotEqual(t, h(s, "go"), h(FixedSeed(2, 1), "go"))
+}
+
+func BenchmarkSipString(b *testing.B) {
+	s := RandomSeed()
+	for i := 0; i < b.N; i++ {
+		SipString(s, "a string long enough to take a few rounds")
+	}
+}
@@ -0,0 +1,75 @@
+package hash
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"sync/atomic"
+)
+
+// TableStats describes the shape of a hash table, long chains or many probes per lookup hint at a bad Hasher
+type TableStats struct {
+	Capacity   int
+	Size       int
+	LoadFactor float64
+	// LongestChain is the most entries sharing one slot
+	LongestChain int
+	// ChainLengths counts the chains of every length, ChainLengths[0] being the count of empty slots
+	ChainLengths []int
+	Resizes      int
+	Reseeds      int
+	// Probes holds the count of entries compared by each of the last recorded lookups, oldest first
+	Probes []int
+}
+
+// MeanProbes averages Probes, it is 0 when no lookups are recorded
+func (s TableStats) MeanProbes() float64 {
+	if len(s.Probes) == 0 {
+		return 0
+	}
+
+	sum := 0
+	for _, p := range s.Probes {
+		sum += p
+	}
+
+	return float64(sum) / float64(len(s.Probes))
+}
+
+// ProbeLog keeps the probe counts of the last lookups of a hash table.
+// Lookups may record concurrently, a nil ProbeLog records nothing.
+type ProbeLog struct {
+	probes []int32
+	next   uint64
+}
+
+func NewProbeLog(n int) *ProbeLog {
+	contract.Require(0 < n, "n is positive")
+
+	return &ProbeLog{probes: make([]int32, n)}
+}
+
+func (l *ProbeLog) Record(probes int) {
+	if l == nil {
+		return
+	}
+
+	i := atomic.AddUint64(&l.next, 1) - 1
+	atomic.StoreInt32(&l.probes[i%uint64(len(l.probes))], int32(probes))
+}
+
+// Last returns the recorded probe counts, oldest first
+func (l *ProbeLog) Last() (result []int) {
+	if l == nil {
+		return nil
+	}
+
+	next := atomic.LoadUint64(&l.next)
+	start := uint64(0)
+	if n := uint64(len(l.probes)); next > n {
+		start = next - n
+	}
+	for i := start; i < next; i++ {
+		result = append(result, int(atomic.LoadInt32(&l.probes[i%uint64(len(l.probes))])))
+	}
+
+	return
+}
@@ -0,0 +1,44 @@
+package hash
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestProbeLog(t *testing.T) {
+	var none *ProbeLog
+	none.Record(1)
+	assert.Nil(t, none.Last())
+
+	l := NewProbeLog(3)
+	assert.Empty(t, l.Last())
+	l.Record(1)
+	l.Record(2)
+	assert.Equal(t, []int{1, 2}, l.Last())
+	l.Record(3)
+	l.Record(4)
+	assert.Equal(t, []int{2, 3, 4}, l.Last())
+	assert.Equal(t, 3.0, TableStats{Probes: l.Last()}.MeanProbes())
+	assert.Equal(t, 0.0, TableStats{}.MeanProbes())
+}
+
+// run with -race
+func TestProbeLog_Concurrent(t *testing.T) {
+	l := NewProbeLog(16)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				l.Record(1)
+				l.Last()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, l.Last())
+}
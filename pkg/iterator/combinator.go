@@ -0,0 +1,253 @@
+package iterator
+
+import "github.com/song-flying/GoDataStructures/pkg/contract"
+
+// Pair is the element type of Zip and Enumerate
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+type sliceIterator[T any] struct {
+	a []T
+	i int
+}
+
+func (it *sliceIterator[T]) HasNext() bool {
+	return it.i < len(it.a)
+}
+
+func (it *sliceIterator[T]) Next() (result T) {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	result = it.a[it.i]
+	it.i++
+	return
+}
+
+func FromSlice[T any](a []T) Iterator[T] {
+	return &sliceIterator[T]{a: a}
+}
+
+type mapIterator[T any, U any] struct {
+	it Iterator[T]
+	f  func(T) U
+}
+
+func (m *mapIterator[T, U]) HasNext() bool {
+	return m.it.HasNext()
+}
+
+func (m *mapIterator[T, U]) Next() U {
+	contract.Require(m.HasNext(), "iterator has next element")
+
+	return m.f(m.it.Next())
+}
+
+func Map[T any, U any](it Iterator[T], f func(T) U) Iterator[U] {
+	contract.Require(it != nil && f != nil, "it and f are not nil")
+
+	return &mapIterator[T, U]{it: it, f: f}
+}
+
+// lookahead is shared by iterators which only know whether there is a next element after fetching it
+type lookahead[T any] struct {
+	fetch   func() (T, bool)
+	fetched bool
+	next    T
+	ok      bool
+}
+
+func (l *lookahead[T]) HasNext() bool {
+	if !l.fetched {
+		l.next, l.ok = l.fetch()
+		l.fetched = true
+	}
+
+	return l.ok
+}
+
+func (l *lookahead[T]) Next() T {
+	contract.Require(l.HasNext(), "iterator has next element")
+
+	l.fetched = false
+	return l.next
+}
+
+func Filter[T any](it Iterator[T], pred func(T) bool) Iterator[T] {
+	contract.Require(it != nil && pred != nil, "it and pred are not nil")
+
+	return &lookahead[T]{
+		fetch: func() (T, bool) {
+			for it.HasNext() {
+				if x := it.Next(); pred(x) {
+					return x, true
+				}
+			}
+			return *new(T), false
+		},
+	}
+}
+
+type takeIterator[T any] struct {
+	it Iterator[T]
+	n  int
+}
+
+func (t *takeIterator[T]) HasNext() bool {
+	return t.n > 0 && t.it.HasNext()
+}
+
+func (t *takeIterator[T]) Next() T {
+	contract.Require(t.HasNext(), "iterator has next element")
+
+	t.n--
+	return t.it.Next()
+}
+
+// Take stops after the first n elements of it
+func Take[T any](it Iterator[T], n int) Iterator[T] {
+	contract.Require(it != nil, "it is not nil")
+	contract.Require(0 <= n, "n is non-negative")
+
+	return &takeIterator[T]{it: it, n: n}
+}
+
+// Skip drops the first n elements of it, once the result is first used
+func Skip[T any](it Iterator[T], n int) Iterator[T] {
+	contract.Require(it != nil, "it is not nil")
+	contract.Require(0 <= n, "n is non-negative")
+
+	return &lookahead[T]{
+		fetch: func() (T, bool) {
+			for ; n > 0 && it.HasNext(); n-- {
+				it.Next()
+			}
+			if it.HasNext() {
+				return it.Next(), true
+			}
+			return *new(T), false
+		},
+	}
+}
+
+type zipIterator[A any, B any] struct {
+	a Iterator[A]
+	b Iterator[B]
+}
+
+func (z *zipIterator[A, B]) HasNext() bool {
+	return z.a.HasNext() && z.b.HasNext()
+}
+
+func (z *zipIterator[A, B]) Next() Pair[A, B] {
+	contract.Require(z.HasNext(), "iterator has next element")
+
+	return Pair[A, B]{First: z.a.Next(), Second: z.b.Next()}
+}
+
+// Zip pairs up the elements of a and b, it stops with the shorter one
+func Zip[A any, B any](a Iterator[A], b Iterator[B]) Iterator[Pair[A, B]] {
+	contract.Require(a != nil && b != nil, "a and b are not nil")
+
+	return &zipIterator[A, B]{a: a, b: b}
+}
+
+type chainIterator[T any] struct {
+	its []Iterator[T]
+}
+
+func (c *chainIterator[T]) HasNext() bool {
+	for len(c.its) > 0 && !c.its[0].HasNext() {
+		c.its = c.its[1:]
+	}
+
+	return len(c.its) > 0
+}
+
+func (c *chainIterator[T]) Next() T {
+	contract.Require(c.HasNext(), "iterator has next element")
+
+	return c.its[0].Next()
+}
+
+// Chain walks its one after the other
+func Chain[T any](its ...Iterator[T]) Iterator[T] {
+	return &chainIterator[T]{its: its}
+}
+
+type flatMapIterator[T any, U any] struct {
+	it   Iterator[T]
+	f    func(T) Iterator[U]
+	curr Iterator[U]
+}
+
+func (fm *flatMapIterator[T, U]) HasNext() bool {
+	for (fm.curr == nil || !fm.curr.HasNext()) && fm.it.HasNext() {
+		fm.curr = fm.f(fm.it.Next())
+	}
+
+	return fm.curr != nil && fm.curr.HasNext()
+}
+
+func (fm *flatMapIterator[T, U]) Next() U {
+	contract.Require(fm.HasNext(), "iterator has next element")
+
+	return fm.curr.Next()
+}
+
+// FlatMap walks the iterators f returns for each element of it, one after the other
+func FlatMap[T any, U any](it Iterator[T], f func(T) Iterator[U]) Iterator[U] {
+	contract.Require(it != nil && f != nil, "it and f are not nil")
+
+	return &flatMapIterator[T, U]{it: it, f: f}
+}
+
+// Enumerate pairs up every element of it with its index
+func Enumerate[T any](it Iterator[T]) Iterator[Pair[int, T]] {
+	contract.Require(it != nil, "it is not nil")
+
+	i := -1
+	return Map(it, func(x T) Pair[int, T] {
+		i++
+		return Pair[int, T]{First: i, Second: x}
+	})
+}
+
+// Window walks all runs of n consecutive elements of it, each one in a new slice
+func Window[T any](it Iterator[T], n int) Iterator[[]T] {
+	contract.Require(it != nil, "it is not nil")
+	contract.Require(0 < n, "n is positive")
+
+	var window []T
+	return &lookahead[[]T]{
+		fetch: func() ([]T, bool) {
+			if len(window) == n {
+				window = window[1:]
+			}
+			for len(window) < n && it.HasNext() {
+				window = append(window, it.Next())
+			}
+			if len(window) < n {
+				return nil, false
+			}
+			return append([]T(nil), window...), true
+		},
+	}
+}
+
+// Chunk splits it into slices of n elements, the last one may be shorter
+func Chunk[T any](it Iterator[T], n int) Iterator[[]T] {
+	contract.Require(it != nil, "it is not nil")
+	contract.Require(0 < n, "n is positive")
+
+	return &lookahead[[]T]{
+		fetch: func() ([]T, bool) {
+			var chunk []T
+			for len(chunk) < n && it.HasNext() {
+				chunk = append(chunk, it.Next())
+			}
+			return chunk, len(chunk) > 0
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package iterator
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func TestMapFilter(t *testing.T) {
+	it := Map(Filter(FromSlice([]int{1, 2, 3, 4, 5, 6}), func(x int) bool { return x%2 == 0 }), strconv.Itoa)
+	assert.Equal(t, []string{"2", "4", "6"}, Collect(it))
+
+	assert.Empty(t, Collect(Filter(FromSlice([]int{1, 3}), func(x int) bool { return x%2 == 0 })))
+}
+
+func TestTakeSkip(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+
+	assert.Equal(t, []int{1, 2}, Collect(Take(FromSlice([]int{1, 2, 3}), 2)))
+	assert.Equal(t, []int{1, 2, 3}, Collect(Take(FromSlice([]int{1, 2, 3}), 5)))
+	assert.Empty(t, Collect(Take(FromSlice([]int{1, 2, 3}), 0)))
+
+	assert.Equal(t, []int{3}, Collect(Skip(FromSlice([]int{1, 2, 3}), 2)))
+	assert.Empty(t, Collect(Skip(FromSlice([]int{1, 2, 3}), 5)))
+	assert.Equal(t, []int{3, 4}, Collect(Take(Skip(FromSlice([]int{1, 2, 3, 4, 5}), 2), 2)))
+
+	assert.Panics(t, func() { Take(FromSlice([]int{1}), -1) })
+}
+
+func TestZipEnumerate(t *testing.T) {
+	zipped := Collect(Zip(FromSlice([]int{1, 2, 3}), FromSlice([]string{"a", "b"})))
+	assert.Equal(t, []Pair[int, string]{{1, "a"}, {2, "b"}}, zipped)
+
+	enumerated := Collect(Enumerate(FromSlice([]string{"a", "b"})))
+	assert.Equal(t, []Pair[int, string]{{0, "a"}, {1, "b"}}, enumerated)
+}
+
+func TestChainFlatMap(t *testing.T) {
+	chained := Chain(FromSlice([]int{}), FromSlice([]int{1, 2}), FromSlice([]int{}), FromSlice([]int{3}))
+	assert.Equal(t, []int{1, 2, 3}, Collect(chained))
+	assert.Empty(t, Collect(Chain[int]()))
+
+	repeat := func(n int) Iterator[int] {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = n
+		}
+		return FromSlice(a)
+	}
+	assert.Equal(t, []int{1, 3, 3, 3}, Collect(FlatMap(FromSlice([]int{0, 1, 0, 3}), repeat)))
+}
+
+func TestWindowChunk(t *testing.T) {
+	windows := Collect(Window(FromSlice([]int{1, 2, 3, 4}), 2))
+	assert.Equal(t, [][]int{{1, 2}, {2, 3}, {3, 4}}, windows)
+	assert.Empty(t, Collect(Window(FromSlice([]int{1, 2}), 3)))
+
+	chunks := Collect(Chunk(FromSlice([]int{1, 2, 3, 4, 5}), 2))
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, chunks)
+	assert.Empty(t, Collect(Chunk(FromSlice([]int{}), 2)))
+}
+
+func TestLaziness(t *testing.T) {
+	pulled := 0
+	source := Map(FromSlice([]int{1, 2, 3, 4, 5}), func(x int) int {
+		pulled++
+		return x
+	})
+
+	it := Take(Filter(source, func(x int) bool { return x > 1 }), 2)
+	assert.Equal(t, 0, pulled)
+
+	assert.Equal(t, []int{2, 3}, Collect(it))
+	assert.Equal(t, 3, pulled)
+}
@@ -0,0 +1,84 @@
+package iterator
+
+import "github.com/song-flying/GoDataStructures/pkg/contract"
+
+// MutableIterator can also remove the element last returned by Next from the underlying container,
+// which is the only safe way of modifying a container while walking it
+type MutableIterator[T any] interface {
+	Iterator[T]
+	Remove()
+}
+
+// ModifiedMsg is the message of ConcurrentModification
+const ModifiedMsg = "container is not modified during iteration"
+
+// ConcurrentModification is the value iterators panic with once their container is modified under them.
+// Unlike a contract violation it is raised at every contract level and whatever the handler,
+// since walking on would go over a container that is not the one the walk began on.
+type ConcurrentModification struct{}
+
+func (ConcurrentModification) Error() string {
+	return ModifiedMsg
+}
+
+// CheckMods panics with ConcurrentModification unless mods, the modification count of a container, is still expected
+func CheckMods(mods, expected int) {
+	if mods != expected {
+		panic(ConcurrentModification{})
+	}
+}
+
+type failFastIterator[T any] struct {
+	it       Iterator[T]
+	mods     func() int
+	expected int
+}
+
+func (f *failFastIterator[T]) HasNext() bool {
+	CheckMods(f.mods(), f.expected)
+
+	return f.it.HasNext()
+}
+
+func (f *failFastIterator[T]) Next() T {
+	contract.Require(f.HasNext(), "iterator has next element")
+
+	return f.it.Next()
+}
+
+// FailFast panics with ConcurrentModification once mods, the modification count of the container it walks,
+// differs from its value when FailFast is called
+func FailFast[T any](it Iterator[T], mods func() int) Iterator[T] {
+	contract.Require(it != nil && mods != nil, "it and mods are not nil")
+
+	return &failFastIterator[T]{it: it, mods: mods, expected: mods()}
+}
+
+// FailFastSeq panics with ConcurrentModification once mods differs from its value when the walk starts,
+// which happens when yield modifies the container
+func FailFastSeq[T any](s Seq[T], mods func() int) Seq[T] {
+	contract.Require(s != nil && mods != nil, "s and mods are not nil")
+
+	return func(yield func(T) bool) {
+		expected := mods()
+		s(func(x T) bool {
+			ok := yield(x)
+			CheckMods(mods(), expected)
+			return ok
+		})
+	}
+}
+
+// FailFastSeq2 is FailFastSeq for Seq2
+func FailFastSeq2[K any, V any](s Seq2[K, V], mods func() int) Seq2[K, V] {
+	contract.Require(s != nil && mods != nil, "s and mods are not nil")
+
+	return func(yield func(K, V) bool) {
+		expected := mods()
+		s(func(k K, v V) bool {
+			ok := yield(k, v)
+			CheckMods(mods(), expected)
+			return ok
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package iterator
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFailFast(t *testing.T) {
+	mods := 0
+	it := FailFast(FromSlice([]int{1, 2, 3}), func() int { return mods })
+	assert.Equal(t, 1, it.Next())
+
+	mods++
+	assert.PanicsWithError(t, ModifiedMsg, func() { it.Next() })
+}
+
+func TestFailFastSeq(t *testing.T) {
+	mods := 0
+	s := FailFastSeq(Seq[int](func(yield func(int) bool) {
+		for _, x := range []int{1, 2, 3} {
+			if !yield(x) {
+				return
+			}
+		}
+	}), func() int { return mods })
+
+	var a []int
+	s(func(x int) bool {
+		a = append(a, x)
+		return true
+	})
+	assert.Equal(t, []int{1, 2, 3}, a)
+
+	assert.PanicsWithError(t, ModifiedMsg, func() {
+		s(func(x int) bool {
+			mods++
+			return true
+		})
+	})
+}
+
+func TestFailFast_IgnoresContractLevelAndHandler(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelOff))
+	mods := 0
+	it := FailFast(FromSlice([]int{1, 2, 3}), func() int { return mods })
+	mods++
+	assert.PanicsWithValue(t, ConcurrentModification{}, func() { it.HasNext() })
+
+	contract.SetLevel(contract.LevelFull)
+	counter := contract.NewCounter()
+	contract.WithHandler(counter.Handle, func() {
+		assert.PanicsWithValue(t, ConcurrentModification{}, func() { it.HasNext() })
+	})
+	assert.Zero(t, counter.Total())
+}
@@ -4,3 +4,10 @@ type Iterator[T any] interface {
 	HasNext() bool
 	Next() T
 }
+
+// Seq is a push style iterator calling yield with every element until yield returns false,
+// it has the shape of iter.Seq so it can be ranged over from Go 1.23 on
+type Seq[T any] func(yield func(T) bool)
+
+// Seq2 is a push style iterator over pairs, it has the shape of iter.Seq2
+type Seq2[K any, V any] func(yield func(K, V) bool)
@@ -0,0 +1,103 @@
+package iterator
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+)
+
+func Reduce[T any, A any](it Iterator[T], init A, f func(A, T) A) (result A) {
+	contract.Require(it != nil && f != nil, "it and f are not nil")
+
+	result = init
+	for it.HasNext() {
+		result = f(result, it.Next())
+	}
+
+	return
+}
+
+func Collect[T any](it Iterator[T]) (result []T) {
+	contract.Require(it != nil, "it is not nil")
+
+	for it.HasNext() {
+		result = append(result, it.Next())
+	}
+
+	return
+}
+
+// Adder is implemented by containers that elements can be collected into, e.g. linked.List or set.HashSet
+type Adder[T any] interface {
+	Add(x T)
+}
+
+// CollectInto adds every element of it to c and returns c,
+// note that linked.List adds at its head so the list ends up reversed
+func CollectInto[T any, C Adder[T]](it Iterator[T], c C) C {
+	contract.Require(it != nil, "it is not nil")
+
+	for it.HasNext() {
+		c.Add(it.Next())
+	}
+
+	return c
+}
+
+func Count[T any](it Iterator[T]) (result int) {
+	contract.Require(it != nil, "it is not nil")
+	defer func() {
+		contract.Ensure(0 <= result, "result is non-negative")
+	}()
+
+	for ; it.HasNext(); it.Next() {
+		result++
+	}
+
+	return
+}
+
+// Any stops at the first element satisfying pred
+func Any[T any](it Iterator[T], pred func(T) bool) bool {
+	contract.Require(it != nil && pred != nil, "it and pred are not nil")
+
+	for it.HasNext() {
+		if pred(it.Next()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All stops at the first element not satisfying pred
+func All[T any](it Iterator[T], pred func(T) bool) bool {
+	contract.Require(it != nil && pred != nil, "it and pred are not nil")
+
+	return !Any(it, func(x T) bool { return !pred(x) })
+}
+
+// Min returns the first of the smallest elements, ok is false when it is empty
+func Min[T any](it Iterator[T], comp order.CompareFn[T]) (result T, ok bool) {
+	contract.Require(it != nil && comp != nil, "it and comp are not nil")
+
+	for it.HasNext() {
+		if x := it.Next(); !ok || comp(x, result) < 0 {
+			result, ok = x, true
+		}
+	}
+
+	return
+}
+
+// Max returns the first of the largest elements, ok is false when it is empty
+func Max[T any](it Iterator[T], comp order.CompareFn[T]) (result T, ok bool) {
+	contract.Require(it != nil && comp != nil, "it and comp are not nil")
+
+	for it.HasNext() {
+		if x := it.Next(); !ok || comp(x, result) > 0 {
+			result, ok = x, true
+		}
+	}
+
+	return
+}
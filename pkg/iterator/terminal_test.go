@@ -0,0 +1,60 @@
+package iterator
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type bag[T any] struct {
+	elements []T
+}
+
+func (b *bag[T]) Add(x T) {
+	b.elements = append(b.elements, x)
+}
+
+func TestReduceCount(t *testing.T) {
+	sum := Reduce(FromSlice([]int{1, 2, 3}), 0, func(acc, x int) int { return acc + x })
+	assert.Equal(t, 6, sum)
+
+	assert.Equal(t, 3, Count(FromSlice([]int{1, 2, 3})))
+	assert.Equal(t, 0, Count(FromSlice([]int{})))
+}
+
+func TestCollectInto(t *testing.T) {
+	b := CollectInto[int](FromSlice([]int{1, 2}), &bag[int]{})
+	assert.Equal(t, []int{1, 2}, b.elements)
+}
+
+func TestAnyAll(t *testing.T) {
+	isEven := func(x int) bool { return x%2 == 0 }
+
+	assert.True(t, Any(FromSlice([]int{1, 2}), isEven))
+	assert.False(t, Any(FromSlice([]int{1, 3}), isEven))
+	assert.False(t, Any(FromSlice([]int{}), isEven))
+
+	assert.True(t, All(FromSlice([]int{2, 4}), isEven))
+	assert.False(t, All(FromSlice([]int{2, 3}), isEven))
+	assert.True(t, All(FromSlice([]int{}), isEven))
+}
+
+func TestMinMax(t *testing.T) {
+	min, ok := Min(FromSlice([]int{3, 1, 2}), order.IntComp)
+	assert.True(t, ok)
+	assert.Equal(t, 1, min)
+
+	max, ok := Max(FromSlice([]int{3, 1, 2}), order.IntComp)
+	assert.True(t, ok)
+	assert.Equal(t, 3, max)
+
+	_, ok = Min(FromSlice([]int{}), order.IntComp)
+	assert.False(t, ok)
+
+	words := [][]string{{"b"}, {"a", "z"}, {"a"}}
+	least, ok := Min(FromSlice(words), order.Lexicographic(order.StringComp))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a"}, least)
+	greatest, _ := Max(FromSlice(words), order.Lexicographic(order.StringComp))
+	assert.Equal(t, []string{"b"}, greatest)
+}
@@ -1,11 +1,42 @@
 package order
 
-import "golang.org/x/exp/constraints"
+import (
+	"golang.org/x/exp/constraints"
+	"reflect"
+	"sync"
+)
 
-type CompareFn[T comparable] func(x, y T) int
+// CompareFn returns a negative number when x < y, zero when x == y and a positive number when x > y
+type CompareFn[T any] func(x, y T) int
 
-func NaturalOrder[T constraints.Ordered]() CompareFn[T] {
-	return func(x, y T) int {
+// Ordering is a CompareFn that can be told apart from others, as func values cannot be compared.
+// Copies of an Ordering are the same, and so are the Natural orderings of a type and the OrderingOf its natural order,
+// but Orderings made by separate NewOrdering calls are not, even over the same CompareFn.
+type Ordering[T any] struct {
+	Compare CompareFn[T]
+	id      *orderingID
+}
+
+type orderingID struct {
+	_ byte // pointers to distinct zero-size values may be equal
+}
+
+// NewOrdering returns a new Ordering by comp, unlike any other
+func NewOrdering[T any](comp CompareFn[T]) Ordering[T] {
+	return Ordering[T]{Compare: comp, id: &orderingID{}}
+}
+
+// naturals holds the Natural ordering of each type
+var naturals sync.Map
+
+// Natural returns the natural Ordering of T, the same one on every call
+func Natural[T constraints.Ordered]() Ordering[T] {
+	key := reflect.TypeOf((*T)(nil)).Elem()
+	if natural, ok := naturals.Load(key); ok {
+		return natural.(Ordering[T])
+	}
+
+	natural, _ := naturals.LoadOrStore(key, NewOrdering(func(x, y T) int {
 		switch {
 		case x < y:
 			return -1
@@ -14,7 +45,32 @@ func NaturalOrder[T constraints.Ordered]() CompareFn[T] {
 		default:
 			return 0
 		}
+	}))
+	return natural.(Ordering[T])
+}
+
+// OrderingOf returns the Natural ordering of T when comp is made by NaturalOrder, like IntComp and StringComp,
+// so that containers made by the same built-in comparator share their Ordering, and a NewOrdering by comp otherwise.
+// The code pointer of a func value does not tell closures over other variables apart,
+// which does not matter for the natural order, as it captures none.
+func OrderingOf[T any](comp CompareFn[T]) Ordering[T] {
+	if natural, ok := naturals.Load(reflect.TypeOf((*T)(nil)).Elem()); ok {
+		if natural := natural.(Ordering[T]); reflect.ValueOf(natural.Compare).Pointer() == reflect.ValueOf(comp).Pointer() {
+			return natural
+		}
 	}
+
+	return NewOrdering(comp)
+}
+
+// Same reports whether x and y are the same Ordering, so that they certainly order alike
+func Same[T any](x, y Ordering[T]) bool {
+	return x.id != nil && x.id == y.id
+}
+
+// NaturalOrder compares with < and >, it is the Compare of the Natural ordering of T
+func NaturalOrder[T constraints.Ordered]() CompareFn[T] {
+	return Natural[T]().Compare
 }
 
 var IntComp = NaturalOrder[int]()
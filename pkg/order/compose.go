@@ -0,0 +1,87 @@
+package order
+
+import "github.com/song-flying/GoDataStructures/pkg/contract"
+
+// Reverse orders the other way round than comp
+func Reverse[T any](comp CompareFn[T]) CompareFn[T] {
+	contract.Require(comp != nil, "comparison function is not nil")
+
+	return func(x, y T) int {
+		return comp(y, x)
+	}
+}
+
+// ThenComparing orders by comp, breaking ties by each of thens in turn
+func ThenComparing[T any](comp CompareFn[T], thens ...CompareFn[T]) CompareFn[T] {
+	contract.Require(comp != nil, "comparison function is not nil")
+	for _, then := range thens {
+		contract.Require(then != nil, "tie-breaking comparison function is not nil")
+	}
+
+	return func(x, y T) int {
+		if result := comp(x, y); result != 0 {
+			return result
+		}
+		for _, then := range thens {
+			if result := then(x, y); result != 0 {
+				return result
+			}
+		}
+		return 0
+	}
+}
+
+// ByKey orders by the keys extract returns
+func ByKey[T any, K any](extract func(T) K, comp CompareFn[K]) CompareFn[T] {
+	contract.Require(extract != nil, "extract is not nil")
+	contract.Require(comp != nil, "comparison function is not nil")
+
+	return func(x, y T) int {
+		return comp(extract(x), extract(y))
+	}
+}
+
+// NilsFirst orders pointers by what they point to, nil before anything else
+func NilsFirst[T any](comp CompareFn[T]) CompareFn[*T] {
+	contract.Require(comp != nil, "comparison function is not nil")
+
+	return func(x, y *T) int {
+		switch {
+		case x == nil && y == nil:
+			return 0
+		case x == nil:
+			return -1
+		case y == nil:
+			return 1
+		default:
+			return comp(*x, *y)
+		}
+	}
+}
+
+// NilsLast orders pointers by what they point to, nil after anything else
+func NilsLast[T any](comp CompareFn[T]) CompareFn[*T] {
+	contract.Require(comp != nil, "comparison function is not nil")
+
+	nilsFirst := NilsFirst(comp)
+	return func(x, y *T) int {
+		if (x == nil) != (y == nil) {
+			return -nilsFirst(x, y)
+		}
+		return nilsFirst(x, y)
+	}
+}
+
+// Lexicographic orders slices by their first differing elements, a proper prefix comes first
+func Lexicographic[T any](comp CompareFn[T]) CompareFn[[]T] {
+	contract.Require(comp != nil, "comparison function is not nil")
+
+	return func(x, y []T) int {
+		for i := 0; i < len(x) && i < len(y); i++ {
+			if result := comp(x[i], y[i]); result != 0 {
+				return result
+			}
+		}
+		return IntComp(len(x), len(y))
+	}
+}
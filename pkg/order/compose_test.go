@@ -0,0 +1,68 @@
+package order
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func TestReverse(t *testing.T) {
+	comp := Reverse(IntComp)
+	assert.Positive(t, comp(1, 2))
+	assert.Negative(t, comp(2, 1))
+	assert.Zero(t, comp(1, 1))
+}
+
+func TestSame(t *testing.T) {
+	reversed := NewOrdering(Reverse(IntComp))
+	assert.True(t, Same(reversed, reversed))
+	assert.True(t, Same(Natural[int](), Natural[int]()))
+	assert.False(t, Same(Natural[int](), reversed))
+	assert.False(t, Same(NewOrdering(IntComp), NewOrdering(IntComp)))
+	assert.False(t, Same(Ordering[int]{Compare: IntComp}, Ordering[int]{Compare: IntComp}))
+	assert.Negative(t, Natural[string]().Compare("a", "b"))
+
+	assert.True(t, Same(OrderingOf(IntComp), Natural[int]()), "built-in comparators share the Natural ordering")
+	assert.True(t, Same(OrderingOf(NaturalOrder[float64]()), OrderingOf(NaturalOrder[float64]())))
+	assert.False(t, Same(OrderingOf(Reverse(IntComp)), OrderingOf(Reverse(IntComp))))
+	assert.False(t, Same(OrderingOf(CaseInsensitiveComp), OrderingOf(CaseInsensitiveComp)))
+}
+
+func TestThenComparingByKey(t *testing.T) {
+	byAge := ByKey(func(p person) int { return p.age }, IntComp)
+	byName := ByKey(func(p person) string { return p.name }, StringComp)
+	comp := ThenComparing(byAge, Reverse(byName))
+
+	assert.Negative(t, comp(person{"bob", 30}, person{"alice", 40}))
+	assert.Negative(t, comp(person{"bob", 30}, person{"alice", 30}))
+	assert.Zero(t, comp(person{"bob", 30}, person{"bob", 30}))
+	assert.Zero(t, ThenComparing(byAge)(person{"bob", 30}, person{"alice", 30}))
+}
+
+func TestNilsFirstLast(t *testing.T) {
+	one, two := 1, 2
+
+	nilsFirst := NilsFirst(IntComp)
+	assert.Negative(t, nilsFirst(nil, &one))
+	assert.Positive(t, nilsFirst(&one, nil))
+	assert.Zero(t, nilsFirst(nil, nil))
+	assert.Negative(t, nilsFirst(&one, &two))
+
+	nilsLast := NilsLast(IntComp)
+	assert.Positive(t, nilsLast(nil, &one))
+	assert.Negative(t, nilsLast(&one, nil))
+	assert.Zero(t, nilsLast(nil, nil))
+	assert.Negative(t, nilsLast(&one, &two))
+}
+
+func TestLexicographic(t *testing.T) {
+	comp := Lexicographic(IntComp)
+	assert.Negative(t, comp([]int{1, 2}, []int{1, 3}))
+	assert.Negative(t, comp([]int{1, 2}, []int{1, 2, 0}))
+	assert.Positive(t, comp([]int{2}, []int{1, 9, 9}))
+	assert.Zero(t, comp(nil, []int{}))
+}
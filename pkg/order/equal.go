@@ -0,0 +1,20 @@
+package order
+
+import "github.com/song-flying/GoDataStructures/pkg/contract"
+
+// Equaler tells whether x and y are to be treated as the same, it must be an equivalence relation
+type Equaler[T any] func(x, y T) bool
+
+// Equal is the Equaler of ==
+func Equal[T comparable](x, y T) bool {
+	return x == y
+}
+
+// Equivalent treats x and y as the same when comp orders neither before the other
+func Equivalent[T any](comp CompareFn[T]) Equaler[T] {
+	contract.Require(comp != nil, "comparison function is not nil")
+
+	return func(x, y T) bool {
+		return comp(x, y) == 0
+	}
+}
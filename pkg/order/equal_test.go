@@ -0,0 +1,15 @@
+package order
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	assert.True(t, Equal(1, 1))
+	assert.False(t, Equal("a", "A"))
+
+	eq := Equivalent(CaseInsensitiveComp)
+	assert.True(t, eq("Go", "gO"))
+	assert.False(t, eq("Go", "Rust"))
+}
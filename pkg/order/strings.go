@@ -0,0 +1,48 @@
+package order
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CaseInsensitiveComp orders strings rune by rune under Unicode simple case folding, without allocating
+var CaseInsensitiveComp CompareFn[string] = compareFold
+
+func compareFold(x, y string) int {
+	for x != "" && y != "" {
+		rx, nx := utf8.DecodeRuneInString(x)
+		ry, ny := utf8.DecodeRuneInString(y)
+		if result := IntComp(int(FoldRune(rx)), int(FoldRune(ry))); result != 0 {
+			return result
+		}
+		x, y = x[nx:], y[ny:]
+	}
+
+	return IntComp(len(x), len(y))
+}
+
+// FoldRune maps all runes of one case folding orbit to the smallest one, e.g. 'K', 'k' and the Kelvin sign to 'K'
+func FoldRune(r rune) rune {
+	smallest := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < smallest {
+			smallest = f
+		}
+	}
+
+	return smallest
+}
+
+// Collator compares strings by the rules of some language,
+// it is satisfied e.g. by *collate.Collator of golang.org/x/text
+type Collator interface {
+	CompareString(a, b string) int
+}
+
+// Collation orders strings as c does
+func Collation(c Collator) CompareFn[string] {
+	contract.Require(c != nil, "collator is not nil")
+
+	return c.CompareString
+}
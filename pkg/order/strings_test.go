@@ -0,0 +1,27 @@
+package order
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCaseInsensitiveComp(t *testing.T) {
+	assert.Zero(t, CaseInsensitiveComp("Hello", "hELLO"))
+	assert.Zero(t, CaseInsensitiveComp("k", "K")) // Kelvin sign
+	assert.Negative(t, CaseInsensitiveComp("apple", "Banana"))
+	assert.Positive(t, CaseInsensitiveComp("apples", "APPLE"))
+}
+
+// reverseCollator stands in for a language specific collator
+type reverseCollator struct{}
+
+func (reverseCollator) CompareString(a, b string) int {
+	return strings.Compare(b, a)
+}
+
+func TestCollation(t *testing.T) {
+	comp := Collation(reverseCollator{})
+	assert.Positive(t, comp("a", "b"))
+	assert.Zero(t, comp("a", "a"))
+}
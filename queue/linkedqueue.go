@@ -3,11 +3,13 @@ package queue
 import (
 	"github.com/song-flying/GoDataStructures/linked"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
 )
 
-type LinkedQueue[T comparable] struct {
-	front *linked.Node[T]
-	back  *linked.Node[T]
+type LinkedQueue[T any] struct {
+	front    *linked.Node[T]
+	back     *linked.Node[T]
+	modCount int
 }
 
 // IsLinkedQueue data structure invariant
@@ -15,9 +17,9 @@ func (q *LinkedQueue[T]) IsLinkedQueue() bool {
 	return q != nil && !linked.HasCycle(q.front) && linked.IsSegment(q.front, q.back)
 }
 
-func NewLinkedQueue[T comparable]() (result *LinkedQueue[T]) {
+func NewLinkedQueue[T any]() (result *LinkedQueue[T]) {
 	defer func() {
-		contract.Ensure(result.IsLinkedQueue(), "queue invariant holds")
+		contract.EnsureInvariant(result.IsLinkedQueue, "queue invariant holds")
 		contract.Ensure(result.IsEmpty(), "new queue is empty")
 	}()
 
@@ -29,39 +31,110 @@ func NewLinkedQueue[T comparable]() (result *LinkedQueue[T]) {
 }
 
 func (q *LinkedQueue[T]) IsEmpty() bool {
-	contract.Require(q.IsLinkedQueue(), "queue invariant holds")
+	contract.RequireInvariant(q.IsLinkedQueue, "queue invariant holds")
 
 	return q.front == q.back
 }
 
 func (q *LinkedQueue[T]) Enqueue(x T) {
-	contract.Require(q.IsLinkedQueue(), "queue invariant holds")
+	contract.RequireInvariant(q.IsLinkedQueue, "queue invariant holds")
 	defer func() {
-		contract.Ensure(q.IsLinkedQueue(), "queue invariant holds")
+		contract.EnsureInvariant(q.IsLinkedQueue, "queue invariant holds")
 	}()
 
 	dummy := linked.NewDummyNode[T]()
 	q.back.Data = x
 	q.back.Next = &dummy
 	q.back = q.back.Next
+	q.modCount++
 }
 
 func (q *LinkedQueue[T]) Dequeue() (result T) {
-	contract.Require(q.IsLinkedQueue(), "queue invariant holds")
+	contract.RequireInvariant(q.IsLinkedQueue, "queue invariant holds")
 	contract.Require(!q.IsEmpty(), "queue is not empty")
 	defer func() {
-		contract.Ensure(q.IsLinkedQueue(), "queue invariant holds")
+		contract.EnsureInvariant(q.IsLinkedQueue, "queue invariant holds")
 	}()
 
 	result = q.front.Data
 	q.front = q.front.Next
+	q.modCount++
 
 	return
 }
 
 func (q *LinkedQueue[T]) Head() T {
-	contract.Require(q.IsLinkedQueue(), "queue invariant holds")
+	contract.RequireInvariant(q.IsLinkedQueue, "queue invariant holds")
 	contract.Require(!q.IsEmpty(), "queue is not empty")
 
 	return q.front.Data
 }
+
+// linkedQueueIterator walks the elements from front to back, it fails fast when the queue is modified other than by Remove
+type linkedQueueIterator[T any] struct {
+	q        *LinkedQueue[T]
+	prev     *linked.Node[T]
+	last     *linked.Node[T]
+	curr     *linked.Node[T]
+	modCount int
+}
+
+func (it *linkedQueueIterator[T]) HasNext() bool {
+	iterator.CheckMods(it.q.modCount, it.modCount)
+
+	return it.curr != it.q.back
+}
+
+func (it *linkedQueueIterator[T]) Next() (result T) {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	if it.last != nil {
+		it.prev = it.last
+	}
+	it.last = it.curr
+	it.curr = it.curr.Next
+
+	return it.last.Data
+}
+
+// Remove unlinks the node last returned by Next
+func (it *linkedQueueIterator[T]) Remove() {
+	iterator.CheckMods(it.q.modCount, it.modCount)
+	contract.Require(it.last != nil, "Next is called after the last Remove")
+	defer func() {
+		contract.EnsureInvariant(it.q.IsLinkedQueue, "queue invariant holds")
+	}()
+
+	if it.prev == nil {
+		it.q.front = it.curr
+	} else {
+		it.prev.Next = it.curr
+	}
+	it.last.Next = nil
+	it.last = nil
+
+	it.q.modCount++
+	it.modCount = it.q.modCount
+}
+
+func (q *LinkedQueue[T]) mods() int {
+	return q.modCount
+}
+
+// Iterator walks the elements from front to back
+func (q *LinkedQueue[T]) Iterator() iterator.MutableIterator[T] {
+	contract.RequireInvariant(q.IsLinkedQueue, "queue invariant holds")
+
+	return &linkedQueueIterator[T]{
+		q:        q,
+		curr:     q.front,
+		modCount: q.modCount,
+	}
+}
+
+// All walks the elements from front to back
+func (q *LinkedQueue[T]) All() iterator.Seq[T] {
+	contract.RequireInvariant(q.IsLinkedQueue, "queue invariant holds")
+
+	return iterator.FailFastSeq(linked.AllSegment(q.front, q.back), q.mods)
+}
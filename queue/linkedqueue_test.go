@@ -1,6 +1,7 @@
 package queue
 
 import (
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -26,3 +27,51 @@ func TestLinkedQueue(t *testing.T) {
 	assert.True(t, q.IsEmpty())
 	assert.Equal(t, 2, v)
 }
+
+func TestLinkedQueue_Iterator(t *testing.T) {
+	var q Queue[int] = NewLinkedQueue[int]()
+	assert.False(t, q.Iterator().HasNext())
+
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Enqueue(3)
+
+	var a []int
+	for it := q.Iterator(); it.HasNext(); {
+		a = append(a, it.Next())
+	}
+	assert.Equal(t, []int{1, 2, 3}, a)
+
+	var b []int
+	q.All()(func(x int) bool {
+		b = append(b, x)
+		return x < 2
+	})
+	assert.Equal(t, []int{1, 2}, b)
+}
+
+func TestLinkedQueue_IteratorRemove(t *testing.T) {
+	q := NewLinkedQueue[int]()
+	for i := 1; i <= 6; i++ {
+		q.Enqueue(i)
+	}
+
+	for it := q.Iterator(); it.HasNext(); {
+		if it.Next()%2 == 1 {
+			it.Remove()
+		}
+	}
+	assert.Equal(t, []int{2, 4, 6}, iterator.Collect[int](q.Iterator()))
+
+	it := q.Iterator()
+	for it.HasNext() {
+		it.Next()
+	}
+	it.Remove()
+	q.Enqueue(8)
+	assert.Equal(t, []int{2, 4, 8}, iterator.Collect[int](q.Iterator()))
+
+	it = q.Iterator()
+	q.Dequeue()
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.HasNext() })
+}
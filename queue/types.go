@@ -1,8 +1,12 @@
 package queue
 
+import "github.com/song-flying/GoDataStructures/pkg/iterator"
+
 type Queue[T any] interface {
 	IsEmpty() bool
 	Enqueue(x T)
 	Dequeue() T
 	Head() T
+	Iterator() iterator.MutableIterator[T]
+	All() iterator.Seq[T]
 }
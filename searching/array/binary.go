@@ -7,9 +7,10 @@ import (
 )
 
 func BinarySearch[T comparable](x T, a []T, comp order.CompareFn[T]) (result int) {
-	contract.Require(array.IsRangeSorted(a, 0, len(a), comp), "a is sorted")
+	contract.RequireInvariant(func() bool { return array.IsRangeSorted(a, 0, len(a), comp) }, "a is sorted")
 	defer func() {
-		contract.Ensure(!array.IsIn(x, a, 0, len(a)) && result == -1 || 0 <= result && result < len(a) && x == a[result], "result is OK")
+		contract.Ensure(result == -1 || 0 <= result && result < len(a) && x == a[result], "result is OK")
+		contract.EnsureInvariant(func() bool { return result != -1 || !array.IsIn(x, a, 0, len(a)) }, "result is -1 only if x is not in a")
 	}()
 
 	low := 0
@@ -36,3 +37,45 @@ func BinarySearch[T comparable](x T, a []T, comp order.CompareFn[T]) (result int
 
 	return -1
 }
+
+// BinarySearchPosition returns the index of the first element of a not less than x, i.e. where x is or would be inserted,
+// found reports whether that element equals x under comp
+func BinarySearchPosition[T any](x T, a []T, comp order.CompareFn[T]) (result int, found bool) {
+	contract.RequireInvariant(func() bool { return array.IsRangeSorted(a, 0, len(a), comp) }, "a is sorted")
+	if contract.Enabled(contract.LevelPost) {
+		defer func() {
+			contract.Ensure(0 <= result && result <= len(a), "result is within bound")
+			contract.EnsureInvariant(func() bool {
+				return (result == 0 || comp(a[result-1], x) < 0) && (result == len(a) || comp(a[result], x) >= 0)
+			}, "a[0, result) is less than x, and a[result, len(a)) is not")
+			contract.EnsureInvariant(func() bool {
+				return found == (result < len(a) && comp(a[result], x) == 0)
+			}, "found iff a[result] equals x")
+		}()
+	}
+
+	low := 0
+	high := len(a)
+
+	loopInv := func(low, high int) bool {
+		if !contract.Enabled(contract.LevelFull) {
+			return true
+		}
+		contract.Invariant(0 <= low && low <= high && high <= len(a), "low and high are within bound")
+		contract.Invariant(low == 0 || comp(a[low-1], x) < 0, "x is larger than any element from a[0, low)")
+		contract.Invariant(high == len(a) || comp(a[high], x) >= 0, "x is not larger than any element from a[high,len(a))")
+		return true
+	}
+	for loopInv(low, high) && low < high {
+		mid := low + (high-low)/2
+		contract.Assert(low <= mid && mid < high, "mid is within [low, high)")
+
+		if comp(a[mid], x) < 0 {
+			low = mid + 1
+		} else { // a[mid] >= x
+			high = mid
+		}
+	}
+
+	return low, low < len(a) && comp(a[low], x) == 0
+}
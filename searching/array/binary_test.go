@@ -1,8 +1,10 @@
 package array
 
 import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
 	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -26,3 +28,41 @@ func TestBinarySearch(t *testing.T) {
 	i = BinarySearch(1, a, order.IntComp)
 	assert.Equal(t, -1, i)
 }
+
+func TestBinarySearch_SortednessIsCheckedAtLevelFull(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPost))
+	a := []int{7, 1, 5, 3}
+
+	assert.NotPanics(t, func() { BinarySearch(5, a, order.IntComp) }, "sortedness is O(n), so it is not checked below LevelFull")
+
+	contract.SetLevel(contract.LevelFull)
+	assert.Panics(t, func() { BinarySearch(5, a, order.IntComp) })
+}
+
+func TestBinarySearchPosition(t *testing.T) {
+	a := []int{1, 3, 5, 7}
+
+	i, found := BinarySearchPosition(5, a, order.IntComp)
+	assert.Equal(t, 2, i)
+	assert.True(t, found)
+
+	i, found = BinarySearchPosition(4, a, order.IntComp)
+	assert.Equal(t, 2, i)
+	assert.False(t, found)
+
+	i, found = BinarySearchPosition(0, a, order.IntComp)
+	assert.Equal(t, 0, i)
+	assert.False(t, found)
+
+	i, found = BinarySearchPosition(8, a, order.IntComp)
+	assert.Equal(t, 4, i)
+	assert.False(t, found)
+
+	i, found = BinarySearchPosition(1, []int{}, order.IntComp)
+	assert.Equal(t, 0, i)
+	assert.False(t, found)
+
+	i, found = BinarySearchPosition("b", []string{"A", "B", "C"}, func(x, y string) int { return order.StringComp(strings.ToUpper(x), strings.ToUpper(y)) })
+	assert.Equal(t, 1, i)
+	assert.True(t, found)
+}
@@ -7,9 +7,10 @@ import (
 )
 
 func LinearSortedSearch[T comparable](x T, a []T, comp order.CompareFn[T]) (result int) {
-	contract.Require(array.IsRangeSorted(a, 0, len(a), comp), "a is sorted")
+	contract.RequireInvariant(func() bool { return array.IsRangeSorted(a, 0, len(a), comp) }, "a is sorted")
 	defer func() {
-		contract.Ensure(0 <= result && result < len(a) && x == a[result] || !array.IsIn(x, a, 0, len(a)) && result == -1, "result OK")
+		contract.Ensure(0 <= result && result < len(a) && x == a[result] || result == -1, "result OK")
+		contract.EnsureInvariant(func() bool { return result != -1 || !array.IsIn(x, a, 0, len(a)) }, "result is -1 only if x is not in a")
 	}()
 
 	loopInv := func(i int) bool {
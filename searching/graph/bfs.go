@@ -3,23 +3,22 @@ package graph
 import (
 	"github.com/song-flying/GoDataStructures/graph"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
-	"github.com/song-flying/GoDataStructures/pkg/hash"
 	"github.com/song-flying/GoDataStructures/queue"
 	"github.com/song-flying/GoDataStructures/set"
 )
 
-func BreathFirstSearch[V comparable](g *graph.UndirectedGraph[V], src V, dst V) bool {
+func BreathFirstSearch[V any](g *graph.UndirectedGraph[V], src V, dst V) bool {
 	contract.Require(g != nil, "g is not nil")
 	contract.Require(g.Contains(src) && g.Contains(dst), "g contains src and dst")
 
-	marked := set.NewHashSet[V](g.Size(), hash.Universal[V], 1)
+	marked := set.NewHashSetFunc(g.Size(), g.VertexHasher(), g.VertexEqualer(), 1)
 	q := queue.NewLinkedQueue[V]()
 	marked.Add(src)
 	q.Enqueue(src)
 
 	for !q.IsEmpty() {
 		x := q.Dequeue()
-		if x == dst {
+		if g.VertexEqualer()(x, dst) {
 			return true
 		}
 
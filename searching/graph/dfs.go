@@ -5,20 +5,19 @@ import (
 	"github.com/song-flying/GoDataStructures/graph"
 	"github.com/song-flying/GoDataStructures/linked"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
-	"github.com/song-flying/GoDataStructures/pkg/hash"
 	"github.com/song-flying/GoDataStructures/set"
 	"github.com/song-flying/GoDataStructures/stack"
 )
 
-func DepthFirstSearchR[V comparable](g *graph.UndirectedGraph[V], src V, dst V) bool {
+func DepthFirstSearchR[V any](g *graph.UndirectedGraph[V], src V, dst V) bool {
 	contract.Require(g != nil, "g is not nil")
 	contract.Require(g.Contains(src) && g.Contains(dst), "g contains src and dst")
 
-	marked := set.NewHashSet[V](g.Size(), hash.Universal[V], 1)
+	marked := set.NewHashSetFunc(g.Size(), g.VertexHasher(), g.VertexEqualer(), 1)
 	return DepthFirstSearchRHelper[V](g, src, dst, marked)
 }
 
-func Some[T comparable](l *linked.List[T], pred func(T) bool) bool {
+func Some[T any](l *linked.List[T], pred func(T) bool) bool {
 	for curr := l.Head; curr != nil; curr = curr.Next {
 		if pred(curr.Data) {
 			return true
@@ -28,7 +27,7 @@ func Some[T comparable](l *linked.List[T], pred func(T) bool) bool {
 	return false
 }
 
-func DepthFirstSearchRHelper[V comparable](g *graph.UndirectedGraph[V], v V, w V, marked set.Set[V]) bool {
+func DepthFirstSearchRHelper[V any](g *graph.UndirectedGraph[V], v V, w V, marked set.Set[V]) bool {
 	contract.Require(g != nil, "g is not nil")
 	contract.Require(g.Contains(v) && g.Contains(w), "g contains v and w")
 	contract.Require(!marked.Contains(v), "v is not marked")
@@ -39,7 +38,7 @@ func DepthFirstSearchRHelper[V comparable](g *graph.UndirectedGraph[V], v V, w V
 
 	marked.Add(v)
 	fmt.Println(v)
-	if v == w {
+	if g.VertexEqualer()(v, w) {
 		return true
 	}
 
@@ -55,11 +54,11 @@ func DepthFirstSearchRHelper[V comparable](g *graph.UndirectedGraph[V], v V, w V
 }
 
 // DepthFirstSearchX NOT equivalent to DepthFirstSearchR
-func DepthFirstSearchX[V comparable](g *graph.UndirectedGraph[V], src V, dst V) bool {
+func DepthFirstSearchX[V any](g *graph.UndirectedGraph[V], src V, dst V) bool {
 	contract.Require(g != nil, "g is not nil")
 	contract.Require(g.Contains(src) && g.Contains(dst), "g contains src and dst")
 
-	marked := set.NewHashSet[V](g.Size(), hash.Universal[V], 1)
+	marked := set.NewHashSetFunc(g.Size(), g.VertexHasher(), g.VertexEqualer(), 1)
 	s := stack.NewLinkedStack[V]()
 	marked.Add(src)
 	s.Push(src)
@@ -67,7 +66,7 @@ func DepthFirstSearchX[V comparable](g *graph.UndirectedGraph[V], src V, dst V)
 	for !s.IsEmpty() {
 		x := s.Pop()
 		fmt.Println(x)
-		if x == dst {
+		if g.VertexEqualer()(x, dst) {
 			return true
 		}
 
@@ -84,15 +83,15 @@ func DepthFirstSearchX[V comparable](g *graph.UndirectedGraph[V], src V, dst V)
 	return false
 }
 
-func DepthFirstSearch[V comparable](g *graph.UndirectedGraph[V], src V, dst V) bool {
+func DepthFirstSearch[V any](g *graph.UndirectedGraph[V], src V, dst V) bool {
 	contract.Require(g != nil, "g is not nil")
 	contract.Require(g.Contains(src) && g.Contains(dst), "g contains src and dst")
 
-	marked := set.NewHashSet[V](g.Size(), hash.Universal[V], 1)
+	marked := set.NewHashSetFunc(g.Size(), g.VertexHasher(), g.VertexEqualer(), 1)
 	s := stack.NewLinkedStack[*linked.ListIterator[V]]()
 	marked.Add(src)
 	vNode := linked.NewNode[V](src)
-	vList := linked.NewList(&vNode)
+	vList := linked.NewListFunc(&vNode, g.VertexEqualer())
 	s.Push(vList.Iterator())
 
 	for !s.IsEmpty() {
@@ -104,7 +103,7 @@ func DepthFirstSearch[V comparable](g *graph.UndirectedGraph[V], src V, dst V) b
 
 		x := xIter.Next()
 		fmt.Println(x)
-		if x == dst {
+		if g.VertexEqualer()(x, dst) {
 			return true
 		}
 
@@ -117,7 +116,7 @@ func DepthFirstSearch[V comparable](g *graph.UndirectedGraph[V], src V, dst V) b
 				s.Push(neighbors)
 
 				yNode := linked.NewNode[V](y)
-				yList := linked.NewList(&yNode)
+				yList := linked.NewListFunc(&yNode, g.VertexEqualer())
 				s.Push(yList.Iterator())
 				break
 			}
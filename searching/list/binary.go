@@ -7,7 +7,7 @@ import (
 )
 
 func BinarySearch[T constraints.Ordered](x T, l *linked.List[T]) (result int) {
-	contract.Require(l.IsList(), "list invariant holds")
+	contract.RequireInvariant(l.IsList, "list invariant holds")
 
 	if l.IsEmpty() {
 		return -1
@@ -17,12 +17,12 @@ func BinarySearch[T constraints.Ordered](x T, l *linked.List[T]) (result int) {
 }
 
 func BinarySearchSegment[T constraints.Ordered](x T, start, end *linked.Node[T]) (result int) {
-	contract.Require(linked.IsSegmentSorted(start, end), "segment [start,end) is sorted")
+	contract.RequireInvariant(func() bool { return linked.IsSegmentSorted(start, end) }, "segment [start,end) is sorted")
 	defer func() {
-		contract.Ensure(
-			result == -1 && !linked.IsInSegment(x, start, end) ||
-				0 <= result && result < linked.LengthOfSegment(start, end) && linked.IthSegment(start, result) == x,
-			"result is OK")
+		contract.EnsureInvariant(func() bool {
+			return result == -1 && !linked.IsInSegment(x, start, end) ||
+				0 <= result && result < linked.LengthOfSegment(start, end) && linked.IthSegment(start, result) == x
+		}, "result is OK")
 	}()
 
 	low := 0
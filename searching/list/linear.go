@@ -6,7 +6,7 @@ import (
 )
 
 func LinearSearch[T comparable](x T, l linked.List[T]) *linked.Node[T] {
-	contract.Require(l.IsList(), "list invariant holds")
+	contract.RequireInvariant(l.IsList, "list invariant holds")
 
 	for curr := l.Head; curr != nil; curr = curr.Next {
 		if curr.Data == x {
@@ -0,0 +1,159 @@
+package set
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+)
+
+// keepers select the elements of a set operation on a and b by where they occur
+
+func inUnion(inA, inB bool) bool {
+	return inA || inB
+}
+
+func inIntersection(inA, inB bool) bool {
+	return inA && inB
+}
+
+func inDifference(inA, inB bool) bool {
+	return inA && !inB
+}
+
+func inSymmetricDifference(inA, inB bool) bool {
+	return inA != inB
+}
+
+func advance[T any](it iterator.Iterator[T], x *T) bool {
+	if !it.HasNext() {
+		return false
+	}
+	*x = it.Next()
+	return true
+}
+
+// mergeWalk walks the ascending iterators a and b in step, visiting every element once with where it occurs,
+// an element in both is visited as the one of a. It stops as soon as visit returns false and reports whether it did not.
+func mergeWalk[T any](a, b iterator.Iterator[T], comp order.CompareFn[T], visit func(x T, inA, inB bool) bool) bool {
+	contract.Require(a != nil && b != nil && comp != nil && visit != nil, "a, b, comp and visit are not nil")
+
+	var x, y T
+	hasX, hasY := advance(a, &x), advance(b, &y)
+	for hasX || hasY {
+		compResult := 0
+		switch {
+		case !hasY:
+			compResult = -1
+		case !hasX:
+			compResult = 1
+		default:
+			compResult = comp(x, y)
+		}
+
+		switch {
+		case compResult < 0:
+			if !visit(x, true, false) {
+				return false
+			}
+			hasX = advance(a, &x)
+		case compResult > 0:
+			if !visit(y, false, true) {
+				return false
+			}
+			hasY = advance(b, &y)
+		default:
+			if !visit(x, true, true) {
+				return false
+			}
+			hasX, hasY = advance(a, &x), advance(b, &y)
+		}
+	}
+
+	return true
+}
+
+// mergeSorted returns the ascending elements of a and b that keep selects in O(m+n)
+func mergeSorted[T any](a, b iterator.Iterator[T], comp order.CompareFn[T], keep func(inA, inB bool) bool) (result []T) {
+	mergeWalk(a, b, comp, func(x T, inA, inB bool) bool {
+		if keep(inA, inB) {
+			result = append(result, x)
+		}
+		return true
+	})
+
+	return
+}
+
+// noneKeptSorted reports whether keep selects none of the elements of the ascending a and b
+func noneKeptSorted[T any](a, b iterator.Iterator[T], comp order.CompareFn[T], keep func(inA, inB bool) bool) bool {
+	return mergeWalk(a, b, comp, func(_ T, inA, inB bool) bool {
+		return !keep(inA, inB)
+	})
+}
+
+// combine adds the elements of a and b that keep selects to result, looking them up with Contains,
+// an element in both is added as the one of a
+func combine[T any](result, a, b Set[T], keep func(inA, inB bool) bool) Set[T] {
+	contract.Require(result != nil && a != nil && b != nil && keep != nil, "result, a, b and keep are not nil")
+
+	a.All()(func(x T) bool {
+		if keep(true, b.Contains(x)) {
+			result.Add(x)
+		}
+		return true
+	})
+	if keep(false, true) {
+		b.All()(func(x T) bool {
+			if !a.Contains(x) {
+				result.Add(x)
+			}
+			return true
+		})
+	}
+
+	return result
+}
+
+// noneKept reports whether keep selects none of the elements of a and b, looking them up with Contains
+func noneKept[T any](a, b Set[T], keep func(inA, inB bool) bool) bool {
+	contract.Require(a != nil && b != nil && keep != nil, "a, b and keep are not nil")
+
+	for it := a.Iterator(); it.HasNext(); {
+		if keep(true, b.Contains(it.Next())) {
+			return false
+		}
+	}
+	if keep(false, true) {
+		for it := b.Iterator(); it.HasNext(); {
+			if !a.Contains(it.Next()) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func isAscending[T any](it iterator.Iterator[T], comp order.CompareFn[T]) bool {
+	var prev, x T
+	for hasPrev := false; advance(it, &x); hasPrev, prev = true, x {
+		if hasPrev && comp(prev, x) >= 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// orderedSet is a tree or skip list set, which iterates its elements in ascending order
+type orderedSet[E comparable] interface {
+	compare(a, b E) int
+	Ordering() order.Ordering[E]
+}
+
+// mergeable reports whether other is an ordered set ordered by ordering, whose elements are then merged with those of an ordered set ordered by it,
+// other sets are looked up
+func mergeable[E comparable](other Set[E], ordering order.Ordering[E]) bool {
+	ordered, ok := other.(orderedSet[E])
+	return ok && order.Same(ordered.Ordering(), ordering)
+}
@@ -0,0 +1,174 @@
+package set
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var setKinds = map[string]func(xs ...int) Set[int]{
+	"hash": func(xs ...int) Set[int] {
+		return iterator.CollectInto[int](iterator.FromSlice(xs), NewHashSet[int](4, nil, 2))
+	},
+	"bst": func(xs ...int) Set[int] {
+		return iterator.CollectInto[int](iterator.FromSlice(xs), NewBSTSetBy[int](order.Natural[int]()))
+	},
+	"avl": func(xs ...int) Set[int] {
+		return iterator.CollectInto[int](iterator.FromSlice(xs), NewAVLSetBy[int](order.Natural[int]()))
+	},
+	"rb": func(xs ...int) Set[int] {
+		return iterator.CollectInto[int](iterator.FromSlice(xs), NewRBSetBy[int](order.Natural[int]()))
+	},
+	"skiplist": func(xs ...int) Set[int] {
+		return iterator.CollectInto[int](iterator.FromSlice(xs), NewSkipListSetBy[int](order.Natural[int]()))
+	},
+}
+
+func sorted(s Set[int]) []int {
+	result := iterator.Collect[int](s.Iterator())
+	sort.Ints(result)
+	return result
+}
+
+func TestSet_Algebra(t *testing.T) {
+	for aKind, newA := range setKinds {
+		for bKind, newB := range setKinds {
+			t.Run(aKind+"-"+bKind, func(t *testing.T) {
+				a, b := newA(1, 2, 3, 4, 5), newB(4, 5, 6, 7)
+
+				assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, sorted(a.Union(b)))
+				assert.Equal(t, []int{4, 5}, sorted(a.Intersection(b)))
+				assert.Equal(t, []int{1, 2, 3}, sorted(a.Difference(b)))
+				assert.Equal(t, []int{6, 7}, sorted(b.Difference(a)))
+				assert.Equal(t, []int{1, 2, 3, 6, 7}, sorted(a.SymmetricDifference(b)))
+				assert.Equal(t, 5, a.Size(), "operands are left alone")
+				assert.Equal(t, 4, b.Size(), "operands are left alone")
+
+				assert.False(t, a.IsSubsetOf(b))
+				assert.True(t, a.Intersection(b).IsSubsetOf(b))
+				assert.True(t, newA().IsSubsetOf(b))
+				assert.False(t, a.IsDisjoint(b))
+				assert.True(t, a.Difference(b).IsDisjoint(b))
+				assert.True(t, newA().IsDisjoint(newB()))
+				assert.False(t, a.Equals(b))
+				assert.True(t, a.Equals(newB(5, 4, 3, 2, 1)))
+				assert.False(t, a.Equals(newB(1, 2, 3, 4)))
+				assert.True(t, newA().Equals(newB()))
+
+				union := a.Union(b)
+				union.Add(8)
+				union.Delete(1)
+				assert.Equal(t, []int{2, 3, 4, 5, 6, 7, 8}, sorted(union))
+				assert.True(t, a.Contains(1))
+			})
+		}
+	}
+}
+
+func TestSet_AlgebraKeepsKind(t *testing.T) {
+	avl := setKinds["avl"](1, 2, 3)
+	bst := setKinds["bst"](3, 4)
+	hash := setKinds["hash"](3, 4)
+
+	assert.IsType(t, &AVLSet[int]{}, avl.Union(bst))
+	assert.IsType(t, &AVLSet[int]{}, avl.Intersection(hash))
+	assert.IsType(t, &BSTSet[int]{}, bst.Difference(avl))
+	assert.IsType(t, &HashSet[int]{}, hash.SymmetricDifference(avl))
+	assert.IsType(t, &SkipListSet[int]{}, setKinds["skiplist"](1).Union(avl))
+}
+
+func TestAVLSet_UnionIsBalanced(t *testing.T) {
+	a, b := NewAVLSet[int](order.IntComp), NewAVLSet[int](order.IntComp)
+	for x := 0; x < 64; x++ {
+		if x%2 == 0 {
+			a.Add(x)
+		} else {
+			b.Add(x)
+		}
+	}
+
+	union := a.Union(b).(*AVLSet[int])
+	assert.True(t, union.IsAVLSet())
+	assert.Equal(t, 64, union.Size())
+	assert.Equal(t, 32, union.Select(32))
+	assert.LessOrEqual(t, union.tree.Root.GetHeight(), 7)
+}
+
+func TestSet_AlgebraKeepsReceiverElements(t *testing.T) {
+	caseInsensitive := func(x, y string) int { return strings.Compare(strings.ToLower(x), strings.ToLower(y)) }
+	a, b := NewAVLSet[string](caseInsensitive), NewBSTSet[string](caseInsensitive)
+	a.Add("Go")
+	a.Add("Rust")
+	b.Add("GO")
+	b.Add("zig")
+
+	assert.Equal(t, []string{"Go", "Rust", "zig"}, iterator.Collect[string](a.Union(b).Iterator()))
+	assert.Equal(t, []string{"GO"}, iterator.Collect[string](b.Intersection(a).Iterator()))
+	assert.True(t, a.Intersection(b).Equals(b.Intersection(a)))
+}
+
+func TestSet_AlgebraWithReversedOrder(t *testing.T) {
+	for kind, newSet := range setKinds {
+		a := newSet(1, 2, 3, 4)
+		b := iterator.CollectInto[int](iterator.FromSlice([]int{3, 4, 5, 6}), NewAVLSet[int](order.Reverse(order.IntComp)))
+
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, sorted(a.Union(b)), kind)
+		assert.Equal(t, []int{3, 4}, sorted(a.Intersection(b)), kind)
+		assert.Equal(t, []int{1, 2}, sorted(a.Difference(b)), kind)
+		assert.Equal(t, []int{1, 2, 5, 6}, sorted(a.SymmetricDifference(b)), kind)
+		assert.False(t, a.IsDisjoint(b), kind)
+	}
+}
+
+func TestSet_AlgebraWithOrdersAgreeingOnlyAtFirst(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+	// orders the digits ahead of the larger numbers, which are in reverse
+	digitsFirst := func(x, y int) int {
+		key := func(x int) int {
+			if x < 10 {
+				return x
+			}
+			return 1000 - x
+		}
+		return order.IntComp(key(x), key(y))
+	}
+
+	for kind, newSet := range setKinds {
+		a := newSet(1, 2, 20, 25, 30)
+		b := iterator.CollectInto[int](iterator.FromSlice([]int{1, 2, 20, 30}), NewAVLSet[int](digitsFirst))
+
+		assert.Equal(t, []int{1, 2, 20, 25, 30}, sorted(a.Union(b)), kind)
+		assert.Equal(t, []int{1, 2, 20, 30}, sorted(a.Intersection(b)), kind)
+		assert.Equal(t, []int{25}, sorted(a.Difference(b)), kind)
+		assert.Equal(t, []int{25}, sorted(a.SymmetricDifference(b)), kind)
+		assert.True(t, b.IsSubsetOf(a), kind)
+	}
+}
+
+func TestMergeable(t *testing.T) {
+	natural := NewAVLSetBy[int](order.Natural[int]())
+	assert.True(t, mergeable[int](NewRBSetBy[int](order.Natural[int]()), natural.Ordering()))
+	assert.True(t, mergeable[int](NewSkipListSetBy[int](natural.Ordering()), natural.Ordering()))
+	assert.True(t, mergeable[int](NewBSTSet[int](order.IntComp), natural.Ordering()), "built-in comparators share the natural ordering")
+	assert.True(t, mergeable[int](NewAVLSet[int](order.IntComp), NewSkipListSet[int](order.IntComp).Ordering()))
+	assert.False(t, mergeable[int](NewBSTSet[int](order.Reverse(order.IntComp)), NewAVLSet[int](order.Reverse(order.IntComp)).Ordering()),
+		"orderings made apart by other comparators are told apart")
+	assert.False(t, mergeable[int](NewHashSet[int](4, nil, 2), natural.Ordering()))
+
+	split, _, _ := natural.Split(0)
+	assert.True(t, mergeable[int](split, natural.Ordering()), "the parts keep the ordering")
+}
+
+func TestMergeWalk_StopsEarly(t *testing.T) {
+	visited := 0
+	done := mergeWalk[int](iterator.FromSlice([]int{1, 3, 5}), iterator.FromSlice([]int{2, 3, 4}), order.IntComp, func(x int, inA, inB bool) bool {
+		visited++
+		return !(inA && inB)
+	})
+	assert.False(t, done)
+	assert.Equal(t, 3, visited)
+}
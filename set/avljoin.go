@@ -0,0 +1,252 @@
+package set
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/song-flying/GoDataStructures/tree"
+)
+
+// join links left, mid and right into an AVL tree in O(|height(left) - height(right)| + 1), reusing mid as the node in between,
+// all elements of left must be less than mid and mid less than all of right
+func (t *AVLSet[E]) join(left, mid, right *tree.BinaryNode[E]) (result *tree.BinaryNode[E]) {
+	contract.Require(mid != nil, "mid is not nil")
+	contract.RequireInvariant(func() bool { return t.IsAVL(left) && t.IsAVL(right) }, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(func() bool { return t.IsAVL(result) }, "AVL invariant holds")
+	}()
+
+	switch {
+	case left.GetHeight() > right.GetHeight()+1:
+		left.Right = t.join(left.Right, mid, right) // invariant broken (not balanced)
+		return t.rebalance(left)                    // invariant restored (balanced)
+	case right.GetHeight() > left.GetHeight()+1:
+		right.Left = t.join(left, mid, right.Left) // invariant broken (not balanced)
+		return t.rebalance(right)                  // invariant restored (balanced)
+	default:
+		mid.Left, mid.Right = left, right
+		mid.SetHeight()
+		mid.SetSize()
+		return mid
+	}
+}
+
+// join2 links left and right, all elements of left less than all of right, with the greatest of left in between
+func (t *AVLSet[E]) join2(left, right *tree.BinaryNode[E]) *tree.BinaryNode[E] {
+	if left == nil {
+		return right
+	}
+
+	left, max := t.removeMax(left)
+	mid := tree.NewBinaryNode(max)
+	return t.join(left, &mid, right)
+}
+
+// split cuts the tree rooted at root into the elements less than x, the node holding x if any,
+// and the elements greater than x in O(log n)
+func (t *AVLSet[E]) split(root *tree.BinaryNode[E], x E) (left, mid, right *tree.BinaryNode[E]) {
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
+
+	if root == nil {
+		return nil, nil, nil
+	}
+
+	compResult := t.comp(x, root.Data)
+	switch {
+	case compResult < 0:
+		left, mid, right = t.split(root.Left, x)
+		return left, mid, t.join(right, root, root.Right)
+	case compResult > 0:
+		left, mid, right = t.split(root.Right, x)
+		return t.join(root.Left, root, left), mid, right
+	default:
+		left, right = root.Left, root.Right
+		root.Left, root.Right = nil, nil
+		root.SetHeight()
+		root.SetSize()
+		return left, root, right
+	}
+}
+
+// union links the elements of a and b into one tree in O(m log(n/m + 1)), keeping those of a where both hold equal ones
+func (t *AVLSet[E]) union(a, b *tree.BinaryNode[E]) *tree.BinaryNode[E] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	left, _, right := t.split(b, a.Data)
+	aLeft, aRight := a.Left, a.Right
+	return t.join(t.union(aLeft, left), a, t.union(aRight, right))
+}
+
+// intersect links the elements of a that b holds as well into one tree in O(m log(n/m + 1))
+func (t *AVLSet[E]) intersect(a, b *tree.BinaryNode[E]) *tree.BinaryNode[E] {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	left, mid, right := t.split(b, a.Data)
+	aLeft, aRight := a.Left, a.Right
+	left, right = t.intersect(aLeft, left), t.intersect(aRight, right)
+	if mid == nil {
+		return t.join2(left, right)
+	}
+	return t.join(left, a, right)
+}
+
+func (t *AVLSet[E]) max() *tree.BinaryNode[E] {
+	node := t.tree.Root
+	for node != nil && node.Right != nil {
+		node = node.Right
+	}
+	return node
+}
+
+func (t *AVLSet[E]) min() *tree.BinaryNode[E] {
+	node := t.tree.Root
+	for node != nil && node.Left != nil {
+		node = node.Left
+	}
+	return node
+}
+
+// NewAVLSetFromSorted builds a set of the strictly ascending elements in O(n)
+func NewAVLSetFromSorted[E comparable](comp order.CompareFn[E], elements []E) (result *AVLSet[E]) {
+	contract.Require(comp != nil, "comparison function is not nil")
+	contract.Require(isAscending[E](iterator.FromSlice(elements), comp), "elements are strictly ascending")
+	defer func() {
+		contract.EnsureInvariant(result.IsAVLSet, "AVL invariant holds")
+		contract.Ensure(result.Size() == len(elements), "result holds all elements")
+	}()
+
+	result = NewAVLSet[E](comp)
+	result.tree.Root = tree.NewBalanced(elements)
+	return
+}
+
+// Split moves the elements less than x into left and those greater than x into right in O(log n), leaving t empty,
+// found reports whether t held x, which ends up in neither
+func (t *AVLSet[E]) Split(x E) (left, right *AVLSet[E], found bool) {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(left.IsAVLSet, "AVL invariant holds")
+		contract.EnsureInvariant(right.IsAVLSet, "AVL invariant holds")
+		contract.Ensure(t.IsEmpty(), "t is empty")
+	}()
+
+	left, right = NewAVLSetBy[E](t.ordering), NewAVLSetBy[E](t.ordering)
+	var mid *tree.BinaryNode[E]
+	left.tree.Root, mid, right.tree.Root = t.split(t.tree.Root, x)
+	t.tree.Root = nil
+	t.modCount++
+
+	return left, right, mid != nil
+}
+
+// Join moves the elements of left and right into t in O(log n), replacing those of t,
+// all elements of left must be less than all of right, t may be left or right itself.
+// If left or right is of another ordering than t, see Ordering, their elements are added one by one.
+func (t *AVLSet[E]) Join(left, right *AVLSet[E]) {
+	contract.Require(left != nil && right != nil, "left and right are not nil")
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+	sameOrdering := order.Same(left.ordering, t.ordering) && order.Same(right.ordering, t.ordering)
+	contract.Require(!sameOrdering || left.IsEmpty() || right.IsEmpty() || t.comp(left.max().Data, right.min().Data) < 0, "left < right")
+	defer func() {
+		contract.EnsureInvariant(t.IsAVLSet, "AVL invariant holds")
+	}()
+
+	leftRoot, rightRoot := left.tree.Root, right.tree.Root
+	left.tree.Root, right.tree.Root = nil, nil
+	left.modCount++
+	right.modCount++
+	t.modCount++
+	if !sameOrdering {
+		t.tree.Root = nil
+		for _, root := range []*tree.BinaryNode[E]{leftRoot, rightRoot} {
+			root.All()(func(x E) bool {
+				t.Add(x)
+				return true
+			})
+		}
+		return
+	}
+
+	t.tree.Root = t.join2(leftRoot, rightRoot)
+}
+
+// DeleteRange removes the elements within [lo, hi) in O(log n)
+func (t *AVLSet[E]) DeleteRange(lo, hi E) {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+	contract.Require(t.comp(lo, hi) <= 0, "lo <= hi")
+	defer func() {
+		contract.EnsureInvariant(t.IsAVLSet, "AVL invariant holds")
+		contract.Ensure(!t.Range(lo, hi).HasNext(), "no element is within [lo, hi)")
+	}()
+
+	rest, mid, right := t.split(t.tree.Root, hi)
+	if mid != nil {
+		right = t.join(nil, mid, right)
+	}
+	left, _, _ := t.split(rest, lo)
+	t.tree.Root = t.join2(left, right)
+	t.modCount++
+}
+
+// AddAll moves the elements of other into t as Add would in O(m log(n/m + 1)), where m is the smaller size,
+// leaving other empty. If other is of another ordering, see Ordering, its elements are added one by one.
+func (t *AVLSet[E]) AddAll(other *AVLSet[E]) {
+	contract.Require(other != nil && other != t, "other is neither nil nor t")
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsAVLSet, "AVL invariant holds")
+	}()
+
+	otherRoot := other.tree.Root
+	other.tree.Root = nil
+	other.modCount++
+	if !order.Same(other.ordering, t.ordering) {
+		otherRoot.All()(func(x E) bool {
+			t.Add(x)
+			return true
+		})
+		return
+	}
+	t.tree.Root = t.union(otherRoot, t.tree.Root)
+	t.modCount++
+}
+
+// RetainAll deletes the elements other does not hold in O(m log(n/m + 1)), where m is the smaller size,
+// splitting up other on the way, which is left empty. If other is of another ordering, see Ordering,
+// the elements of t are looked up in other one by one.
+func (t *AVLSet[E]) RetainAll(other *AVLSet[E]) {
+	contract.Require(other != nil && other != t, "other is neither nil nor t")
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsAVLSet, "AVL invariant holds")
+	}()
+
+	if !order.Same(other.ordering, t.ordering) {
+		var missing []E
+		t.tree.Root.All()(func(x E) bool {
+			if !other.Contains(x) {
+				missing = append(missing, x)
+			}
+			return true
+		})
+		other.tree.Root = nil
+		other.modCount++
+		for _, x := range missing {
+			t.Delete(x)
+		}
+		return
+	}
+
+	otherRoot := other.tree.Root
+	other.tree.Root = nil
+	other.modCount++
+	t.tree.Root = t.intersect(t.tree.Root, otherRoot)
+	t.modCount++
+}
@@ -0,0 +1,234 @@
+package set
+
+import (
+	"github.com/song-flying/GoDataStructures/array"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func avlSetOf(xs ...int) *AVLSet[int] {
+	return iterator.CollectInto[int](iterator.FromSlice(xs), NewAVLSet[int](order.IntComp))
+}
+
+func avlSetBy(ordering order.Ordering[int], xs ...int) *AVLSet[int] {
+	return iterator.CollectInto[int](iterator.FromSlice(xs), NewAVLSetBy[int](ordering))
+}
+
+func TestAVLSet_SplitJoin(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+
+	a := make([]int, 40)
+	for i := range a {
+		a[i] = 2 * i
+	}
+	array.Shuffle(a)
+	set := avlSetOf(a...)
+
+	left, right, found := set.Split(30)
+	assert.True(t, found)
+	assert.True(t, set.IsEmpty())
+	assert.Equal(t, 15, left.Size())
+	assert.Equal(t, 24, right.Size())
+	assert.Equal(t, 28, left.Select(14))
+	assert.Equal(t, 32, right.Select(0))
+
+	l, r, found := right.Split(33)
+	assert.False(t, found)
+	assert.Equal(t, []int{32}, iterator.Collect[int](l.Iterator()))
+	assert.Equal(t, 23, r.Size())
+
+	// a lopsided join
+	left.Join(left, l)
+	assert.True(t, left.IsAVLSet())
+	assert.Equal(t, 16, left.Size())
+	set.Join(left, r)
+	assert.True(t, set.IsAVLSet())
+	assert.Equal(t, 39, set.Size())
+	assert.False(t, set.Contains(30))
+	assert.True(t, left.IsEmpty())
+	assert.True(t, r.IsEmpty())
+
+	assert.Panics(t, func() { set.Join(avlSetOf(5), avlSetOf(1)) })
+}
+
+func TestAVLSet_JoinOrderedOtherwise(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPost))
+
+	set, left := avlSetOf(), avlSetBy(order.NewOrdering(order.Reverse(order.IntComp)), 1, 2, 3)
+	set.Join(left, avlSetOf(10))
+	assert.True(t, set.IsAVLSet())
+	assert.Equal(t, []int{1, 2, 3, 10}, iterator.Collect[int](set.Iterator()))
+	assert.True(t, set.Contains(3))
+	assert.True(t, left.IsEmpty(), "left is moved into set")
+
+	set.Join(avlSetOf(20), avlSetBy(order.NewOrdering(order.IntComp), 5))
+	assert.Equal(t, []int{5, 20}, iterator.Collect[int](set.Iterator()), "elements are added one by one, whatever their order")
+}
+
+func TestAVLSet_DeleteRange(t *testing.T) {
+	set := avlSetOf(1, 2, 3, 4, 5, 6, 7, 8, 9)
+
+	set.DeleteRange(3, 7)
+	assert.Equal(t, []int{1, 2, 7, 8, 9}, iterator.Collect[int](set.Iterator()))
+	set.DeleteRange(0, 2)
+	assert.Equal(t, []int{2, 7, 8, 9}, iterator.Collect[int](set.Iterator()))
+	set.DeleteRange(8, 8)
+	assert.Equal(t, 4, set.Size())
+	set.DeleteRange(5, 100)
+	assert.Equal(t, []int{2}, iterator.Collect[int](set.Iterator()))
+	assert.True(t, set.IsAVLSet())
+}
+
+func TestAVLSet_AddAllRetainAll(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+
+	evens, threes := NewAVLSet[int](order.IntComp), NewAVLSet[int](order.IntComp)
+	for x := 0; x < 30; x++ {
+		if x%2 == 0 {
+			evens.Add(x)
+		}
+		if x%3 == 0 {
+			threes.Add(x)
+		}
+	}
+
+	threeElements := iterator.Collect[int](threes.Iterator())
+
+	union := avlSetOf(evens.Select(0))
+	union.AddAll(avlSetOf(iterator.Collect[int](evens.Iterator())...))
+	union.AddAll(avlSetOf(threeElements...))
+	assert.True(t, union.IsAVLSet())
+	assert.Equal(t, 20, union.Size())
+
+	union.RetainAll(avlSetOf(threeElements...))
+	assert.True(t, union.IsAVLSet())
+	assert.Equal(t, threeElements, iterator.Collect[int](union.Iterator()))
+
+	evens.RetainAll(threes)
+	assert.Equal(t, []int{0, 6, 12, 18, 24}, iterator.Collect[int](evens.Iterator()))
+	assert.True(t, threes.IsEmpty(), "other is split up")
+	evens.RetainAll(NewAVLSet[int](order.IntComp))
+	assert.True(t, evens.IsEmpty())
+
+	other := avlSetOf(1, 2)
+	it := other.Iterator()
+	union.AddAll(other)
+	assert.True(t, other.IsEmpty(), "other is moved into union")
+	assert.Panics(t, func() { it.HasNext() })
+	assert.Panics(t, func() { union.AddAll(union) })
+}
+
+func TestAVLSet_AddAllRetainAllOrderedOtherwise(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+	reversed := func(xs ...int) *AVLSet[int] {
+		return iterator.CollectInto[int](iterator.FromSlice(xs), NewAVLSet[int](order.Reverse(order.IntComp)))
+	}
+
+	set, other := avlSetOf(1, 2, 3), reversed(3, 4, 5)
+	set.AddAll(other)
+	assert.True(t, set.IsAVLSet())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, iterator.Collect[int](set.Iterator()))
+	assert.True(t, other.IsEmpty(), "other is moved into set")
+
+	other = reversed(0, 2, 4, 6)
+	set.RetainAll(other)
+	assert.True(t, set.IsAVLSet())
+	assert.Equal(t, []int{2, 4}, iterator.Collect[int](set.Iterator()))
+	assert.True(t, other.IsEmpty(), "other is left empty")
+}
+
+func TestAVLSet_AddAllIsSublinear(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelOff))
+	sorted := make([]int, 1<<16)
+	for i := range sorted {
+		sorted[i] = 2 * i
+	}
+	big := NewAVLSetFromSorted(order.IntComp, sorted)
+
+	// only building the small operands allocates, joining them in reuses their nodes,
+	// as sets made by the same built-in comparator share their ordering
+	building := testing.AllocsPerRun(10, func() { avlSetOf(1, 1001, 50001, 99999) })
+	adding := testing.AllocsPerRun(10, func() { big.AddAll(avlSetOf(1, 1001, 50001, 99999)) })
+	assert.Equal(t, building, adding)
+	assert.Equal(t, 1<<16+4, big.Size())
+
+	retaining := testing.AllocsPerRun(1, func() { big.RetainAll(avlSetOf(1, 4, 1001, 7)) })
+	assert.Less(t, retaining-building, 8.0, "only the O(m) nodes join2 links in between are allocated")
+	assert.Equal(t, []int{1, 4, 1001}, iterator.Collect[int](big.Iterator()))
+}
+
+func TestAVLSet_IntersectionIsSublinear(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelOff))
+	sorted := make([]int, 1<<16)
+	for i := range sorted {
+		sorted[i] = 2 * i
+	}
+	big := NewAVLSetFromSorted(order.IntComp, sorted)
+	small := avlSetOf(2, 3, 4, 1000)
+
+	var intersection Set[int]
+	allocs := testing.AllocsPerRun(10, func() { intersection = big.Intersection(small) })
+	assert.Less(t, allocs, 20.0)
+	assert.Equal(t, []int{2, 4, 1000}, iterator.Collect[int](intersection.Iterator()))
+	assert.Equal(t, 1<<16, big.Size(), "operands are left alone")
+	assert.Equal(t, 4, small.Size(), "operands are left alone")
+}
+
+func TestNewAVLSetFromSorted(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+	set := NewAVLSetFromSorted(order.IntComp, []int{1, 3, 5, 7, 9, 11, 13})
+	assert.True(t, set.IsAVLSet())
+	assert.Equal(t, 7, set.Size())
+	assert.Equal(t, 3, set.tree.Root.GetHeight())
+	assert.True(t, set.Contains(9))
+
+	set.Add(4)
+	assert.Equal(t, 2, set.Rank(4))
+	assert.True(t, NewAVLSetFromSorted[int](order.IntComp, nil).IsEmpty())
+	assert.Panics(t, func() { NewAVLSetFromSorted(order.IntComp, []int{1, 3, 3}) })
+}
+
+func BenchmarkAVLSet_Load(b *testing.B) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelOff))
+	sorted := make([]int, 100000)
+	for i := range sorted {
+		sorted[i] = i
+	}
+
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			set := NewAVLSet[int](order.IntComp)
+			for _, x := range sorted {
+				set.Add(x)
+			}
+		}
+	})
+	b.Run("FromSorted", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewAVLSetFromSorted(order.IntComp, sorted)
+		}
+	})
+}
+
+func BenchmarkAVLSet_AddAll(b *testing.B) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelOff))
+	big := NewAVLSet[int](order.IntComp)
+	for x := 0; x < 100000; x++ {
+		big.Add(2 * x)
+	}
+	small := avlSetOf(1, 1001, 50001, 99999)
+
+	b.Run("AddAll", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			big.AddAll(avlSetOf(1, 1001, 50001, 99999))
+		}
+	})
+	b.Run("Union", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			big.Union(small)
+		}
+	})
+}
@@ -3,18 +3,21 @@ package set
 import (
 	"github.com/song-flying/GoDataStructures/array"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
 	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/song-flying/GoDataStructures/tree"
+	"math/bits"
 )
 
 type AVLSet[E comparable] struct {
-	tree *tree.BinaryTree[E]
-	comp order.CompareFn[E]
-	size int
+	tree     *tree.BinaryTree[E]
+	comp     order.CompareFn[E]
+	ordering order.Ordering[E]
+	modCount int
 }
 
 func (t *AVLSet[E]) isOrdered(root *tree.BinaryNode[E], lower, upper *E) bool {
-	contract.Require(root.IsBinaryTree(), "root is binary tree")
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
 	contract.Require(t.comp != nil, "comparison function is not nil")
 	contract.Require(lower == nil || upper == nil || t.comp(*lower, *upper) < 0, "lower < upper")
 
@@ -30,7 +33,7 @@ func (t *AVLSet[E]) isOrdered(root *tree.BinaryNode[E], lower, upper *E) bool {
 
 func (t *AVLSet[E]) isOrderedWithMinMax(root *tree.BinaryNode[E]) (minKey, maxKey *E, isOrdered bool) {
 	contract.Require(t.comp != nil, "comparison function is not nil")
-	contract.Require(root.IsBinaryTree(), "root is a binary tree")
+	contract.RequireInvariant(root.IsBinaryTree, "root is a binary tree")
 
 	if root == nil {
 		return nil, nil, true
@@ -68,13 +71,35 @@ func (t *AVLSet[E]) hasSameEntries(a1, a2 []E) bool {
 	return array.Same(a1, a2)
 }
 
+// snapshot checks the AVL invariant and keeps the elements before an update in old,
+// it is given to RequireInvariant so that the elements are only copied when the pre-condition is sampled
+func (t *AVLSet[E]) snapshot(old *[]E, taken *bool) func() bool {
+	return func() bool {
+		if !t.IsAVLSet() {
+			return false
+		}
+		*old, *taken = t.ToArray(t.tree.Root), true
+		return true
+	}
+}
+
+func (t *AVLSet[E]) hasSameElementsExcept(oldElements []E, element E) bool {
+	filter := func(e E) bool { return t.comp(e, element) != 0 }
+	return t.hasSameEntries(array.Filter(oldElements, filter), array.Filter(t.ToArray(t.tree.Root), filter))
+}
+
 func (t *AVLSet[E]) isHeightOKFrom(root *tree.BinaryNode[E]) bool {
-	contract.Require(root.IsBinaryTree(), "root is binary tree")
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
 	return root == nil || t.isHeightOKFrom(root.Left) && t.isHeightOKFrom(root.Right) && root.Height == order.Max(root.Left.GetHeight(), root.Right.GetHeight())+1
 }
 
+func (t *AVLSet[E]) isSizeOKFrom(root *tree.BinaryNode[E]) bool {
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
+	return root == nil || t.isSizeOKFrom(root.Left) && t.isSizeOKFrom(root.Right) && root.Size == root.Left.GetSize()+root.Right.GetSize()+1
+}
+
 func (t *AVLSet[E]) isBalancedFrom(root *tree.BinaryNode[E]) bool {
-	contract.Require(root.IsBinaryTree(), "root is binary tree")
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
 	return root == nil || t.isBalancedFrom(root.Left) && t.isBalancedFrom(root.Right) && abs(root.Left.GetHeight()-root.Right.GetHeight()) <= 1
 }
 
@@ -86,26 +111,33 @@ func (t *AVLSet[E]) IsAVLSet() bool {
 func (t *AVLSet[E]) IsAVL(root *tree.BinaryNode[E]) bool {
 	_, _, isOrdered := t.isOrderedWithMinMax(root)
 	return t.comp != nil &&
-		root.IsBinaryTree() && isOrdered && t.isHeightOKFrom(root) && t.isBalancedFrom(root)
+		root.IsBinaryTree() && isOrdered && t.isHeightOKFrom(root) && t.isSizeOKFrom(root) && t.isBalancedFrom(root)
 }
 
-func NewAVLSet[E comparable](comp order.CompareFn[E]) (result *AVLSet[E]) {
+func NewAVLSet[E comparable](comp order.CompareFn[E]) *AVLSet[E] {
 	contract.Require(comp != nil, "comparison function is not nil")
+
+	return NewAVLSetBy(order.OrderingOf(comp))
+}
+
+// NewAVLSetBy makes a set ordered by ordering, whose elements merge with those of other sets made by it
+func NewAVLSetBy[E comparable](ordering order.Ordering[E]) (result *AVLSet[E]) {
+	contract.Require(ordering.Compare != nil, "comparison function is not nil")
 	defer func() {
-		contract.Ensure(result.IsAVLSet(), "AVL invariant holds")
+		contract.EnsureInvariant(result.IsAVLSet, "AVL invariant holds")
 	}()
 
 	t := tree.NewBinaryTree(tree.Nil[E]())
 
 	return &AVLSet[E]{
-		tree: t,
-		comp: comp,
-		size: 0,
+		tree:     t,
+		comp:     ordering.Compare,
+		ordering: ordering,
 	}
 }
 
 func (t *AVLSet[E]) lookup(root *tree.BinaryNode[E], element E) *tree.BinaryNode[E] {
-	contract.Require(t.IsAVL(root), "AVL invariant holds")
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
 
 	if root == nil {
 		return nil
@@ -123,7 +155,7 @@ func (t *AVLSet[E]) lookup(root *tree.BinaryNode[E], element E) *tree.BinaryNode
 }
 
 func (t *AVLSet[E]) Contains(element E) bool {
-	contract.Require(t.IsAVLSet(), "AVL invariant holds")
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
 
 	node := t.lookup(t.tree.Root, element)
 
@@ -131,7 +163,7 @@ func (t *AVLSet[E]) Contains(element E) bool {
 }
 
 func (t *AVLSet[E]) ToArray(root *tree.BinaryNode[E]) (result []E) {
-	contract.Require(t.IsAVL(root), "AVL invariant holds")
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
 	defer func() {
 		contract.Ensure(array.IsSorted(result, t.comp), "result entries are sorted")
 	}()
@@ -153,22 +185,16 @@ func (t *AVLSet[E]) ToArray(root *tree.BinaryNode[E]) (result []E) {
 }
 
 func (t *AVLSet[E]) insertFrom(root *tree.BinaryNode[E], element E) (result *tree.BinaryNode[E]) {
-	contract.Require(t.IsAVL(root), "AVL invariant holds")
-	defer func(oldElements []E) {
-		contract.Ensure(t.IsAVL(result), "AVL invariant holds")
-		newElements := t.ToArray(result)
-		extract := func(e E) E { return e }
-		contract.Ensure(array.Contains(newElements, element, extract), "new root should contain new element")
-		filter := func(e E) bool { return e != element }
-		oldElements = array.Filter(oldElements, filter)
-		newElements = array.Filter(newElements, filter)
-		contract.Ensure(t.hasSameEntries(oldElements, newElements), "new root should contain same elements as old root, except for new element")
-	}(t.ToArray(root))
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(func() bool { return t.IsAVL(result) }, "AVL invariant holds")
+	}()
 
 	if root == nil {
 		node := tree.NewBinaryNode[E](element)
 		node.Height = 1
-		t.size++
+		node.Size = 1
+		t.modCount++
 		return &node
 	}
 
@@ -188,26 +214,27 @@ func (t *AVLSet[E]) insertFrom(root *tree.BinaryNode[E], element E) (result *tre
 }
 
 func (t *AVLSet[E]) Add(element E) {
-	contract.Require(t.IsAVLSet(), "AVL invariant holds")
+	var oldElements []E
+	snapshotted := false
+	contract.RequireInvariant(t.snapshot(&oldElements, &snapshotted), "AVL invariant holds")
 	defer func() {
-		contract.Ensure(t.IsAVLSet(), "AVL invariant holds")
-		contract.Ensure(t.Contains(element), "Contains(element) returns true")
+		contract.EnsureInvariant(t.IsAVLSet, "AVL invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(t.Contains(element), "Contains(element) returns true")
+		}
+		if snapshotted {
+			contract.Ensure(t.hasSameElementsExcept(oldElements, element), "set contains same elements as before, except for new element")
+		}
 	}()
 
 	t.tree.Root = t.insertFrom(t.tree.Root, element)
 }
 
 func (t *AVLSet[E]) removeFrom(root *tree.BinaryNode[E], element E) (result *tree.BinaryNode[E]) {
-	contract.Require(t.IsAVL(root), "AVL invariant holds")
-	defer func(oldElements []E) {
-		contract.Ensure(t.IsAVL(result), "AVL invariant holds")
-		newElements := t.ToArray(result)
-		extract := func(e E) E { return e }
-		contract.Ensure(!array.Contains(newElements, element, extract), "root tree does not contain removed element")
-		filter := func(e E) bool { return e != element }
-		oldElements = array.Filter(oldElements, filter)
-		contract.Ensure(t.hasSameEntries(oldElements, newElements), "new root should contain same element as old root excluding removed element")
-	}(t.ToArray(root))
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(func() bool { return t.IsAVL(result) }, "AVL invariant holds")
+	}()
 
 	if root == nil {
 		return nil
@@ -233,7 +260,7 @@ func (t *AVLSet[E]) removeFrom(root *tree.BinaryNode[E], element E) (result *tre
 		default:
 			root = nil
 		}
-		t.size--
+		t.modCount++
 	}
 
 	return root
@@ -241,16 +268,10 @@ func (t *AVLSet[E]) removeFrom(root *tree.BinaryNode[E], element E) (result *tre
 
 func (t *AVLSet[E]) removeMax(root *tree.BinaryNode[E]) (result *tree.BinaryNode[E], max E) {
 	contract.Require(root != nil, "root is not nil")
-	contract.Require(t.IsAVL(root), "AVL invariant holds")
-	defer func(oldElements []E) {
-		contract.Ensure(t.IsAVL(result), "AVL invariant holds")
-		newEntries := t.ToArray(result)
-		extract := func(e E) E { return e }
-		contract.Ensure(!array.Contains(newEntries, max, extract), "root tree does not contain removed element")
-		filter := func(e E) bool { return e != max }
-		oldElements = array.Filter(oldElements, filter)
-		contract.Ensure(t.hasSameEntries(oldElements, newEntries), "new root should contain same elements as old root excluding removed elements")
-	}(t.ToArray(root))
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(func() bool { return t.IsAVL(result) }, "AVL invariant holds")
+	}()
 
 	if root.Right == nil {
 		return root.Left, root.Data
@@ -264,16 +285,10 @@ func (t *AVLSet[E]) removeMax(root *tree.BinaryNode[E]) (result *tree.BinaryNode
 
 func (t *AVLSet[E]) removeMin(root *tree.BinaryNode[E]) (result *tree.BinaryNode[E], min E) {
 	contract.Require(root != nil, "root is not nil")
-	contract.Require(t.IsAVL(root), "AVL invariant holds")
-	defer func(oldElements []E) {
-		contract.Ensure(t.IsAVL(result), "AVL invariant holds")
-		newElements := t.ToArray(result)
-		extract := func(e E) E { return e }
-		contract.Ensure(!array.Contains(newElements, min, extract), "root tree does not contain removed element")
-		filter := func(e E) bool { return e != min }
-		oldElements = array.Filter(oldElements, filter)
-		contract.Ensure(t.hasSameEntries(oldElements, newElements), "new root should contain same elements as old root excluding removed elements")
-	}(t.ToArray(root))
+	contract.RequireInvariant(func() bool { return t.IsAVL(root) }, "AVL invariant holds")
+	defer func() {
+		contract.EnsureInvariant(func() bool { return t.IsAVL(result) }, "AVL invariant holds")
+	}()
 
 	if root.Left == nil {
 		return root.Right, root.Data
@@ -286,30 +301,78 @@ func (t *AVLSet[E]) removeMin(root *tree.BinaryNode[E]) (result *tree.BinaryNode
 }
 
 func (t *AVLSet[E]) Delete(element E) {
-	contract.Require(t.IsAVLSet(), "AVL invariant holds")
+	var oldElements []E
+	snapshotted := false
+	contract.RequireInvariant(t.snapshot(&oldElements, &snapshotted), "AVL invariant holds")
 	defer func() {
-		contract.Ensure(t.IsAVLSet(), "AVL invariant holds")
-		contract.Ensure(!t.Contains(element), "Contains(element) returns false")
+		contract.EnsureInvariant(t.IsAVLSet, "AVL invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(!t.Contains(element), "Contains(element) returns false")
+		}
+		if snapshotted {
+			contract.Ensure(t.hasSameElementsExcept(oldElements, element), "set contains same elements as before, excluding removed element")
+		}
 	}()
 
 	t.tree.Root = t.removeFrom(t.tree.Root, element)
 }
 
 func (t *AVLSet[E]) Size() (result int) {
-	contract.Require(t.IsAVLSet(), "AVL invariant holds")
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
 	defer func() {
 		contract.Ensure(0 <= result, "result is non-negative")
 	}()
 
-	return t.size
+	return t.tree.Root.GetSize()
+}
+
+// Rank returns the number of elements less than x in O(log n)
+func (t *AVLSet[E]) Rank(x E) (result int) {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+	defer func() {
+		contract.Ensure(0 <= result && result <= t.tree.Root.GetSize(), "0 <= result <= Size()")
+	}()
+
+	for node := t.tree.Root; node != nil; {
+		if t.comp(x, node.Data) <= 0 {
+			node = node.Left
+		} else {
+			result += node.Left.GetSize() + 1
+			node = node.Right
+		}
+	}
+
+	return
+}
+
+// Select returns the element of rank k, i.e. the k-th smallest counting from 0, in O(log n)
+func (t *AVLSet[E]) Select(k int) (result E) {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+	contract.Require(0 <= k && k < t.tree.Root.GetSize(), "0 <= k < Size()")
+	defer func() {
+		contract.Ensure(t.Rank(result) == k, "Rank(result) == k")
+	}()
+
+	for node, i := t.tree.Root, k; ; {
+		leftSize := node.Left.GetSize()
+		switch {
+		case i < leftSize:
+			node = node.Left
+		case i > leftSize:
+			i -= leftSize + 1
+			node = node.Right
+		default:
+			return node.Data
+		}
+	}
 }
 
 func (t *AVLSet[E]) rebalance(root *tree.BinaryNode[E]) (result *tree.BinaryNode[E]) {
 	contract.Require(root != nil, "root is not nil")
-	contract.Require(t.IsAVL(root.Left), "left child is AVL")
-	contract.Require(t.IsAVL(root.Right), "right child is AVL")
+	contract.RequireInvariant(func() bool { return t.IsAVL(root.Left) }, "left child is AVL")
+	contract.RequireInvariant(func() bool { return t.IsAVL(root.Right) }, "right child is AVL")
 	defer func() {
-		contract.Ensure(t.IsAVL(result), "result is AVL")
+		contract.EnsureInvariant(func() bool { return t.IsAVL(result) }, "result is AVL")
 	}()
 
 	diffLR := root.Left.GetHeight() - root.Right.GetHeight()
@@ -328,8 +391,11 @@ func (t *AVLSet[E]) rebalance(root *tree.BinaryNode[E]) (result *tree.BinaryNode
 			w.Left = u
 			w.Right = v
 			u.SetHeight()
+			u.SetSize()
 			v.SetHeight()
+			v.SetSize()
 			w.SetHeight()
+			w.SetSize()
 			root = w
 		} else {
 			w = v.Right
@@ -337,7 +403,9 @@ func (t *AVLSet[E]) rebalance(root *tree.BinaryNode[E]) (result *tree.BinaryNode
 			u.Right = v.Left
 			v.Left = u
 			u.SetHeight()
+			u.SetSize()
 			v.SetHeight()
+			v.SetSize()
 			root = v
 		}
 
@@ -354,8 +422,11 @@ func (t *AVLSet[E]) rebalance(root *tree.BinaryNode[E]) (result *tree.BinaryNode
 			w.Right = u
 			w.Left = v
 			u.SetHeight()
+			u.SetSize()
 			v.SetHeight()
+			v.SetSize()
 			w.SetHeight()
+			w.SetSize()
 			root = w
 		} else {
 			w = v.Left
@@ -363,12 +434,15 @@ func (t *AVLSet[E]) rebalance(root *tree.BinaryNode[E]) (result *tree.BinaryNode
 			u.Left = v.Right
 			v.Right = u
 			u.SetHeight()
+			u.SetSize()
 			v.SetHeight()
+			v.SetSize()
 			root = v
 		}
 
 	default: // -1 <= diffLR <= 1
 		root.SetHeight()
+		root.SetSize()
 	}
 
 	return root
@@ -377,3 +451,172 @@ func (t *AVLSet[E]) rebalance(root *tree.BinaryNode[E]) (result *tree.BinaryNode
 func (t *AVLSet[E]) IsEmpty() bool {
 	return t.Size() == 0
 }
+
+func (t *AVLSet[E]) walk(descending bool, afterStart, beforeEnd func(E) bool) *tree.OrderedIterator[E] {
+	return tree.NewOrderedIterator(t.tree.Root, descending, afterStart, beforeEnd)
+}
+
+func (t *AVLSet[E]) compare(a, b E) int {
+	return t.comp(a, b)
+}
+
+// Ordering returns the ordering of t, sets made by it merge their elements with those of t
+func (t *AVLSet[E]) Ordering() order.Ordering[E] {
+	return t.ordering
+}
+
+func (t *AVLSet[E]) mods() int {
+	return t.modCount
+}
+
+// Iterator walks the elements in ascending order
+func (t *AVLSet[E]) Iterator() iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+
+	return newTreeSetIterator[E](t, false, nil, nil)
+}
+
+// All walks the elements in ascending order
+func (t *AVLSet[E]) All() iterator.Seq[E] {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+
+	return iterator.FailFastSeq(t.tree.All(), t.mods)
+}
+
+// Descending walks the elements in descending order
+func (t *AVLSet[E]) Descending() iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+
+	return newTreeSetIterator[E](t, true, nil, nil)
+}
+
+// Range walks the elements within [lo, hi) in ascending order
+func (t *AVLSet[E]) Range(lo, hi E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+	contract.Require(t.comp(lo, hi) <= 0, "lo <= hi")
+
+	return newTreeSetIterator[E](t, false, atLeast(t.comp, lo), below(t.comp, hi))
+}
+
+// ReverseRange walks the elements within [lo, hi) in descending order
+func (t *AVLSet[E]) ReverseRange(lo, hi E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+	contract.Require(t.comp(lo, hi) <= 0, "lo <= hi")
+
+	return newTreeSetIterator[E](t, true, below(t.comp, hi), atLeast(t.comp, lo))
+}
+
+// From walks the elements not less than x in ascending order
+func (t *AVLSet[E]) From(x E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+
+	return newTreeSetIterator[E](t, false, atLeast(t.comp, x), nil)
+}
+
+// ReverseFrom walks the elements not greater than x in descending order
+func (t *AVLSet[E]) ReverseFrom(x E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+
+	return newTreeSetIterator[E](t, true, atMost(t.comp, x), nil)
+}
+
+// combineWith merges with a tree set other in O(m+n), and looks up the elements of any other set
+func (t *AVLSet[E]) combineWith(other Set[E], keep func(inA, inB bool) bool) (result *AVLSet[E]) {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+	contract.Require(other != nil, "other is not nil")
+	defer func() {
+		contract.EnsureInvariant(result.IsAVLSet, "AVL invariant holds")
+	}()
+
+	result = NewAVLSetBy[E](t.ordering)
+	if !mergeable(other, t.ordering) {
+		combine[E](result, t, other, keep)
+		return
+	}
+
+	elements := mergeSorted[E](t.Iterator(), other.Iterator(), t.comp, keep)
+	result.tree.Root = tree.NewBalanced(elements)
+	return
+}
+
+func (t *AVLSet[E]) noneKeptWith(other Set[E], keep func(inA, inB bool) bool) bool {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+	contract.Require(other != nil, "other is not nil")
+
+	if mergeable(other, t.ordering) {
+		return noneKeptSorted[E](t.Iterator(), other.Iterator(), t.comp, keep)
+	}
+	return noneKept[E](t, other, keep)
+}
+
+// intersectByLookup looks up the elements of the smaller of t and other in the larger in O(m log n),
+// where m is the smaller size, keeping the elements of t
+func (t *AVLSet[E]) intersectByLookup(other *AVLSet[E]) (result *AVLSet[E]) {
+	contract.RequireInvariant(t.IsAVLSet, "AVL invariant holds")
+	contract.Require(mergeable[E](other, t.ordering), "other is ordered by the same comparison")
+	defer func() {
+		contract.EnsureInvariant(result.IsAVLSet, "AVL invariant holds")
+	}()
+
+	small, large := t, other
+	if other.Size() < t.Size() {
+		small, large = other, t
+	}
+
+	var elements []E
+	small.tree.All()(func(x E) bool {
+		if node := large.lookup(large.tree.Root, x); node != nil {
+			if large == t {
+				x = node.Data
+			}
+			elements = append(elements, x)
+		}
+		return true
+	})
+
+	result = NewAVLSetBy[E](t.ordering)
+	result.tree.Root = tree.NewBalanced(elements)
+	return
+}
+
+// lookupIsCheaper reports whether looking up the m elements of the smaller of two sets in the larger,
+// of n elements, beats merging them in m+n steps
+func lookupIsCheaper(m, n int) bool {
+	if n < m {
+		m, n = n, m
+	}
+	return m*bits.Len(uint(n)) < m+n
+}
+
+// Union is O(m+n), as it copies every element of t and other into the result
+func (t *AVLSet[E]) Union(other Set[E]) Set[E] {
+	return t.combineWith(other, inUnion)
+}
+
+// Intersection is O(min(m log n, m+n)), where m is the smaller size, when other is an AVLSet of the same ordering
+func (t *AVLSet[E]) Intersection(other Set[E]) Set[E] {
+	if other, ok := other.(*AVLSet[E]); ok && lookupIsCheaper(t.Size(), other.Size()) && order.Same(other.ordering, t.ordering) {
+		return t.intersectByLookup(other)
+	}
+	return t.combineWith(other, inIntersection)
+}
+
+func (t *AVLSet[E]) Difference(other Set[E]) Set[E] {
+	return t.combineWith(other, inDifference)
+}
+
+func (t *AVLSet[E]) SymmetricDifference(other Set[E]) Set[E] {
+	return t.combineWith(other, inSymmetricDifference)
+}
+
+func (t *AVLSet[E]) IsSubsetOf(other Set[E]) bool {
+	return t.noneKeptWith(other, inDifference)
+}
+
+func (t *AVLSet[E]) IsDisjoint(other Set[E]) bool {
+	return t.noneKeptWith(other, inIntersection)
+}
+
+func (t *AVLSet[E]) Equals(other Set[E]) bool {
+	return t.noneKeptWith(other, inSymmetricDifference)
+}
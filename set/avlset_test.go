@@ -2,6 +2,9 @@ package set
 
 import (
 	"github.com/song-flying/GoDataStructures/array"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -52,3 +55,133 @@ func TestAVLSet(t *testing.T) {
 		assert.Equal(t, len(a)-i-1, set.Size())
 	}
 }
+
+func TestAVLSet_Iterator(t *testing.T) {
+	var set Set[int] = NewAVLSet[int](order.IntComp)
+	assert.False(t, set.Iterator().HasNext())
+
+	a := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	array.Shuffle(a)
+	for _, e := range a {
+		set.Add(e)
+	}
+
+	var fromIterator []int
+	for it := set.Iterator(); it.HasNext(); {
+		fromIterator = append(fromIterator, it.Next())
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, fromIterator)
+
+	var fromAll []int
+	set.All()(func(e int) bool {
+		fromAll = append(fromAll, e)
+		return e < 5
+	})
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, fromAll)
+}
+
+func TestAVLSet_Range(t *testing.T) {
+	set := NewAVLSet[int](order.IntComp)
+	assert.False(t, set.Descending().HasNext())
+
+	a := []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}
+	array.Shuffle(a)
+	for _, e := range a {
+		set.Add(e)
+	}
+
+	assert.Equal(t, []int{18, 16, 14, 12, 10, 8, 6, 4, 2, 0}, iterator.Collect[int](set.Descending()))
+	assert.Equal(t, []int{4, 6, 8}, iterator.Collect[int](set.Range(3, 10)))
+	assert.Equal(t, []int{8, 6, 4}, iterator.Collect[int](set.ReverseRange(3, 10)))
+	assert.Empty(t, iterator.Collect[int](set.Range(4, 4)))
+	assert.Equal(t, []int{14, 16, 18}, iterator.Collect[int](set.From(14)))
+	assert.Equal(t, []int{4, 2, 0}, iterator.Collect[int](set.ReverseFrom(5)))
+	assert.Empty(t, iterator.Collect[int](set.From(19)))
+}
+
+func TestAVLSet_IteratorRemove(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+
+	set := NewAVLSet[int](order.IntComp)
+	a := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	array.Shuffle(a)
+	for _, e := range a {
+		set.Add(e)
+	}
+
+	for it := set.Range(2, 8); it.HasNext(); {
+		if it.Next()%2 == 1 {
+			it.Remove()
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2, 4, 6, 8, 9}, iterator.Collect[int](set.Iterator()))
+
+	for it := set.Descending(); it.HasNext(); {
+		if it.Next() > 4 {
+			it.Remove()
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2, 4}, iterator.Collect[int](set.Iterator()))
+	assert.Equal(t, 4, set.Size())
+
+	it := set.Iterator()
+	assert.Panics(t, func() { it.Remove() })
+	set.Delete(0)
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.HasNext() })
+}
+
+func TestAVLSet_CaseInsensitive(t *testing.T) {
+	set := NewAVLSet[string](order.CaseInsensitiveComp)
+	set.Add("Go")
+	set.Add("go")
+	set.Add("Rust")
+
+	assert.Equal(t, 2, set.Size())
+	assert.True(t, set.Contains("GO"))
+	assert.Equal(t, []string{"Rust", "go"}, iterator.Collect[string](set.Descending()))
+}
+
+func TestAVLSet_RankSelect(t *testing.T) {
+	set := NewAVLSet[int](order.IntComp)
+	a := []int{10, 20, 30, 40, 50, 60, 70, 80, 90}
+	array.Shuffle(a)
+	for _, x := range a {
+		set.Add(x)
+	}
+
+	assert.Equal(t, 0, set.Rank(5))
+	assert.Equal(t, 0, set.Rank(10))
+	assert.Equal(t, 1, set.Rank(15))
+	assert.Equal(t, 4, set.Rank(50))
+	assert.Equal(t, 9, set.Rank(100))
+	for k := 0; k < set.Size(); k++ {
+		assert.Equal(t, (k+1)*10, set.Select(k))
+	}
+	assert.Panics(t, func() { set.Select(9) })
+	assert.Panics(t, func() { set.Select(-1) })
+
+	set.Delete(10)
+	set.Delete(50)
+	assert.Equal(t, 7, set.Size())
+	assert.Equal(t, 3, set.Rank(55))
+	assert.Equal(t, 60, set.Select(3))
+
+	// the median of the live data
+	set.Add(100)
+	assert.Equal(t, 70, set.Select(set.Size()/2))
+}
+
+func TestAVLSet_IsAVLChecksSize(t *testing.T) {
+	set := NewAVLSet[int](order.IntComp)
+	for x := 0; x < 8; x++ {
+		set.Add(x)
+	}
+	assert.True(t, set.IsAVLSet())
+
+	set.tree.Root.Left.Size++
+	assert.False(t, set.IsAVLSet())
+}
+
+func BenchmarkAVLSet(b *testing.B) {
+	benchmarkTreeSet(b, func() Set[int] { return NewAVLSet[int](order.IntComp) })
+}
@@ -0,0 +1,17 @@
+package set
+
+import "github.com/song-flying/GoDataStructures/pkg/order"
+
+// bounds handed to tree.NewOrderedIterator by the tree sets, and to the searches of SkipListSet
+
+func atLeast[E comparable](comp order.CompareFn[E], x E) func(E) bool {
+	return func(e E) bool { return comp(e, x) >= 0 }
+}
+
+func atMost[E comparable](comp order.CompareFn[E], x E) func(E) bool {
+	return func(e E) bool { return comp(e, x) <= 0 }
+}
+
+func below[E comparable](comp order.CompareFn[E], x E) func(E) bool {
+	return func(e E) bool { return comp(e, x) < 0 }
+}
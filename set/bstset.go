@@ -3,14 +3,17 @@ package set
 import (
 	"github.com/song-flying/GoDataStructures/array"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
 	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/song-flying/GoDataStructures/tree"
 )
 
 type BSTSet[E comparable] struct {
-	tree *tree.BinaryTree[E]
-	comp order.CompareFn[E]
-	size int
+	tree     *tree.BinaryTree[E]
+	comp     order.CompareFn[E]
+	ordering order.Ordering[E]
+	size     int
+	modCount int
 }
 
 func (t *BSTSet[E]) isOrdered(root *tree.BinaryNode[E], lower, upper *E) bool {
@@ -27,7 +30,7 @@ func (t *BSTSet[E]) isOrdered(root *tree.BinaryNode[E], lower, upper *E) bool {
 
 func (t *BSTSet[E]) isOrderedWithMinMax(root *tree.BinaryNode[E]) (minElement, maxElement *E, isOrdered bool) {
 	contract.Require(t.comp != nil, "comparison function is not nil")
-	contract.Require(root.IsBinaryTree(), "root is a binary tree")
+	contract.RequireInvariant(root.IsBinaryTree, "root is a binary tree")
 
 	if root == nil {
 		return nil, nil, true
@@ -75,24 +78,32 @@ func (t *BSTSet[E]) IsBST(root *tree.BinaryNode[E]) bool {
 	return t.comp != nil && root.IsBinaryTree() && isOrdered
 }
 
-func NewBSTSet[E comparable](comp order.CompareFn[E]) (result *BSTSet[E]) {
+func NewBSTSet[E comparable](comp order.CompareFn[E]) *BSTSet[E] {
 	contract.Require(comp != nil, "comparison function is not nil")
+
+	return NewBSTSetBy(order.OrderingOf(comp))
+}
+
+// NewBSTSetBy makes a set ordered by ordering, whose elements merge with those of other sets made by it
+func NewBSTSetBy[E comparable](ordering order.Ordering[E]) (result *BSTSet[E]) {
+	contract.Require(ordering.Compare != nil, "comparison function is not nil")
 	defer func() {
-		contract.Ensure(result.IsBSTSet(), "BST invariant holds")
+		contract.EnsureInvariant(result.IsBSTSet, "BST invariant holds")
 	}()
 
 	t := tree.NewBinaryTree(tree.Nil[E]())
 
 	return &BSTSet[E]{
-		tree: t,
-		comp: comp,
-		size: 0,
+		tree:     t,
+		comp:     ordering.Compare,
+		ordering: ordering,
+		size:     0,
 	}
 }
 
 func (t *BSTSet[E]) lookup(root **tree.BinaryNode[E], element E) **tree.BinaryNode[E] {
 	contract.Require(root != nil, "root pointer is not nil")
-	contract.Require(t.IsBST(*root), "BST invariant holds")
+	contract.RequireInvariant(func() bool { return t.IsBST(*root) }, "BST invariant holds")
 
 	if *root == nil {
 		return nil
@@ -110,14 +121,14 @@ func (t *BSTSet[E]) lookup(root **tree.BinaryNode[E], element E) **tree.BinaryNo
 }
 
 func (t *BSTSet[E]) Contains(element E) bool {
-	contract.Require(t.IsBSTSet(), "BST invariant holds")
+	contract.RequireInvariant(t.IsBSTSet, "BST invariant holds")
 
 	node := t.lookup(&t.tree.Root, element)
 	return node != nil
 }
 
 func (t *BSTSet[E]) ToArray(root *tree.BinaryNode[E]) (result []E) {
-	contract.Require(t.IsBST(root), "BST invariant holds")
+	contract.RequireInvariant(func() bool { return t.IsBST(root) }, "BST invariant holds")
 	defer func() {
 		contract.Ensure(array.IsSorted(result, t.comp), "result elements are sorted")
 	}()
@@ -137,21 +148,24 @@ func (t *BSTSet[E]) ToArray(root *tree.BinaryNode[E]) (result []E) {
 }
 
 func (t *BSTSet[E]) insert(root *tree.BinaryNode[E], element E) (result *tree.BinaryNode[E]) {
-	contract.Require(t.IsBST(root), "BST invariant holds for root")
-	defer func(oldElements []E) {
-		contract.Ensure(t.IsBST(result), "BST invariant holds for result")
-		newElements := t.ToArray(result)
-		extract := func(e E) E { return e }
-		contract.Ensure(array.Contains(newElements, element, extract), "new root should contain new element")
-		filter := func(e E) bool { return e != element }
-		oldElements = array.Filter(oldElements, filter)
-		newElements = array.Filter(newElements, filter)
-		contract.Ensure(t.hasSameEntries(oldElements, newElements), "new root should contain same entries as old root, except for new element")
-	}(t.ToArray(root))
+	contract.RequireInvariant(func() bool { return t.IsBST(root) }, "BST invariant holds for root")
+	if contract.Enabled(contract.LevelPost) {
+		defer func(oldElements []E) {
+			contract.EnsureInvariant(func() bool { return t.IsBST(result) }, "BST invariant holds for result")
+			newElements := t.ToArray(result)
+			extract := func(e E) E { return e }
+			contract.Ensure(array.Contains(newElements, element, extract), "new root should contain new element")
+			filter := func(e E) bool { return t.comp(e, element) != 0 }
+			oldElements = array.Filter(oldElements, filter)
+			newElements = array.Filter(newElements, filter)
+			contract.Ensure(t.hasSameEntries(oldElements, newElements), "new root should contain same entries as old root, except for new element")
+		}(t.ToArray(root))
+	}
 
 	if root == nil {
 		node := tree.NewBinaryNode[E](element)
 		t.size++
+		t.modCount++
 		return &node
 	}
 
@@ -169,10 +183,12 @@ func (t *BSTSet[E]) insert(root *tree.BinaryNode[E], element E) (result *tree.Bi
 }
 
 func (t *BSTSet[E]) Add(element E) {
-	contract.Require(t.IsBSTSet(), "BST invariant holds")
+	contract.RequireInvariant(t.IsBSTSet, "BST invariant holds")
 	defer func() {
-		contract.Ensure(t.IsBSTSet(), "BST invariant holds")
-		contract.Ensure(t.Contains(element), "Contains(element) returns true")
+		contract.EnsureInvariant(t.IsBSTSet, "BST invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(t.Contains(element), "Contains(element) returns true")
+		}
 	}()
 
 	t.tree.Root = t.insert(t.tree.Root, element)
@@ -181,13 +197,15 @@ func (t *BSTSet[E]) Add(element E) {
 func (t *BSTSet[E]) maxFrom(root **tree.BinaryNode[E]) (result **tree.BinaryNode[E]) {
 	contract.Require(t.comp != nil, "comparison function is not nil")
 	contract.Require(root != nil && *root != nil, "root points to some node")
-	_, maxElement, isOrdered := t.isOrderedWithMinMax(*root)
-	contract.Require(isOrdered, "root node is ordered")
-	defer func(maxElement *E) {
-		contract.Ensure(result != nil && (*result) != nil, "result points to some node")
-		contract.Ensure((*result).Right == nil, "result node is the right most one")
-		contract.Ensure((*result).Data == *maxElement, "result node has max element")
-	}(maxElement)
+	if contract.Enabled(contract.LevelFull) {
+		_, maxElement, isOrdered := t.isOrderedWithMinMax(*root)
+		contract.Require(isOrdered, "root node is ordered")
+		defer func(maxElement *E) {
+			contract.Ensure(result != nil && (*result) != nil, "result points to some node")
+			contract.Ensure((*result).Right == nil, "result node is the right most one")
+			contract.Ensure((*result).Data == *maxElement, "result node has max element")
+		}(maxElement)
+	}
 
 	curr := root
 	for (*curr).Right != nil {
@@ -199,13 +217,15 @@ func (t *BSTSet[E]) maxFrom(root **tree.BinaryNode[E]) (result **tree.BinaryNode
 func (t *BSTSet[E]) minFrom(root **tree.BinaryNode[E]) (result **tree.BinaryNode[E]) {
 	contract.Require(t.comp != nil, "comparison function is not nil")
 	contract.Require(root != nil && *root != nil, "root points to some node")
-	minElement, _, isOrdered := t.isOrderedWithMinMax(*root)
-	contract.Require(isOrdered, "root node is ordered")
-	defer func(minElement *E) {
-		contract.Ensure(result != nil && (*result) != nil, "result points to some node")
-		contract.Ensure((*result).Left == nil, "result node is the left most one")
-		contract.Ensure((*result).Data == *minElement, "result node has min element")
-	}(minElement)
+	if contract.Enabled(contract.LevelFull) {
+		minElement, _, isOrdered := t.isOrderedWithMinMax(*root)
+		contract.Require(isOrdered, "root node is ordered")
+		defer func(minElement *E) {
+			contract.Ensure(result != nil && (*result) != nil, "result points to some node")
+			contract.Ensure((*result).Left == nil, "result node is the left most one")
+			contract.Ensure((*result).Data == *minElement, "result node has min element")
+		}(minElement)
+	}
 
 	curr := root
 	for (*curr).Left != nil {
@@ -216,16 +236,18 @@ func (t *BSTSet[E]) minFrom(root **tree.BinaryNode[E]) (result **tree.BinaryNode
 
 func (t *BSTSet[E]) remove(pRoot **tree.BinaryNode[E]) {
 	contract.Require(pRoot != nil && *pRoot != nil, "root is not nil")
-	contract.Require(t.IsBST(*pRoot), "BST invariant holds for root")
-	defer func(element E, oldElements []E) {
-		contract.Ensure(t.IsBST(*pRoot), "BST invariant holds for root")
-		newElements := t.ToArray(*pRoot)
-		extract := func(e E) E { return e }
-		contract.Ensure(!array.Contains(newElements, element, extract), "root tree does not contain removed element")
-		filter := func(e E) bool { return e != element }
-		oldElements = array.Filter(oldElements, filter)
-		contract.Ensure(t.hasSameEntries(oldElements, newElements), "new root should contain same entries as old root excluding removed element")
-	}((*pRoot).Data, t.ToArray(*pRoot))
+	contract.RequireInvariant(func() bool { return t.IsBST(*pRoot) }, "BST invariant holds for root")
+	if contract.Enabled(contract.LevelPost) {
+		defer func(element E, oldElements []E) {
+			contract.EnsureInvariant(func() bool { return t.IsBST(*pRoot) }, "BST invariant holds for root")
+			newElements := t.ToArray(*pRoot)
+			extract := func(e E) E { return e }
+			contract.Ensure(!array.Contains(newElements, element, extract), "root tree does not contain removed element")
+			filter := func(e E) bool { return t.comp(e, element) != 0 }
+			oldElements = array.Filter(oldElements, filter)
+			contract.Ensure(t.hasSameEntries(oldElements, newElements), "new root should contain same entries as old root excluding removed element")
+		}((*pRoot).Data, t.ToArray(*pRoot))
+	}
 
 	switch {
 	case (*pRoot).Left == nil && (*pRoot).Right == nil:
@@ -243,10 +265,12 @@ func (t *BSTSet[E]) remove(pRoot **tree.BinaryNode[E]) {
 }
 
 func (t *BSTSet[E]) Delete(element E) {
-	contract.Require(t.IsBSTSet(), "BST invariant holds")
+	contract.RequireInvariant(t.IsBSTSet, "BST invariant holds")
 	defer func() {
-		contract.Ensure(t.IsBSTSet(), "BST invariant holds")
-		contract.Ensure(!t.Contains(element), "Contains(element) returns false")
+		contract.EnsureInvariant(t.IsBSTSet, "BST invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(!t.Contains(element), "Contains(element) returns false")
+		}
 	}()
 
 	target := t.lookup(&t.tree.Root, element)
@@ -256,10 +280,11 @@ func (t *BSTSet[E]) Delete(element E) {
 
 	t.remove(target)
 	t.size--
+	t.modCount++
 }
 
 func (t *BSTSet[E]) Size() (result int) {
-	contract.Require(t.IsBSTSet(), "BST invariant holds")
+	contract.RequireInvariant(t.IsBSTSet, "BST invariant holds")
 	defer func() {
 		contract.Ensure(0 <= result, "result is non-negative")
 	}()
@@ -270,3 +295,129 @@ func (t *BSTSet[E]) Size() (result int) {
 func (t *BSTSet[E]) IsEmpty() bool {
 	return t.Size() == 0
 }
+
+func (t *BSTSet[E]) walk(descending bool, afterStart, beforeEnd func(E) bool) *tree.OrderedIterator[E] {
+	return tree.NewOrderedIterator(t.tree.Root, descending, afterStart, beforeEnd)
+}
+
+func (t *BSTSet[E]) compare(a, b E) int {
+	return t.comp(a, b)
+}
+
+// Ordering returns the ordering of t, sets made by it merge their elements with those of t
+func (t *BSTSet[E]) Ordering() order.Ordering[E] {
+	return t.ordering
+}
+
+func (t *BSTSet[E]) mods() int {
+	return t.modCount
+}
+
+// Iterator walks the elements in ascending order
+func (t *BSTSet[E]) Iterator() iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsBSTSet, "BST invariant holds")
+
+	return newTreeSetIterator[E](t, false, nil, nil)
+}
+
+// All walks the elements in ascending order
+func (t *BSTSet[E]) All() iterator.Seq[E] {
+	contract.RequireInvariant(t.IsBSTSet, "BST invariant holds")
+
+	return iterator.FailFastSeq(t.tree.All(), t.mods)
+}
+
+// Descending walks the elements in descending order
+func (t *BSTSet[E]) Descending() iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsBSTSet, "BST invariant holds")
+
+	return newTreeSetIterator[E](t, true, nil, nil)
+}
+
+// Range walks the elements within [lo, hi) in ascending order
+func (t *BSTSet[E]) Range(lo, hi E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsBSTSet, "BST invariant holds")
+	contract.Require(t.comp(lo, hi) <= 0, "lo <= hi")
+
+	return newTreeSetIterator[E](t, false, atLeast(t.comp, lo), below(t.comp, hi))
+}
+
+// ReverseRange walks the elements within [lo, hi) in descending order
+func (t *BSTSet[E]) ReverseRange(lo, hi E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsBSTSet, "BST invariant holds")
+	contract.Require(t.comp(lo, hi) <= 0, "lo <= hi")
+
+	return newTreeSetIterator[E](t, true, below(t.comp, hi), atLeast(t.comp, lo))
+}
+
+// From walks the elements not less than x in ascending order
+func (t *BSTSet[E]) From(x E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsBSTSet, "BST invariant holds")
+
+	return newTreeSetIterator[E](t, false, atLeast(t.comp, x), nil)
+}
+
+// ReverseFrom walks the elements not greater than x in descending order
+func (t *BSTSet[E]) ReverseFrom(x E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsBSTSet, "BST invariant holds")
+
+	return newTreeSetIterator[E](t, true, atMost(t.comp, x), nil)
+}
+
+// combineWith merges with a tree set other in O(m+n), and looks up the elements of any other set
+func (t *BSTSet[E]) combineWith(other Set[E], keep func(inA, inB bool) bool) (result *BSTSet[E]) {
+	contract.RequireInvariant(t.IsBSTSet, "BST invariant holds")
+	contract.Require(other != nil, "other is not nil")
+	defer func() {
+		contract.EnsureInvariant(result.IsBSTSet, "BST invariant holds")
+	}()
+
+	result = NewBSTSetBy[E](t.ordering)
+	if !mergeable(other, t.ordering) {
+		combine[E](result, t, other, keep)
+		return
+	}
+
+	elements := mergeSorted[E](t.Iterator(), other.Iterator(), t.comp, keep)
+	result.tree.Root = tree.NewBalanced(elements)
+	result.size = len(elements)
+	return
+}
+
+func (t *BSTSet[E]) noneKeptWith(other Set[E], keep func(inA, inB bool) bool) bool {
+	contract.RequireInvariant(t.IsBSTSet, "BST invariant holds")
+	contract.Require(other != nil, "other is not nil")
+
+	if mergeable(other, t.ordering) {
+		return noneKeptSorted[E](t.Iterator(), other.Iterator(), t.comp, keep)
+	}
+	return noneKept[E](t, other, keep)
+}
+
+func (t *BSTSet[E]) Union(other Set[E]) Set[E] {
+	return t.combineWith(other, inUnion)
+}
+
+func (t *BSTSet[E]) Intersection(other Set[E]) Set[E] {
+	return t.combineWith(other, inIntersection)
+}
+
+func (t *BSTSet[E]) Difference(other Set[E]) Set[E] {
+	return t.combineWith(other, inDifference)
+}
+
+func (t *BSTSet[E]) SymmetricDifference(other Set[E]) Set[E] {
+	return t.combineWith(other, inSymmetricDifference)
+}
+
+func (t *BSTSet[E]) IsSubsetOf(other Set[E]) bool {
+	return t.noneKeptWith(other, inDifference)
+}
+
+func (t *BSTSet[E]) IsDisjoint(other Set[E]) bool {
+	return t.noneKeptWith(other, inIntersection)
+}
+
+func (t *BSTSet[E]) Equals(other Set[E]) bool {
+	return t.noneKeptWith(other, inSymmetricDifference)
+}
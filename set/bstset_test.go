@@ -2,6 +2,9 @@ package set
 
 import (
 	"github.com/song-flying/GoDataStructures/array"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -52,3 +55,77 @@ func TestBSTSet(t *testing.T) {
 		assert.Equal(t, len(a)-i-1, set.Size())
 	}
 }
+
+func TestBSTSet_Iterator(t *testing.T) {
+	var set Set[int] = NewBSTSet[int](order.IntComp)
+	assert.False(t, set.Iterator().HasNext())
+
+	a := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	array.Shuffle(a)
+	for _, e := range a {
+		set.Add(e)
+	}
+
+	var fromIterator []int
+	for it := set.Iterator(); it.HasNext(); {
+		fromIterator = append(fromIterator, it.Next())
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, fromIterator)
+
+	var fromAll []int
+	set.All()(func(e int) bool {
+		fromAll = append(fromAll, e)
+		return e < 5
+	})
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, fromAll)
+}
+
+func TestBSTSet_Range(t *testing.T) {
+	set := NewBSTSet[int](order.IntComp)
+	assert.False(t, set.Descending().HasNext())
+
+	a := []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}
+	array.Shuffle(a)
+	for _, e := range a {
+		set.Add(e)
+	}
+
+	assert.Equal(t, []int{18, 16, 14, 12, 10, 8, 6, 4, 2, 0}, iterator.Collect[int](set.Descending()))
+	assert.Equal(t, []int{4, 6, 8}, iterator.Collect[int](set.Range(3, 10)))
+	assert.Equal(t, []int{8, 6, 4}, iterator.Collect[int](set.ReverseRange(3, 10)))
+	assert.Empty(t, iterator.Collect[int](set.Range(4, 4)))
+	assert.Equal(t, []int{14, 16, 18}, iterator.Collect[int](set.From(14)))
+	assert.Equal(t, []int{4, 2, 0}, iterator.Collect[int](set.ReverseFrom(5)))
+	assert.Empty(t, iterator.Collect[int](set.From(19)))
+}
+
+func TestBSTSet_IteratorRemove(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+
+	set := NewBSTSet[int](order.IntComp)
+	a := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	array.Shuffle(a)
+	for _, e := range a {
+		set.Add(e)
+	}
+
+	for it := set.Range(2, 8); it.HasNext(); {
+		if it.Next()%2 == 1 {
+			it.Remove()
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2, 4, 6, 8, 9}, iterator.Collect[int](set.Iterator()))
+
+	for it := set.Descending(); it.HasNext(); {
+		if it.Next() > 4 {
+			it.Remove()
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2, 4}, iterator.Collect[int](set.Iterator()))
+	assert.Equal(t, 4, set.Size())
+
+	it := set.Iterator()
+	assert.Panics(t, func() { it.Remove() })
+	set.Delete(0)
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.HasNext() })
+}
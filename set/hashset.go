@@ -3,21 +3,32 @@ package set
 import (
 	"github.com/song-flying/GoDataStructures/linked"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/hash"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
 )
 
+// HashFn is the hash function type of the constructors comparing with ==, it converts to hash.Hasher[E]
 type HashFn[E comparable] func(E) int
 
-type HashSet[E comparable] struct {
-	size     int
-	capacity int
-	table    []linked.List[E]
-	hashFn   HashFn[E]
-	maxLoad  int
+type HashSet[E any] struct {
+	size       int
+	capacity   int
+	table      []*linked.Node[E]
+	hashFn     hash.KeyedHasher[E]
+	seed       hash.Seed
+	equalFn    order.Equaler[E]
+	maxLoad    int
+	modCount   int
+	chainLimit int
+	reseeds    int
+	resizes    int
+	probeLog   *hash.ProbeLog
 }
 
 func (h *HashSet[E]) hashOK() bool {
-	for i, l := range h.table {
-		for curr := l.Head; curr != nil; curr = curr.Next {
+	for i, chain := range h.table {
+		for curr := chain; curr != nil; curr = curr.Next {
 			hashIndex := h.indexOfElement(curr.Data)
 			if i != hashIndex {
 				return false
@@ -30,8 +41,10 @@ func (h *HashSet[E]) hashOK() bool {
 
 func (h *HashSet[E]) sizeOK() bool {
 	size := 0
-	for _, l := range h.table {
-		size += l.Length()
+	for _, chain := range h.table {
+		for curr := chain; curr != nil; curr = curr.Next {
+			size++
+		}
 	}
 
 	return h.size == size
@@ -39,25 +52,49 @@ func (h *HashSet[E]) sizeOK() bool {
 
 // data structure invariant
 func (h *HashSet[E]) isHashSet() bool {
-	return h != nil && 0 <= h.size && 0 < h.capacity &&
-		len(h.table) == h.capacity && 0 < h.maxLoad && h.hashOK() && h.sizeOK()
+	return h != nil && 0 <= h.size && 0 < h.capacity && len(h.table) == h.capacity &&
+		h.hashFn != nil && h.equalFn != nil && 0 < h.maxLoad && 0 < h.chainLimit && h.hashOK() && h.sizeOK()
 }
 
+// NewHashSet compares elements with ==, a nil hashFn stands for hash.OfKeyed[E](), which hashes strings with SipHash.
+// Only SipHash hashers resist flooding with colliding elements: an unkeyed hashFn, like the Hasher given to NewHashSetFunc,
+// is seeded after hashing, so elements colliding under it collide under every seed.
 func NewHashSet[E comparable](capacity int, hashFn HashFn[E], maxLoad int) (result *HashSet[E]) {
+	if hashFn == nil {
+		return NewHashSetKeyed[E](capacity, hash.OfKeyed[E](), order.Equal[E], maxLoad)
+	}
+
+	return NewHashSetFunc[E](capacity, hash.Hasher[E](hashFn), order.Equal[E], maxLoad)
+}
+
+// NewHashSetFunc compares elements with equalFn, hashFn must agree with it
+func NewHashSetFunc[E any](capacity int, hashFn hash.Hasher[E], equalFn order.Equaler[E], maxLoad int) (result *HashSet[E]) {
+	contract.Require(hashFn != nil, "hash function is not nil")
+
+	return NewHashSetKeyed[E](capacity, hash.Keyed(hashFn), equalFn, maxLoad)
+}
+
+// NewHashSetKeyed hashes with a KeyedHasher like hash.SipString, so that chains stay short even for elements chosen by an attacker.
+// Whenever a chain grows longer than maxLoad*hash.DefaultChainLimit, all elements are rehashed with a fresh random seed.
+func NewHashSetKeyed[E any](capacity int, hashFn hash.KeyedHasher[E], equalFn order.Equaler[E], maxLoad int) (result *HashSet[E]) {
 	contract.Require(0 < capacity, "capacity is positive")
 	contract.Require(0 < maxLoad, "maxLoad is positive")
 	contract.Require(hashFn != nil, "hash function is not nil")
+	contract.Require(equalFn != nil, "equality function is not nil")
 	defer func() {
-		contract.Ensure(result.isHashSet(), "hash set invariant holds")
+		contract.EnsureInvariant(result.isHashSet, "hash set invariant holds")
 	}()
 
-	table := make([]linked.List[E], capacity)
+	table := make([]*linked.Node[E], capacity)
 	return &HashSet[E]{
-		size:     0,
-		capacity: capacity,
-		table:    table,
-		hashFn:   hashFn,
-		maxLoad:  maxLoad,
+		size:       0,
+		capacity:   capacity,
+		table:      table,
+		hashFn:     hashFn,
+		seed:       hash.RandomSeed(),
+		equalFn:    equalFn,
+		maxLoad:    maxLoad,
+		chainLimit: maxLoad * hash.DefaultChainLimit,
 	}
 }
 
@@ -80,85 +117,96 @@ func (h *HashSet[E]) indexOfElement(key E) (result int) {
 		contract.Ensure(0 <= result && result < h.capacity, "result is within bound")
 	}()
 
-	return abs(h.hashFn(key) % h.capacity)
+	return int(uint64(h.hashFn(h.seed, key)) % uint64(h.capacity))
 }
 
-func (h *HashSet[E]) Contains(x E) bool {
-	contract.Require(h.isHashSet(), "hash set invariant holds")
-
-	index := h.indexOfElement(x)
-	l := &h.table[index]
-	for curr := l.Head; curr != nil; curr = curr.Next {
-		if curr.Data == x {
-			return true
+// find reports whether h contains x and the count of nodes it probed.
+// It records nothing, so that lookups made by contracts leave Stats alone.
+func (h *HashSet[E]) find(x E) (found bool, probes int) {
+	for curr := h.table[h.indexOfElement(x)]; curr != nil; curr = curr.Next {
+		probes++
+		if h.equalFn(curr.Data, x) {
+			return true, probes
 		}
 	}
 
-	return false
+	return false, probes
+}
+
+func (h *HashSet[E]) Contains(x E) bool {
+	contract.RequireInvariant(h.isHashSet, "hash set invariant holds")
+
+	found, probes := h.find(x)
+	h.probeLog.Record(probes)
+
+	return found
 }
 
 func (h *HashSet[E]) Add(x E) {
-	contract.Require(h.isHashSet(), "hash set invariant holds")
+	contract.RequireInvariant(h.isHashSet, "hash set invariant holds")
 	defer func() {
-		contract.Ensure(h.isHashSet(), "hash set invariant holds")
-		contract.Ensure(h.Contains(x), "hash set contains element x")
+		contract.EnsureInvariant(h.isHashSet, "hash set invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			found, _ := h.find(x)
+			contract.Ensure(found, "hash set contains element x")
+		}
 	}()
 
 	index := h.indexOfElement(x)
-	l := &h.table[index]
-	for curr := l.Head; curr != nil; curr = curr.Next {
-		if curr.Data == x {
+	length := 1
+	for curr := h.table[index]; curr != nil; curr = curr.Next {
+		if h.equalFn(curr.Data, x) {
 			return
 		}
+		length++
 	}
 
 	newHead := linked.NewNode(x)
-	newHead.Next = l.Head
-	l.Head = &newHead
+	newHead.Next = h.table[index]
+	h.table[index] = &newHead
 	h.size++
+	h.modCount++
 
 	if h.size >= h.capacity*h.maxLoad {
 		h.resize(h.capacity * 2)
+	} else if length > h.chainLimit {
+		h.reseed(x)
 	}
 }
 
 func (h *HashSet[E]) Delete(x E) {
-	contract.Require(h.isHashSet(), "hash set invariant holds")
+	contract.RequireInvariant(h.isHashSet, "hash set invariant holds")
 	defer func() {
-		contract.Ensure(h.isHashSet(), "hash set invariant holds")
-		contract.Ensure(!h.Contains(x), "hash set does not contain element x")
+		contract.EnsureInvariant(h.isHashSet, "hash set invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			found, _ := h.find(x)
+			contract.Ensure(!found, "hash set does not contain element x")
+		}
 	}()
 
-	index := h.indexOfElement(x)
-	l := &h.table[index]
-	if l.Head == nil {
-		return
+	if h.unlink(x) && 4*h.size <= h.capacity*h.maxLoad {
+		h.resize((h.capacity + 1) / 2)
 	}
+}
 
-	isDeleted := false
-	for curr := &l.Head; *curr != nil; curr = &(*curr).Next {
-		if (*curr).Data == x {
+// unlink removes x from its chain without shrinking the table, so that iterators can remove too
+func (h *HashSet[E]) unlink(x E) bool {
+	for curr := &h.table[h.indexOfElement(x)]; *curr != nil; curr = &(*curr).Next {
+		if h.equalFn((*curr).Data, x) {
 			target := *curr
 			*curr = target.Next
 			target.Next = nil
-			isDeleted = true
-			break
-		}
-		if (*curr).Next == nil {
-			break
+			h.size--
+			h.modCount++
+			return true
 		}
 	}
 
-	if isDeleted {
-		h.size--
-		if 4*h.size <= h.capacity*h.maxLoad {
-			h.resize((h.capacity + 1) / 2)
-		}
-	}
+	return false
 }
 
 func (h *HashSet[E]) Size() (result int) {
-	contract.Require(h.isHashSet(), "hash set invariant holds")
+	contract.RequireInvariant(h.isHashSet, "hash set invariant holds")
 	defer func() {
 		contract.Ensure(result >= 0, "result is non-negative")
 	}()
@@ -167,9 +215,9 @@ func (h *HashSet[E]) Size() (result int) {
 }
 
 func (h *HashSet[E]) resize(newCapacity int) {
-	contract.Require(h.isHashSet(), "hash set invariant holds")
+	contract.RequireInvariant(h.isHashSet, "hash set invariant holds")
 	defer func(oldSize int) {
-		contract.Ensure(h.isHashSet(), "hash set invariant holds")
+		contract.EnsureInvariant(h.isHashSet, "hash set invariant holds")
 		contract.Ensure(oldSize == h.size, "resize does not change count of entries")
 		contract.Ensure(newCapacity == h.capacity, "resize changes capacity")
 	}(h.size)
@@ -178,18 +226,246 @@ func (h *HashSet[E]) resize(newCapacity int) {
 		return
 	}
 
+	h.rehash(newCapacity)
+	h.resizes++
+}
+
+// rehash moves all nodes into a new table of newCapacity chains, placed by the current seed
+func (h *HashSet[E]) rehash(newCapacity int) {
 	oldTable := h.table
-	h.table = make([]linked.List[E], newCapacity)
+	h.table = make([]*linked.Node[E], newCapacity)
 	h.capacity = newCapacity
-	h.size = 0
 
-	for _, l := range oldTable {
-		for curr := l.Head; curr != nil; curr = curr.Next {
-			h.Add(curr.Data)
+	for _, chain := range oldTable {
+		for curr := chain; curr != nil; {
+			next := curr.Next
+			index := h.indexOfElement(curr.Data)
+			curr.Next = h.table[index]
+			h.table[index] = curr
+			curr = next
+		}
+	}
+	h.modCount++
+}
+
+// reseed rehashes with a fresh random seed once the chain of x got too long, which takes bad luck or an attack.
+// If the chain is still too long, its elements collide under every seed, so chainLimit is doubled to not rehash on every insertion.
+func (h *HashSet[E]) reseed(x E) {
+	h.seed = hash.RandomSeed()
+	h.rehash(h.capacity)
+	h.reseeds++
+
+	if h.chainLength(h.indexOfElement(x)) > h.chainLimit {
+		h.chainLimit *= 2
+	}
+}
+
+func (h *HashSet[E]) chainLength(index int) (result int) {
+	for curr := h.table[index]; curr != nil; curr = curr.Next {
+		result++
+	}
+
+	return
+}
+
+// LongestChain returns the length of the longest chain
+func (h *HashSet[E]) LongestChain() (result int) {
+	contract.RequireInvariant(h.isHashSet, "hash set invariant holds")
+
+	for i := range h.table {
+		if n := h.chainLength(i); n > result {
+			result = n
+		}
+	}
+
+	return
+}
+
+// RecordProbes makes Stats report the probes of the last n lookups by Contains, 0 stops recording
+func (h *HashSet[E]) RecordProbes(n int) {
+	contract.Require(0 <= n, "n is non-negative")
+
+	if n == 0 {
+		h.probeLog = nil
+	} else {
+		h.probeLog = hash.NewProbeLog(n)
+	}
+}
+
+// Stats takes O(capacity) to walk all chains
+func (h *HashSet[E]) Stats() (result hash.TableStats) {
+	contract.RequireInvariant(h.isHashSet, "hash set invariant holds")
+	defer func() {
+		contract.Ensure(len(result.ChainLengths) > 0 && result.ChainLengths[len(result.ChainLengths)-1] > 0, "histogram ends with the longest chain")
+	}()
+
+	result = hash.TableStats{
+		Capacity:   h.capacity,
+		Size:       h.size,
+		LoadFactor: float64(h.size) / float64(h.capacity),
+		Resizes:    h.resizes,
+		Reseeds:    h.reseeds,
+		Probes:     h.probeLog.Last(),
+	}
+	for i := range h.table {
+		n := h.chainLength(i)
+		for len(result.ChainLengths) <= n {
+			result.ChainLengths = append(result.ChainLengths, 0)
 		}
+		result.ChainLengths[n]++
 	}
+	result.LongestChain = len(result.ChainLengths) - 1
+
+	return
+}
+
+// Reseeds returns how often a too long chain made this hash set pick a fresh seed
+func (h *HashSet[E]) Reseeds() int {
+	return h.reseeds
+}
+
+// Seed returns the seed keying the hash function of this hash set
+func (h *HashSet[E]) Seed() hash.Seed {
+	return h.seed
+}
+
+// SetSeed rehashes all entries with seed, which is random by default
+func (h *HashSet[E]) SetSeed(seed hash.Seed) {
+	contract.RequireInvariant(h.isHashSet, "hash set invariant holds")
+	defer func(oldSize int) {
+		contract.EnsureInvariant(h.isHashSet, "hash set invariant holds")
+		contract.Ensure(oldSize == h.size, "rehashing does not change count of entries")
+		contract.Ensure(h.seed == seed, "rehashing changes seed")
+	}(h.size)
+
+	h.seed = seed
+	h.rehash(h.capacity)
 }
 
 func (h *HashSet[E]) IsEmpty() bool {
 	return h.Size() == 0
 }
+
+type hashSetIterator[E any] struct {
+	h        *HashSet[E]
+	index    int
+	curr     *linked.Node[E]
+	last     *linked.Node[E]
+	modCount int
+}
+
+func (it *hashSetIterator[E]) skipEmptyChains() {
+	for it.curr == nil && it.index < len(it.h.table) {
+		it.curr = it.h.table[it.index]
+		it.index++
+	}
+}
+
+func (it *hashSetIterator[E]) HasNext() bool {
+	iterator.CheckMods(it.h.modCount, it.modCount)
+
+	return it.curr != nil
+}
+
+func (it *hashSetIterator[E]) Next() E {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	it.last = it.curr
+	it.curr = it.curr.Next
+	it.skipEmptyChains()
+
+	return it.last.Data
+}
+
+// Remove deletes the element last returned by Next, the table is not shrunk until the next Delete
+func (it *hashSetIterator[E]) Remove() {
+	iterator.CheckMods(it.h.modCount, it.modCount)
+	contract.Require(it.last != nil, "Next is called after the last Remove")
+	defer func() {
+		contract.EnsureInvariant(it.h.isHashSet, "hash set invariant holds")
+	}()
+
+	it.h.unlink(it.last.Data)
+	it.last = nil
+	it.modCount = it.h.modCount
+}
+
+// Iterator walks the elements in no particular order
+func (h *HashSet[E]) Iterator() iterator.MutableIterator[E] {
+	contract.RequireInvariant(h.isHashSet, "hash set invariant holds")
+
+	it := &hashSetIterator[E]{
+		h:        h,
+		modCount: h.modCount,
+	}
+	it.skipEmptyChains()
+
+	return it
+}
+
+// All walks the elements in no particular order
+func (h *HashSet[E]) All() iterator.Seq[E] {
+	contract.RequireInvariant(h.isHashSet, "hash set invariant holds")
+
+	return iterator.FailFastSeq(func(yield func(E) bool) {
+		for _, chain := range h.table {
+			for curr := chain; curr != nil; curr = curr.Next {
+				if !yield(curr.Data) {
+					return
+				}
+			}
+		}
+	}, func() int { return h.modCount })
+}
+
+// empty returns an empty set hashing and comparing elements like h, with a seed of its own
+func (h *HashSet[E]) empty() *HashSet[E] {
+	return NewHashSetKeyed[E](h.capacity, h.hashFn, h.equalFn, h.maxLoad)
+}
+
+func (h *HashSet[E]) combineWith(other Set[E], keep func(inA, inB bool) bool) (result *HashSet[E]) {
+	contract.RequireInvariant(h.isHashSet, "hash set invariant holds")
+	contract.Require(other != nil, "other is not nil")
+	defer func() {
+		contract.EnsureInvariant(result.isHashSet, "hash set invariant holds")
+	}()
+
+	result = h.empty()
+	combine[E](result, h, other, keep)
+	return
+}
+
+func (h *HashSet[E]) noneKeptWith(other Set[E], keep func(inA, inB bool) bool) bool {
+	contract.RequireInvariant(h.isHashSet, "hash set invariant holds")
+	contract.Require(other != nil, "other is not nil")
+
+	return noneKept[E](h, other, keep)
+}
+
+func (h *HashSet[E]) Union(other Set[E]) Set[E] {
+	return h.combineWith(other, inUnion)
+}
+
+func (h *HashSet[E]) Intersection(other Set[E]) Set[E] {
+	return h.combineWith(other, inIntersection)
+}
+
+func (h *HashSet[E]) Difference(other Set[E]) Set[E] {
+	return h.combineWith(other, inDifference)
+}
+
+func (h *HashSet[E]) SymmetricDifference(other Set[E]) Set[E] {
+	return h.combineWith(other, inSymmetricDifference)
+}
+
+func (h *HashSet[E]) IsSubsetOf(other Set[E]) bool {
+	return h.noneKeptWith(other, inDifference)
+}
+
+func (h *HashSet[E]) IsDisjoint(other Set[E]) bool {
+	return h.noneKeptWith(other, inIntersection)
+}
+
+func (h *HashSet[E]) Equals(other Set[E]) bool {
+	return h.noneKeptWith(other, inSymmetricDifference)
+}
@@ -1,9 +1,13 @@
 package set
 
 import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
 	"github.com/song-flying/GoDataStructures/pkg/hash"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
 	"github.com/stretchr/testify/assert"
 	"strconv"
+	"sync"
 	"testing"
 )
 
@@ -41,3 +45,213 @@ func TestHashSet(t *testing.T) {
 	}
 	assert.Equal(t, 4, set.capacity)
 }
+
+func TestHashSet_Iterator(t *testing.T) {
+	var set Set[string] = NewHashSet[string](1, hash.String, 1)
+	assert.False(t, set.Iterator().HasNext())
+
+	var elements []string
+	for i := 0; i < 10; i++ {
+		elements = append(elements, strconv.Itoa(i))
+		set.Add(strconv.Itoa(i))
+	}
+
+	var fromIterator []string
+	for it := set.Iterator(); it.HasNext(); {
+		fromIterator = append(fromIterator, it.Next())
+	}
+	assert.ElementsMatch(t, elements, fromIterator)
+
+	var fromAll []string
+	set.All()(func(e string) bool {
+		fromAll = append(fromAll, e)
+		return len(fromAll) < 3
+	})
+	assert.Equal(t, fromIterator[:3], fromAll)
+}
+
+func TestHashSet_CollectInto(t *testing.T) {
+	words := iterator.FromSlice([]string{"a", "b", "a", "c"})
+	set := iterator.CollectInto[string](words, NewHashSet[string](1, hash.String, 1))
+	assert.Equal(t, 3, set.Size())
+}
+
+func TestHashSet_IteratorRemove(t *testing.T) {
+	set := NewHashSet[int](1, hash.Universal[int], 1)
+	for i := 0; i < 10; i++ {
+		set.Add(i)
+	}
+
+	for it := set.Iterator(); it.HasNext(); {
+		if it.Next()%2 == 1 {
+			it.Remove()
+		}
+	}
+	assert.ElementsMatch(t, []int{0, 2, 4, 6, 8}, iterator.Collect[int](set.Iterator()))
+	assert.Equal(t, 5, set.Size())
+
+	it := set.Iterator()
+	set.Add(10)
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.HasNext() })
+
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() {
+		set.All()(func(x int) bool {
+			set.Delete(x)
+			return true
+		})
+	})
+}
+
+func TestHashSet_CustomEquality(t *testing.T) {
+	set := NewHashSetFunc[string](1, hash.CaseInsensitiveString, order.Equivalent(order.CaseInsensitiveComp), 1)
+	set.Add("Go")
+	set.Add("GO")
+	set.Add("Rust")
+
+	assert.Equal(t, 2, set.Size())
+	assert.True(t, set.Contains("go"))
+
+	set.Delete("rUST")
+	assert.False(t, set.Contains("Rust"))
+	assert.Equal(t, 1, set.Size())
+}
+
+func TestHashSet_DefaultHash(t *testing.T) {
+	set := NewHashSet[string](1, nil, 1)
+	for i := 0; i < 100; i++ {
+		set.Add(strconv.Itoa(i))
+	}
+
+	assert.Equal(t, 100, set.Size())
+	assert.True(t, set.Contains("42"))
+	assert.False(t, set.Contains("100"))
+}
+
+func TestHashSet_Seed(t *testing.T) {
+	a := NewHashSet[int](1, nil, 1)
+	b := NewHashSet[int](1, nil, 1)
+	assert.NotEqual(t, a.Seed(), b.Seed())
+
+	for i := 0; i < 100; i++ {
+		a.Add(i)
+		b.Add(i)
+	}
+	b.SetSeed(a.Seed())
+	assert.Equal(t, a.Seed(), b.Seed())
+	assert.Equal(t, 100, b.Size())
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, a.indexOfElement(i), b.indexOfElement(i))
+	}
+}
+
+// run with -race, readers share one set while writers own theirs
+func TestHashSet_Concurrent(t *testing.T) {
+	const goroutines, n = 8, 200
+
+	shared := NewHashSet[string](1, nil, 1)
+	for i := 0; i < n; i++ {
+		shared.Add(strconv.Itoa(i))
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				assert.True(t, shared.Contains(strconv.Itoa(i)))
+			}
+		}()
+		go func(g int) {
+			defer wg.Done()
+			own := NewHashSet[string](1, nil, 1)
+			own.SetSeed(hash.FixedSeed(uint64(g), 0))
+			for i := 0; i < n; i++ {
+				own.Add(strconv.Itoa(i))
+			}
+			assert.Equal(t, n, own.Size())
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestHashSet_Reseed(t *testing.T) {
+	set := NewHashSetKeyed[string](1024, hash.SipString, order.Equal[string], 1)
+	set.SetSeed(hash.FixedSeed(1, 2))
+
+	// elements sharing a chain under the known seed, as an attacker would pick them
+	var elements []string
+	for i := 0; len(elements) <= hash.DefaultChainLimit; i++ {
+		if x := strconv.Itoa(i); set.indexOfElement(x) == 0 {
+			elements = append(elements, x)
+		}
+	}
+	for _, x := range elements {
+		set.Add(x)
+	}
+
+	assert.Equal(t, 1, set.Reseeds())
+	assert.NotEqual(t, hash.FixedSeed(1, 2), set.Seed())
+	assert.LessOrEqual(t, set.LongestChain(), hash.DefaultChainLimit)
+	for _, x := range elements {
+		assert.True(t, set.Contains(x))
+	}
+}
+
+func TestHashSet_ReseedCollidingHasher(t *testing.T) {
+	// all elements collide under every seed, reseeding gives up instead of rehashing on every Add
+	set := NewHashSet[int](1024, func(int) int { return 0 }, 1)
+	for i := 0; i < 100; i++ {
+		set.Add(i)
+	}
+
+	assert.Equal(t, 100, set.LongestChain())
+	assert.LessOrEqual(t, set.Reseeds(), 4)
+	for i := 0; i < 100; i++ {
+		assert.True(t, set.Contains(i))
+	}
+}
+
+func TestHashSet_Stats(t *testing.T) {
+	set := NewHashSet[int](1, nil, 1)
+	for i := 0; i < 100; i++ {
+		set.Add(i)
+	}
+
+	stats := set.Stats()
+	assert.Equal(t, 128, stats.Capacity)
+	assert.Equal(t, 100, stats.Size)
+	assert.InDelta(t, 100.0/128, stats.LoadFactor, 1e-9)
+	assert.Equal(t, 7, stats.Resizes)
+	assert.Equal(t, set.LongestChain(), stats.LongestChain)
+	chains, elements := 0, 0
+	for n, count := range stats.ChainLengths {
+		chains += count
+		elements += n * count
+	}
+	assert.Equal(t, stats.Capacity, chains)
+	assert.Equal(t, stats.Size, elements)
+
+	set.RecordProbes(10)
+	for i := 0; i < 200; i++ {
+		set.Contains(i)
+	}
+	assert.Len(t, set.Stats().Probes, 10)
+}
+
+func TestHashSet_ProbesIgnoreContracts(t *testing.T) {
+	for _, level := range []contract.Level{contract.LevelOff, contract.LevelFull} {
+		restore := contract.SetLevel(level)
+		set := NewHashSet[int](1, nil, 1)
+		set.RecordProbes(10)
+		for i := 0; i < 20; i++ {
+			set.Add(i)
+			set.Delete(i - 1)
+		}
+		assert.Empty(t, set.Stats().Probes, "level %v", level)
+
+		set.Contains(0)
+		assert.Len(t, set.Stats().Probes, 1, "level %v", level)
+		contract.SetLevel(restore)
+	}
+}
@@ -0,0 +1,87 @@
+package set
+
+import (
+	"github.com/song-flying/GoDataStructures/array"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// testRandomUpdates runs random deletes and adds of elements below n against a map
+func testRandomUpdates(t *testing.T, set Set[int], seed int64, n, steps int, isValid func() bool) {
+	r := rand.New(rand.NewSource(seed))
+	expected := map[int]bool{}
+	for i := 0; i < steps; i++ {
+		x := r.Intn(n)
+		if r.Intn(3) == 0 {
+			set.Delete(x)
+			delete(expected, x)
+		} else {
+			set.Add(x)
+			expected[x] = true
+		}
+		assert.Equal(t, len(expected), set.Size())
+	}
+
+	assert.True(t, isValid())
+	for x := 0; x < n; x++ {
+		assert.Equal(t, expected[x], set.Contains(x))
+	}
+	assert.True(t, array.IsSorted(iterator.Collect[int](set.Iterator()), order.IntComp))
+}
+
+// invariantBreak corrupts a set with apply, undo puts it back
+type invariantBreak struct {
+	name        string
+	apply, undo func()
+}
+
+// testInvariantBreaks checks that isValid fails after each break and holds again after its undo
+func testInvariantBreaks(t *testing.T, isValid func() bool, breaks []invariantBreak) {
+	assert.True(t, isValid())
+	for _, b := range breaks {
+		b.apply()
+		assert.False(t, isValid(), b.name)
+		b.undo()
+		assert.True(t, isValid(), b.name)
+	}
+}
+
+func benchmarkTreeSet(b *testing.B, newSet func() Set[int]) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelOff))
+	const n = 1 << 14
+	keys := rand.New(rand.NewSource(1)).Perm(n)
+
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			set := newSet()
+			for _, x := range keys {
+				set.Add(x)
+			}
+		}
+	})
+	b.Run("AddDelete", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			set := newSet()
+			for _, x := range keys {
+				set.Add(x)
+			}
+			for _, x := range keys {
+				set.Delete(x)
+			}
+		}
+	})
+	b.Run("Contains", func(b *testing.B) {
+		set := newSet()
+		for _, x := range keys {
+			set.Add(x)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			set.Contains(keys[i%n])
+		}
+	})
+}
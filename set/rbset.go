@@ -0,0 +1,537 @@
+package set
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/song-flying/GoDataStructures/tree"
+	"math/bits"
+)
+
+// RBSet is a red-black tree set, rebalanced with at most two rotations per insertion and three per removal
+type RBSet[E comparable] struct {
+	tree      *tree.RBTree[E]
+	comp      order.CompareFn[E]
+	ordering  order.Ordering[E]
+	size      int
+	modCount  int
+	rotations int
+}
+
+func (t *RBSet[E]) isOrderedWithMinMax(root *tree.RBNode[E]) (minElement, maxElement *E, isOrdered bool) {
+	contract.Require(t.comp != nil, "comparison function is not nil")
+	contract.RequireInvariant(root.IsBinaryTree, "root is a binary tree")
+
+	if root == nil {
+		return nil, nil, true
+	}
+
+	if root.Left != nil {
+		leftMin, leftMax, leftIsOrdered := t.isOrderedWithMinMax(root.Left)
+		if !leftIsOrdered || t.comp(*leftMax, root.Data) >= 0 {
+			return nil, nil, false
+		} else {
+			minElement = leftMin
+		}
+	} else {
+		minElement = &root.Data
+	}
+
+	if root.Right != nil {
+		rightMin, rightMax, rightIsOrdered := t.isOrderedWithMinMax(root.Right)
+		if !rightIsOrdered || t.comp(*rightMin, root.Data) <= 0 {
+			return nil, nil, false
+		} else {
+			maxElement = rightMax
+		}
+	} else {
+		maxElement = &root.Data
+	}
+
+	return minElement, maxElement, true
+}
+
+// isColourOKFrom checks that no red node has a red child
+func (t *RBSet[E]) isColourOKFrom(root *tree.RBNode[E]) bool {
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
+	return root == nil || t.isColourOKFrom(root.Left) && t.isColourOKFrom(root.Right) &&
+		!(root.IsRed() && (root.Left.IsRed() || root.Right.IsRed()))
+}
+
+// blackHeight counts the black nodes on every path from root down to a leaf, ok is false if they differ
+func (t *RBSet[E]) blackHeight(root *tree.RBNode[E]) (height int, ok bool) {
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
+
+	if root == nil {
+		return 0, true
+	}
+
+	leftHeight, leftOK := t.blackHeight(root.Left)
+	rightHeight, rightOK := t.blackHeight(root.Right)
+	if !leftOK || !rightOK || leftHeight != rightHeight {
+		return 0, false
+	}
+	if root.IsRed() {
+		return leftHeight, true
+	}
+	return leftHeight + 1, true
+}
+
+func (t *RBSet[E]) sizeOK() bool {
+	return t.size == iterator.Count[E](t.tree.Iterator())
+}
+
+// IsRBSet data structure invariant
+func (t *RBSet[E]) IsRBSet() bool {
+	return t.tree != nil && t.IsRBTree(t.tree.Root) && t.sizeOK()
+}
+
+// IsRBTree checks that root is an ordered, black red-black tree with the same black height on every path
+func (t *RBSet[E]) IsRBTree(root *tree.RBNode[E]) bool {
+	_, _, isOrdered := t.isOrderedWithMinMax(root)
+	_, isBlackHeightOK := t.blackHeight(root)
+	return t.comp != nil && root.IsBinaryTree() && isOrdered && !root.IsRed() && t.isColourOKFrom(root) && isBlackHeightOK
+}
+
+func NewRBSet[E comparable](comp order.CompareFn[E]) *RBSet[E] {
+	contract.Require(comp != nil, "comparison function is not nil")
+
+	return NewRBSetBy(order.OrderingOf(comp))
+}
+
+// NewRBSetBy makes a set ordered by ordering, whose elements merge with those of other sets made by it
+func NewRBSetBy[E comparable](ordering order.Ordering[E]) (result *RBSet[E]) {
+	contract.Require(ordering.Compare != nil, "comparison function is not nil")
+	defer func() {
+		contract.EnsureInvariant(result.IsRBSet, "red-black invariant holds")
+	}()
+
+	t := tree.NewRBTree[E](nil)
+
+	return &RBSet[E]{
+		tree:     t,
+		comp:     ordering.Compare,
+		ordering: ordering,
+		size:     0,
+	}
+}
+
+func (t *RBSet[E]) Contains(element E) bool {
+	contract.RequireInvariant(t.IsRBSet, "red-black invariant holds")
+
+	for node := t.tree.Root; node != nil; {
+		compResult := t.comp(element, node.Data)
+		switch {
+		case compResult == 0:
+			return true
+		case compResult < 0:
+			node = node.Left
+		default: // compResult > 0
+			node = node.Right
+		}
+	}
+
+	return false
+}
+
+func (t *RBSet[E]) rotateLeft(root *tree.RBNode[E]) *tree.RBNode[E] {
+	contract.Require(root != nil && root.Right != nil, "root has a right child")
+
+	right := root.Right
+	root.Right = right.Left
+	right.Left = root
+	t.rotations++
+
+	return right
+}
+
+func (t *RBSet[E]) rotateRight(root *tree.RBNode[E]) *tree.RBNode[E] {
+	contract.Require(root != nil && root.Left != nil, "root has a left child")
+
+	left := root.Left
+	root.Left = left.Right
+	left.Right = root
+	t.rotations++
+
+	return left
+}
+
+// fixRed repairs a red child of root with a red child, left by an insertion below it.
+// A red sibling takes the black of root, which passes the repair up, otherwise at most two rotations end it.
+func (t *RBSet[E]) fixRed(root *tree.RBNode[E]) *tree.RBNode[E] {
+	switch {
+	case root.Left.IsRed() && (root.Left.Left.IsRed() || root.Left.Right.IsRed()):
+		if root.Right.IsRed() {
+			root.Red, root.Left.Red, root.Right.Red = true, false, false
+			return root
+		}
+		if root.Left.Right.IsRed() {
+			root.Left = t.rotateLeft(root.Left)
+		}
+		root = t.rotateRight(root)
+		root.Red, root.Right.Red = false, true
+	case root.Right.IsRed() && (root.Right.Right.IsRed() || root.Right.Left.IsRed()):
+		if root.Left.IsRed() {
+			root.Red, root.Left.Red, root.Right.Red = true, false, false
+			return root
+		}
+		if root.Right.Left.IsRed() {
+			root.Right = t.rotateRight(root.Right)
+		}
+		root = t.rotateLeft(root)
+		root.Red, root.Left.Red = false, true
+	}
+
+	return root
+}
+
+func (t *RBSet[E]) insertFrom(root *tree.RBNode[E], element E) *tree.RBNode[E] {
+	if root == nil {
+		node := tree.NewRBNode[E](element)
+		node.Red = true
+		t.size++
+		t.modCount++
+		return &node
+	}
+
+	compResult := t.comp(element, root.Data)
+	switch {
+	case compResult == 0:
+		root.Data = element
+		return root
+	case compResult < 0:
+		root.Left = t.insertFrom(root.Left, element) // invariant broken (maybe two red nodes in a row)
+	default: //compResult > 0:
+		root.Right = t.insertFrom(root.Right, element) // invariant broken (maybe two red nodes in a row)
+	}
+
+	return t.fixRed(root) // invariant restored, up to a red root with a red child
+}
+
+func (t *RBSet[E]) Add(element E) {
+	contract.RequireInvariant(t.IsRBSet, "red-black invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsRBSet, "red-black invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(t.Contains(element), "Contains(element) returns true")
+		}
+	}()
+
+	t.tree.Root = t.insertFrom(t.tree.Root, element)
+	t.tree.Root.Red = false
+}
+
+// fixLeftShort repairs root after a removal took one black node off the paths through root.Left.
+// A red sibling is rotated up first, after that at most two rotations end the repair,
+// short reports whether only recolouring was possible, so that the paths through root are still one black node short.
+func (t *RBSet[E]) fixLeftShort(root *tree.RBNode[E]) (result *tree.RBNode[E], short bool) {
+	contract.Require(root != nil && root.Right != nil, "root has a right child")
+
+	if root.Right.IsRed() {
+		result = t.rotateLeft(root)
+		result.Red, root.Red = false, true
+		result.Left, _ = t.fixLeftShort(root) // root is red now, so the repair ends below
+		return result, false
+	}
+
+	sibling := root.Right
+	if !sibling.Left.IsRed() && !sibling.Right.IsRed() {
+		sibling.Red = true
+		short = !root.Red
+		root.Red = false
+		return root, short
+	}
+
+	if !sibling.Right.IsRed() {
+		root.Right = t.rotateRight(sibling)
+		root.Right.Red, sibling.Red = false, true
+	}
+	result = t.rotateLeft(root)
+	result.Red, root.Red, result.Right.Red = root.Red, false, false
+
+	return result, false
+}
+
+// fixRightShort mirrors fixLeftShort
+func (t *RBSet[E]) fixRightShort(root *tree.RBNode[E]) (result *tree.RBNode[E], short bool) {
+	contract.Require(root != nil && root.Left != nil, "root has a left child")
+
+	if root.Left.IsRed() {
+		result = t.rotateRight(root)
+		result.Red, root.Red = false, true
+		result.Right, _ = t.fixRightShort(root) // root is red now, so the repair ends below
+		return result, false
+	}
+
+	sibling := root.Left
+	if !sibling.Left.IsRed() && !sibling.Right.IsRed() {
+		sibling.Red = true
+		short = !root.Red
+		root.Red = false
+		return root, short
+	}
+
+	if !sibling.Left.IsRed() {
+		root.Left = t.rotateLeft(sibling)
+		root.Left.Red, sibling.Red = false, true
+	}
+	result = t.rotateRight(root)
+	result.Red, root.Red, result.Left.Red = root.Red, false, false
+
+	return result, false
+}
+
+// unlink removes root, which has at most one child, and returns that child
+func (t *RBSet[E]) unlink(root *tree.RBNode[E]) (result *tree.RBNode[E], short bool) {
+	contract.Require(root != nil && (root.Left == nil || root.Right == nil), "root has at most one child")
+
+	t.size--
+	t.modCount++
+	result = root.Left
+	if result == nil {
+		result = root.Right
+	}
+	if root.IsRed() {
+		return result, false
+	}
+	if result.IsRed() {
+		result.Red = false
+		return result, false
+	}
+
+	return result, true
+}
+
+// removeMin removes the least element below root
+func (t *RBSet[E]) removeMin(root *tree.RBNode[E]) (result *tree.RBNode[E], min E, short bool) {
+	contract.Require(root != nil, "root is not nil")
+
+	if root.Left == nil {
+		result, short = t.unlink(root)
+		return result, root.Data, short
+	}
+
+	root.Left, min, short = t.removeMin(root.Left)
+	if short {
+		root, short = t.fixLeftShort(root)
+	}
+
+	return root, min, short
+}
+
+// removeNode removes the element of root, taking the least element on its right when it has two children
+func (t *RBSet[E]) removeNode(root *tree.RBNode[E]) (result *tree.RBNode[E], short bool) {
+	if root.Left == nil || root.Right == nil {
+		return t.unlink(root)
+	}
+
+	root.Right, root.Data, short = t.removeMin(root.Right)
+	if short {
+		return t.fixRightShort(root)
+	}
+
+	return root, false
+}
+
+// removeFrom removes element if it is below root, short reports whether the paths through root lost a black node
+func (t *RBSet[E]) removeFrom(root *tree.RBNode[E], element E) (result *tree.RBNode[E], short bool) {
+	if root == nil {
+		return nil, false
+	}
+
+	compResult := t.comp(element, root.Data)
+	switch {
+	case compResult == 0:
+		return t.removeNode(root)
+	case compResult < 0:
+		if root.Left, short = t.removeFrom(root.Left, element); short {
+			return t.fixLeftShort(root)
+		}
+	default: // compResult > 0
+		if root.Right, short = t.removeFrom(root.Right, element); short {
+			return t.fixRightShort(root)
+		}
+	}
+
+	return root, false
+}
+
+func (t *RBSet[E]) Delete(element E) {
+	contract.RequireInvariant(t.IsRBSet, "red-black invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsRBSet, "red-black invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(!t.Contains(element), "Contains(element) returns false")
+		}
+	}()
+
+	t.tree.Root, _ = t.removeFrom(t.tree.Root, element)
+	if t.tree.Root != nil {
+		t.tree.Root.Red = false
+	}
+}
+
+func (t *RBSet[E]) Size() (result int) {
+	contract.RequireInvariant(t.IsRBSet, "red-black invariant holds")
+	defer func() {
+		contract.Ensure(0 <= result, "result is non-negative")
+	}()
+
+	return t.size
+}
+
+func (t *RBSet[E]) IsEmpty() bool {
+	return t.Size() == 0
+}
+
+func (t *RBSet[E]) walk(descending bool, afterStart, beforeEnd func(E) bool) *tree.OrderedIterator[E] {
+	return tree.NewRBOrderedIterator(t.tree.Root, descending, afterStart, beforeEnd)
+}
+
+func (t *RBSet[E]) compare(a, b E) int {
+	return t.comp(a, b)
+}
+
+// Ordering returns the ordering of t, sets made by it merge their elements with those of t
+func (t *RBSet[E]) Ordering() order.Ordering[E] {
+	return t.ordering
+}
+
+func (t *RBSet[E]) mods() int {
+	return t.modCount
+}
+
+// Iterator walks the elements in ascending order
+func (t *RBSet[E]) Iterator() iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsRBSet, "red-black invariant holds")
+
+	return newTreeSetIterator[E](t, false, nil, nil)
+}
+
+// All walks the elements in ascending order
+func (t *RBSet[E]) All() iterator.Seq[E] {
+	contract.RequireInvariant(t.IsRBSet, "red-black invariant holds")
+
+	return iterator.FailFastSeq(t.tree.All(), t.mods)
+}
+
+// Descending walks the elements in descending order
+func (t *RBSet[E]) Descending() iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsRBSet, "red-black invariant holds")
+
+	return newTreeSetIterator[E](t, true, nil, nil)
+}
+
+// Range walks the elements within [lo, hi) in ascending order
+func (t *RBSet[E]) Range(lo, hi E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsRBSet, "red-black invariant holds")
+	contract.Require(t.comp(lo, hi) <= 0, "lo <= hi")
+
+	return newTreeSetIterator[E](t, false, atLeast(t.comp, lo), below(t.comp, hi))
+}
+
+// ReverseRange walks the elements within [lo, hi) in descending order
+func (t *RBSet[E]) ReverseRange(lo, hi E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsRBSet, "red-black invariant holds")
+	contract.Require(t.comp(lo, hi) <= 0, "lo <= hi")
+
+	return newTreeSetIterator[E](t, true, below(t.comp, hi), atLeast(t.comp, lo))
+}
+
+// From walks the elements not less than x in ascending order
+func (t *RBSet[E]) From(x E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsRBSet, "red-black invariant holds")
+
+	return newTreeSetIterator[E](t, false, atLeast(t.comp, x), nil)
+}
+
+// ReverseFrom walks the elements not greater than x in descending order
+func (t *RBSet[E]) ReverseFrom(x E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsRBSet, "red-black invariant holds")
+
+	return newTreeSetIterator[E](t, true, atMost(t.comp, x), nil)
+}
+
+// newRBTree links sorted elements into a balanced tree, red only on its bottom level when that is not full,
+// so that every path down passes the same number of black nodes
+func newRBTree[E any](sorted []E) *tree.RBNode[E] {
+	n := uint(len(sorted))
+	redDepth := -1
+	if n&(n+1) != 0 {
+		redDepth = bits.Len(n) - 1
+	}
+
+	return newRBTreeFrom(sorted, 0, redDepth)
+}
+
+func newRBTreeFrom[E any](sorted []E, depth, redDepth int) *tree.RBNode[E] {
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	mid := len(sorted) / 2
+	node := tree.NewRBNode(sorted[mid])
+	node.Red = depth == redDepth
+	node.Left = newRBTreeFrom(sorted[:mid], depth+1, redDepth)
+	node.Right = newRBTreeFrom(sorted[mid+1:], depth+1, redDepth)
+
+	return &node
+}
+
+// combineWith merges with a tree set other in O(m+n) and builds the result bottom-up, and looks up the elements of any other set
+func (t *RBSet[E]) combineWith(other Set[E], keep func(inA, inB bool) bool) (result *RBSet[E]) {
+	contract.RequireInvariant(t.IsRBSet, "red-black invariant holds")
+	contract.Require(other != nil, "other is not nil")
+	defer func() {
+		contract.EnsureInvariant(result.IsRBSet, "red-black invariant holds")
+	}()
+
+	result = NewRBSetBy[E](t.ordering)
+	if !mergeable(other, t.ordering) {
+		combine[E](result, t, other, keep)
+		return
+	}
+
+	elements := mergeSorted[E](t.Iterator(), other.Iterator(), t.comp, keep)
+	result.tree.Root = newRBTree(elements)
+	result.size = len(elements)
+	return
+}
+
+func (t *RBSet[E]) noneKeptWith(other Set[E], keep func(inA, inB bool) bool) bool {
+	contract.RequireInvariant(t.IsRBSet, "red-black invariant holds")
+	contract.Require(other != nil, "other is not nil")
+
+	if mergeable(other, t.ordering) {
+		return noneKeptSorted[E](t.Iterator(), other.Iterator(), t.comp, keep)
+	}
+	return noneKept[E](t, other, keep)
+}
+
+func (t *RBSet[E]) Union(other Set[E]) Set[E] {
+	return t.combineWith(other, inUnion)
+}
+
+func (t *RBSet[E]) Intersection(other Set[E]) Set[E] {
+	return t.combineWith(other, inIntersection)
+}
+
+func (t *RBSet[E]) Difference(other Set[E]) Set[E] {
+	return t.combineWith(other, inDifference)
+}
+
+func (t *RBSet[E]) SymmetricDifference(other Set[E]) Set[E] {
+	return t.combineWith(other, inSymmetricDifference)
+}
+
+func (t *RBSet[E]) IsSubsetOf(other Set[E]) bool {
+	return t.noneKeptWith(other, inDifference)
+}
+
+func (t *RBSet[E]) IsDisjoint(other Set[E]) bool {
+	return t.noneKeptWith(other, inIntersection)
+}
+
+func (t *RBSet[E]) Equals(other Set[E]) bool {
+	return t.noneKeptWith(other, inSymmetricDifference)
+}
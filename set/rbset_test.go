@@ -0,0 +1,162 @@
+package set
+
+import (
+	"github.com/song-flying/GoDataStructures/array"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/song-flying/GoDataStructures/tree"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestRBSet(t *testing.T) {
+	set := NewRBSet[int](func(m, n int) int { return m - n })
+
+	assert.False(t, set.Contains(1))
+	assert.Equal(t, 0, set.Size())
+
+	set.Add(1)
+	assert.True(t, set.Contains(1))
+	assert.Equal(t, 1, set.Size())
+
+	set.Add(2)
+	assert.True(t, set.Contains(2))
+	assert.Equal(t, 2, set.Size())
+
+	set.Add(1)
+	assert.True(t, set.Contains(1))
+	assert.Equal(t, 2, set.Size())
+
+	set.Delete(3)
+	assert.Equal(t, 2, set.Size())
+
+	set.Delete(2)
+	assert.False(t, set.Contains(2))
+	assert.Equal(t, 1, set.Size())
+
+	set.Delete(1)
+	assert.Equal(t, 0, set.Size())
+
+	// Test repeated insertion and removal
+	a := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+	array.Shuffle(a)
+	t.Logf("elements to insert = %v", a)
+	for i, e := range a {
+		set.Add(e)
+		t.Logf("tree after insertion of element %d = %s", e, set.tree.String())
+		assert.True(t, set.Contains(e))
+		assert.Equal(t, i+1, set.Size())
+	}
+
+	array.Shuffle(a)
+	t.Logf("elements to delete = %v", a)
+	for i, e := range a {
+		set.Delete(e)
+		t.Logf("tree after deletion of element %d = %s", e, set.tree.String())
+		assert.False(t, set.Contains(e))
+		assert.Equal(t, len(a)-i-1, set.Size())
+	}
+}
+
+func TestRBSet_Random(t *testing.T) {
+	set := NewRBSet[int](order.IntComp)
+	testRandomUpdates(t, set, 23, 50, 300, set.IsRBSet)
+}
+
+func TestRBSet_IsRBTree(t *testing.T) {
+	set := NewRBSet[int](order.IntComp)
+	for x := 1; x <= 7; x++ {
+		set.Add(x)
+	}
+	// 2 with 1 and a red 4, which has 3 and 6, which has a red 5 and 7
+	four, six := set.tree.Root.Right, set.tree.Root.Right.Right
+	assert.True(t, four.IsRed() && six.Left.IsRed() && six.Right.IsRed())
+	flipFour := func() { four.Red = !four.Red }
+	flipSix := func() { six.Red, six.Left.Red, six.Right.Red = !six.Red, !six.Left.Red, !six.Right.Red }
+	testInvariantBreaks(t, func() bool { return set.IsRBTree(set.tree.Root) }, []invariantBreak{
+		{"root is black", func() { set.tree.Root.Red = true }, func() { set.tree.Root.Red = false }},
+		{"black height differs", flipFour, flipFour},
+		{"no two red nodes in a row", flipSix, flipSix},
+	})
+}
+
+func TestRBSet_Height(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+	set := NewRBSet[int](order.IntComp)
+	for x := 0; x < 1<<12; x++ {
+		set.Add(x)
+	}
+
+	var height func(node *tree.RBNode[int]) int
+	height = func(node *tree.RBNode[int]) int {
+		if node == nil {
+			return 0
+		}
+		return order.Max(height(node.Left), height(node.Right)) + 1
+	}
+	assert.LessOrEqual(t, height(set.tree.Root), 2*12)
+	blackHeight, _ := set.blackHeight(set.tree.Root)
+	assert.LessOrEqual(t, blackHeight, 12)
+	assert.True(t, set.IsRBSet())
+}
+
+func TestRBSet_Rotations(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+	set := NewRBSet[int](order.IntComp)
+	keys := rand.New(rand.NewSource(23)).Perm(1 << 10)
+	for _, x := range keys {
+		before := set.rotations
+		set.Add(x)
+		assert.LessOrEqual(t, set.rotations-before, 2)
+	}
+	assert.True(t, set.IsRBSet())
+	for _, x := range keys {
+		before := set.rotations
+		set.Delete(x)
+		assert.LessOrEqual(t, set.rotations-before, 3)
+	}
+}
+
+func TestRBSet_CombineBuildsRBTree(t *testing.T) {
+	defer contract.SetLevel(contract.SetLevel(contract.LevelPre))
+	for n := 0; n < 70; n++ {
+		a, b := NewRBSet[int](order.IntComp), NewRBSet[int](order.IntComp)
+		for x := 0; x < n; x++ {
+			a.Add(2 * x)
+			b.Add(2*x + 1)
+		}
+		union := a.Union(b).(*RBSet[int])
+		assert.True(t, union.IsRBSet(), "n = %d", n)
+		assert.Equal(t, 2*n, union.Size())
+
+		union.Add(-1)
+		union.Delete(0)
+		assert.True(t, union.IsRBSet(), "n = %d", n)
+	}
+}
+
+func TestRBSet_Range(t *testing.T) {
+	set := NewRBSet[int](order.IntComp)
+	for _, x := range []int{5, 1, 9, 3, 7} {
+		set.Add(x)
+	}
+
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, iterator.Collect[int](set.Iterator()))
+	assert.Equal(t, []int{9, 7, 5, 3, 1}, iterator.Collect[int](set.Descending()))
+	assert.Equal(t, []int{3, 5}, iterator.Collect[int](set.Range(2, 7)))
+
+	it := set.Iterator()
+	for it.HasNext() {
+		if it.Next()%3 == 0 {
+			it.Remove()
+		}
+	}
+	assert.Equal(t, []int{1, 5, 7}, iterator.Collect[int](set.Iterator()))
+}
+
+func BenchmarkRBSet(b *testing.B) {
+	benchmarkTreeSet(b, func() Set[int] { return NewRBSet[int](order.IntComp) })
+}
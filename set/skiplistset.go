@@ -0,0 +1,410 @@
+package set
+
+import (
+	"github.com/song-flying/GoDataStructures/linked"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"math/rand"
+	"time"
+)
+
+// SkipListSet keeps its elements in ascending order on level 0 of a skip list, a node is on level i with probability 2^-i,
+// which makes lookups and updates take O(log n) expected time
+type SkipListSet[E comparable] struct {
+	head     *linked.SkipNode[E]
+	level    int // levels in use, head.Next[i] is nil from level on
+	comp     order.CompareFn[E]
+	ordering order.Ordering[E]
+	size     int
+	modCount int
+	seed     int64
+	random   *rand.Rand
+}
+
+func (t *SkipListSet[E]) isOrdered() bool {
+	for curr := t.head.Next[0]; curr != nil && curr.Next[0] != nil; curr = curr.Next[0] {
+		if t.comp(curr.Data, curr.Next[0].Data) >= 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *SkipListSet[E]) isLevelOK() bool {
+	if t.level < 1 || t.level > linked.MaxSkipLevel || t.level > 1 && t.head.Next[t.level-1] == nil {
+		return false
+	}
+	for i := t.level; i < t.head.Level(); i++ {
+		if t.head.Next[i] != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *SkipListSet[E]) sizeOK() bool {
+	size := 0
+	for curr := t.head.Next[0]; curr != nil; curr = curr.Next[0] {
+		size++
+	}
+
+	return t.size == size
+}
+
+// IsSkipListSet data structure invariant
+func (t *SkipListSet[E]) IsSkipListSet() bool {
+	return t != nil && t.comp != nil && t.random != nil && t.head.IsSkipList() && t.head.Level() == linked.MaxSkipLevel &&
+		t.isLevelOK() && t.isOrdered() && t.sizeOK()
+}
+
+// NewSkipListSet picks the levels of nodes with a random seed, SetSeed makes them deterministic
+func NewSkipListSet[E comparable](comp order.CompareFn[E]) *SkipListSet[E] {
+	contract.Require(comp != nil, "comparison function is not nil")
+
+	return NewSkipListSetBy(order.OrderingOf(comp))
+}
+
+// NewSkipListSetBy makes a set ordered by ordering, whose elements merge with those of other sets made by it
+func NewSkipListSetBy[E comparable](ordering order.Ordering[E]) (result *SkipListSet[E]) {
+	contract.Require(ordering.Compare != nil, "comparison function is not nil")
+	defer func() {
+		contract.EnsureInvariant(result.IsSkipListSet, "skip list invariant holds")
+	}()
+
+	seed := time.Now().UnixNano()
+	return &SkipListSet[E]{
+		head:     linked.NewSkipHead[E](),
+		level:    1,
+		comp:     ordering.Compare,
+		ordering: ordering,
+		seed:     seed,
+		random:   rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Seed returns the seed the levels of nodes are drawn from
+func (t *SkipListSet[E]) Seed() int64 {
+	return t.seed
+}
+
+// SetSeed restarts drawing the levels of nodes added from now on from seed
+func (t *SkipListSet[E]) SetSeed(seed int64) {
+	t.seed = seed
+	t.random = rand.New(rand.NewSource(seed))
+}
+
+// find returns the first node not less than element, or nil
+func (t *SkipListSet[E]) find(element E, update []*linked.SkipNode[E]) *linked.SkipNode[E] {
+	return t.head.LastBefore(t.level, below(t.comp, element), update).Next[0]
+}
+
+func (t *SkipListSet[E]) Contains(element E) bool {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+
+	node := t.find(element, nil)
+	return node != nil && t.comp(node.Data, element) == 0
+}
+
+// link adds a node of element after the last nodes before it on each level, raising the levels in use if needed
+func (t *SkipListSet[E]) link(element E, update []*linked.SkipNode[E]) {
+	node := linked.NewSkipNode(element, linked.RandomSkipLevel(t.random))
+	for ; t.level < node.Level(); t.level++ {
+		update[t.level] = t.head
+	}
+	for i := 0; i < node.Level(); i++ {
+		node.Next[i] = update[i].Next[i]
+		update[i].Next[i] = node
+	}
+
+	t.size++
+	t.modCount++
+}
+
+func (t *SkipListSet[E]) Add(element E) {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsSkipListSet, "skip list invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(t.Contains(element), "Contains(element) returns true")
+		}
+	}()
+
+	update := make([]*linked.SkipNode[E], linked.MaxSkipLevel)
+	if node := t.find(element, update); node != nil && t.comp(node.Data, element) == 0 {
+		node.Data = element
+		return
+	}
+	t.link(element, update)
+}
+
+func (t *SkipListSet[E]) Delete(element E) {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+	defer func() {
+		contract.EnsureInvariant(t.IsSkipListSet, "skip list invariant holds")
+		if contract.Enabled(contract.LevelPost) {
+			contract.Ensure(!t.Contains(element), "Contains(element) returns false")
+		}
+	}()
+
+	update := make([]*linked.SkipNode[E], linked.MaxSkipLevel)
+	node := t.find(element, update)
+	if node == nil || t.comp(node.Data, element) != 0 {
+		return
+	}
+
+	for i := 0; i < node.Level(); i++ {
+		update[i].Next[i] = node.Next[i]
+	}
+	for t.level > 1 && t.head.Next[t.level-1] == nil {
+		t.level--
+	}
+	t.size--
+	t.modCount++
+}
+
+func (t *SkipListSet[E]) Size() (result int) {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+	defer func() {
+		contract.Ensure(0 <= result, "result is non-negative")
+	}()
+
+	return t.size
+}
+
+func (t *SkipListSet[E]) IsEmpty() bool {
+	return t.Size() == 0
+}
+
+// dataOf returns the element of node, ok is false for nil or the head
+func (t *SkipListSet[E]) dataOf(node *linked.SkipNode[E]) (result E, ok bool) {
+	if node == nil || node == t.head {
+		return
+	}
+
+	return node.Data, true
+}
+
+// Min returns the least element, ok is false when the set is empty
+func (t *SkipListSet[E]) Min() (E, bool) {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+
+	return t.dataOf(t.head.Next[0])
+}
+
+// Max returns the greatest element, ok is false when the set is empty
+func (t *SkipListSet[E]) Max() (E, bool) {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+
+	return t.dataOf(t.head.LastBefore(t.level, func(E) bool { return true }, nil))
+}
+
+// Floor returns the greatest element not greater than x
+func (t *SkipListSet[E]) Floor(x E) (result E, ok bool) {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.comp(result, x) <= 0, "result <= x")
+	}()
+
+	return t.dataOf(t.head.LastBefore(t.level, atMost(t.comp, x), nil))
+}
+
+// Ceiling returns the least element not less than x
+func (t *SkipListSet[E]) Ceiling(x E) (result E, ok bool) {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.comp(result, x) >= 0, "result >= x")
+	}()
+
+	return t.dataOf(t.find(x, nil))
+}
+
+// Lower returns the greatest element less than x
+func (t *SkipListSet[E]) Lower(x E) (result E, ok bool) {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.comp(result, x) < 0, "result < x")
+	}()
+
+	return t.dataOf(t.head.LastBefore(t.level, below(t.comp, x), nil))
+}
+
+// Higher returns the least element greater than x
+func (t *SkipListSet[E]) Higher(x E) (result E, ok bool) {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+	defer func() {
+		contract.Ensure(!ok || t.comp(result, x) > 0, "result > x")
+	}()
+
+	return t.dataOf(t.head.LastBefore(t.level, atMost(t.comp, x), nil).Next[0])
+}
+
+func (t *SkipListSet[E]) compare(a, b E) int {
+	return t.comp(a, b)
+}
+
+// Ordering returns the ordering of t, sets made by it merge their elements with those of t
+func (t *SkipListSet[E]) Ordering() order.Ordering[E] {
+	return t.ordering
+}
+
+func (t *SkipListSet[E]) mods() int {
+	return t.modCount
+}
+
+// skipListSetIterator walks level 0 from next up to the first element beforeEnd rejects
+type skipListSetIterator[E comparable] struct {
+	set       *SkipListSet[E]
+	next      *linked.SkipNode[E]
+	beforeEnd func(E) bool
+	last      E
+	canRemove bool
+	modCount  int
+}
+
+func newSkipListSetIterator[E comparable](set *SkipListSet[E], start *linked.SkipNode[E], beforeEnd func(E) bool) *skipListSetIterator[E] {
+	return &skipListSetIterator[E]{
+		set:       set,
+		next:      start,
+		beforeEnd: beforeEnd,
+		modCount:  set.modCount,
+	}
+}
+
+func (it *skipListSetIterator[E]) HasNext() bool {
+	iterator.CheckMods(it.set.modCount, it.modCount)
+
+	return it.next != nil && (it.beforeEnd == nil || it.beforeEnd(it.next.Data))
+}
+
+func (it *skipListSetIterator[E]) Next() E {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	it.last = it.next.Data
+	it.next = it.next.Next[0]
+	it.canRemove = true
+
+	return it.last
+}
+
+// Remove deletes the element last returned by Next, which leaves the node after it in place
+func (it *skipListSetIterator[E]) Remove() {
+	iterator.CheckMods(it.set.modCount, it.modCount)
+	contract.Require(it.canRemove, "Next is called after the last Remove")
+
+	it.set.Delete(it.last)
+	it.canRemove = false
+	it.modCount = it.set.modCount
+}
+
+// Iterator walks the elements in ascending order
+func (t *SkipListSet[E]) Iterator() iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+
+	return newSkipListSetIterator[E](t, t.head.Next[0], nil)
+}
+
+// All walks the elements in ascending order
+func (t *SkipListSet[E]) All() iterator.Seq[E] {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+
+	return iterator.FailFastSeq(func(yield func(E) bool) {
+		for curr := t.head.Next[0]; curr != nil; curr = curr.Next[0] {
+			if !yield(curr.Data) {
+				return
+			}
+		}
+	}, t.mods)
+}
+
+// Range walks the elements within [lo, hi) in ascending order
+func (t *SkipListSet[E]) Range(lo, hi E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+	contract.Require(t.comp(lo, hi) <= 0, "lo <= hi")
+
+	return newSkipListSetIterator[E](t, t.find(lo, nil), below(t.comp, hi))
+}
+
+// From walks the elements not less than x in ascending order
+func (t *SkipListSet[E]) From(x E) iterator.MutableIterator[E] {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+
+	return newSkipListSetIterator[E](t, t.find(x, nil), nil)
+}
+
+// appendSorted links the ascending elements, all greater than those of the empty t, in O(n)
+func (t *SkipListSet[E]) appendSorted(elements []E) {
+	contract.Require(t.size == 0, "set is empty")
+
+	last := make([]*linked.SkipNode[E], linked.MaxSkipLevel)
+	for i := range last {
+		last[i] = t.head
+	}
+	for _, element := range elements {
+		t.link(element, last)
+		for i := 0; i < t.level; i++ {
+			if last[i].Next[i] != nil {
+				last[i] = last[i].Next[i]
+			}
+		}
+	}
+}
+
+// combineWith merges with an ordered set other in O(m+n), and looks up the elements of any other set
+func (t *SkipListSet[E]) combineWith(other Set[E], keep func(inA, inB bool) bool) (result *SkipListSet[E]) {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+	contract.Require(other != nil, "other is not nil")
+	defer func() {
+		contract.EnsureInvariant(result.IsSkipListSet, "skip list invariant holds")
+	}()
+
+	result = NewSkipListSetBy[E](t.ordering)
+	result.SetSeed(t.random.Int63())
+	if !mergeable(other, t.ordering) {
+		combine[E](result, t, other, keep)
+		return
+	}
+
+	result.appendSorted(mergeSorted[E](t.Iterator(), other.Iterator(), t.comp, keep))
+	return
+}
+
+func (t *SkipListSet[E]) noneKeptWith(other Set[E], keep func(inA, inB bool) bool) bool {
+	contract.RequireInvariant(t.IsSkipListSet, "skip list invariant holds")
+	contract.Require(other != nil, "other is not nil")
+
+	if mergeable(other, t.ordering) {
+		return noneKeptSorted[E](t.Iterator(), other.Iterator(), t.comp, keep)
+	}
+	return noneKept[E](t, other, keep)
+}
+
+func (t *SkipListSet[E]) Union(other Set[E]) Set[E] {
+	return t.combineWith(other, inUnion)
+}
+
+func (t *SkipListSet[E]) Intersection(other Set[E]) Set[E] {
+	return t.combineWith(other, inIntersection)
+}
+
+func (t *SkipListSet[E]) Difference(other Set[E]) Set[E] {
+	return t.combineWith(other, inDifference)
+}
+
+func (t *SkipListSet[E]) SymmetricDifference(other Set[E]) Set[E] {
+	return t.combineWith(other, inSymmetricDifference)
+}
+
+func (t *SkipListSet[E]) IsSubsetOf(other Set[E]) bool {
+	return t.noneKeptWith(other, inDifference)
+}
+
+func (t *SkipListSet[E]) IsDisjoint(other Set[E]) bool {
+	return t.noneKeptWith(other, inIntersection)
+}
+
+func (t *SkipListSet[E]) Equals(other Set[E]) bool {
+	return t.noneKeptWith(other, inSymmetricDifference)
+}
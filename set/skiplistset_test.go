@@ -0,0 +1,138 @@
+package set
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/pkg/order"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSkipListSet(t *testing.T) {
+	set := NewSkipListSet[int](order.IntComp)
+
+	assert.False(t, set.Contains(1))
+	assert.True(t, set.IsEmpty())
+
+	set.Add(2)
+	set.Add(1)
+	set.Add(3)
+	set.Add(1)
+	assert.True(t, set.Contains(1))
+	assert.Equal(t, 3, set.Size())
+	assert.Equal(t, []int{1, 2, 3}, iterator.Collect[int](set.Iterator()))
+
+	set.Delete(4)
+	set.Delete(2)
+	assert.False(t, set.Contains(2))
+	assert.Equal(t, []int{1, 3}, iterator.Collect[int](set.Iterator()))
+
+	set.Delete(1)
+	set.Delete(3)
+	assert.True(t, set.IsEmpty())
+	assert.Equal(t, 1, set.level)
+	assert.True(t, set.IsSkipListSet())
+}
+
+func TestSkipListSet_Random(t *testing.T) {
+	set := NewSkipListSet[int](order.IntComp)
+	set.SetSeed(25)
+	testRandomUpdates(t, set, 25, 60, 300, set.IsSkipListSet)
+}
+
+func TestSkipListSet_Seed(t *testing.T) {
+	levels := func(seed int64) (result []int) {
+		set := NewSkipListSet[int](order.IntComp)
+		set.SetSeed(seed)
+		for x := 0; x < 32; x++ {
+			set.Add(x)
+		}
+		assert.Equal(t, seed, set.Seed())
+		for curr := set.head.Next[0]; curr != nil; curr = curr.Next[0] {
+			result = append(result, curr.Level())
+		}
+		return
+	}
+
+	assert.Equal(t, levels(3), levels(3))
+	assert.NotEqual(t, levels(3), levels(4))
+}
+
+func TestSkipListSet_Navigable(t *testing.T) {
+	set := NewSkipListSet[int](order.IntComp)
+	_, ok := set.Min()
+	assert.False(t, ok)
+	_, ok = set.Floor(5)
+	assert.False(t, ok)
+
+	for x := 0; x < 20; x += 2 {
+		set.Add(x)
+	}
+
+	found := func(x int, ok bool) int {
+		assert.True(t, ok)
+		return x
+	}
+	assert.Equal(t, 0, found(set.Min()))
+	assert.Equal(t, 18, found(set.Max()))
+	assert.Equal(t, 6, found(set.Floor(7)))
+	assert.Equal(t, 6, found(set.Floor(6)))
+	assert.Equal(t, 8, found(set.Ceiling(7)))
+	assert.Equal(t, 6, found(set.Ceiling(6)))
+	assert.Equal(t, 4, found(set.Lower(6)))
+	assert.Equal(t, 8, found(set.Higher(6)))
+	_, ok = set.Floor(-1)
+	assert.False(t, ok)
+	_, ok = set.Lower(0)
+	assert.False(t, ok)
+	_, ok = set.Ceiling(19)
+	assert.False(t, ok)
+	_, ok = set.Higher(18)
+	assert.False(t, ok)
+}
+
+func TestSkipListSet_Range(t *testing.T) {
+	set := NewSkipListSet[int](order.IntComp)
+	for x := 0; x < 20; x++ {
+		set.Add(x)
+	}
+
+	assert.Equal(t, []int{5, 6, 7}, iterator.Collect[int](set.Range(5, 8)))
+	assert.Empty(t, iterator.Collect[int](set.Range(5, 5)))
+	assert.Equal(t, []int{17, 18, 19}, iterator.Collect[int](set.From(17)))
+	assert.Empty(t, iterator.Collect[int](set.From(20)))
+
+	for it := set.Range(2, 18); it.HasNext(); {
+		if it.Next()%4 != 0 {
+			it.Remove()
+		}
+	}
+	assert.Equal(t, []int{0, 1, 4, 8, 12, 16, 18, 19}, iterator.Collect[int](set.Iterator()))
+
+	var all []int
+	set.All()(func(x int) bool {
+		all = append(all, x)
+		return x < 8
+	})
+	assert.Equal(t, []int{0, 1, 4, 8}, all)
+
+	it := set.Iterator()
+	set.Add(100)
+	assert.Panics(t, func() { it.HasNext() })
+}
+
+func TestSkipListSet_IsSkipListSet(t *testing.T) {
+	set := NewSkipListSet[int](order.IntComp)
+	for x := 0; x < 8; x++ {
+		set.Add(x)
+	}
+	first := set.head.Next[0]
+	testInvariantBreaks(t, set.IsSkipListSet, []invariantBreak{
+		{"elements are unsorted", func() { first.Data = 100 }, func() { first.Data = 0 }},
+		{"size is off", func() { set.size++ }, func() { set.size-- }},
+		{"level is off", func() { set.level++ }, func() { set.level-- }},
+	})
+}
+
+func BenchmarkSkipListSet(b *testing.B) {
+	benchmarkTreeSet(b, func() Set[int] { return NewSkipListSet[int](order.IntComp) })
+}
@@ -0,0 +1,71 @@
+package set
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/tree"
+)
+
+// treeSet is what treeSetIterator needs from AVLSet, BSTSet and RBSet
+type treeSet[E comparable] interface {
+	walk(descending bool, afterStart, beforeEnd func(E) bool) *tree.OrderedIterator[E]
+	compare(a, b E) int
+	mods() int
+	Delete(x E)
+}
+
+// treeSetIterator makes the ordered walks of AVLSet, BSTSet and RBSet fail-fast and able to remove elements
+type treeSetIterator[E comparable] struct {
+	set        treeSet[E]
+	descending bool
+	beforeEnd  func(E) bool
+	walk       *tree.OrderedIterator[E]
+	last       E
+	canRemove  bool
+	modCount   int
+}
+
+func newTreeSetIterator[E comparable](set treeSet[E], descending bool, afterStart, beforeEnd func(E) bool) *treeSetIterator[E] {
+	return &treeSetIterator[E]{
+		set:        set,
+		descending: descending,
+		beforeEnd:  beforeEnd,
+		walk:       set.walk(descending, afterStart, beforeEnd),
+		modCount:   set.mods(),
+	}
+}
+
+func (it *treeSetIterator[E]) HasNext() bool {
+	iterator.CheckMods(it.set.mods(), it.modCount)
+
+	return it.walk.HasNext()
+}
+
+func (it *treeSetIterator[E]) Next() E {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	it.last = it.walk.Next()
+	it.canRemove = true
+
+	return it.last
+}
+
+// Remove deletes the element last returned by Next,
+// the walk then restarts right after it in O(log n) as deleting may restructure the tree
+func (it *treeSetIterator[E]) Remove() {
+	iterator.CheckMods(it.set.mods(), it.modCount)
+	contract.Require(it.canRemove, "Next is called after the last Remove")
+
+	it.set.Delete(it.last)
+	it.canRemove = false
+	it.modCount = it.set.mods()
+
+	last, descending := it.last, it.descending
+	afterLast := func(e E) bool {
+		if descending {
+			return it.set.compare(e, last) < 0
+		}
+		return it.set.compare(e, last) > 0
+	}
+	it.walk = it.set.walk(descending, afterLast, it.beforeEnd)
+}
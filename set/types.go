@@ -1,9 +1,25 @@
 package set
 
-type Set[T comparable] interface {
+import "github.com/song-flying/GoDataStructures/pkg/iterator"
+
+type Set[T any] interface {
 	Contains(x T) bool
 	Add(x T)
 	Delete(x T)
 	Size() int
 	IsEmpty() bool
+	Iterator() iterator.MutableIterator[T]
+	All() iterator.Seq[T]
+
+	// Union, Intersection, Difference and SymmetricDifference return a new set of the kind of the receiver,
+	// keeping the element of the receiver where both hold equal ones. Two tree or skip list sets are merged in O(m+n)
+	// when they share their Ordering, like sets made by the same built-in comparator such as order.IntComp
+	// or by the same order.Ordering, the elements of other sets are looked up one by one.
+	Union(other Set[T]) Set[T]
+	Intersection(other Set[T]) Set[T]
+	Difference(other Set[T]) Set[T]
+	SymmetricDifference(other Set[T]) Set[T]
+	IsSubsetOf(other Set[T]) bool
+	IsDisjoint(other Set[T]) bool
+	Equals(other Set[T]) bool
 }
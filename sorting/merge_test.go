@@ -15,3 +15,13 @@ func TestMergeSort(t *testing.T) {
 	MergeSort(a, order.IntComp)
 	assert.Equal(t, []int{42}, a)
 }
+
+func TestMergeSort_ComposedOrder(t *testing.T) {
+	words := []string{"banana", "Cherry", "apple", "Apple", "cherry"}
+	MergeSort(words, order.ThenComparing(order.CaseInsensitiveComp, order.StringComp))
+	assert.Equal(t, []string{"Apple", "apple", "banana", "Cherry", "cherry"}, words)
+
+	MergeSort(words, order.Reverse(order.ByKey(func(s string) int { return len(s) }, order.IntComp)))
+	assert.Equal(t, 6, len(words[0]))
+	assert.Equal(t, 5, len(words[len(words)-1]))
+}
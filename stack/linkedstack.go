@@ -3,11 +3,13 @@ package stack
 import (
 	"github.com/song-flying/GoDataStructures/linked"
 	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
 )
 
-type LinkedStack[T comparable] struct {
-	top    *linked.Node[T]
-	bottom *linked.Node[T]
+type LinkedStack[T any] struct {
+	top      *linked.Node[T]
+	bottom   *linked.Node[T]
+	modCount int
 }
 
 // IsLinkedStack data structure invariant
@@ -15,9 +17,9 @@ func (s *LinkedStack[T]) IsLinkedStack() bool {
 	return s != nil && !linked.HasCycle(s.top) && linked.IsSegment(s.top, s.bottom)
 }
 
-func NewLinkedStack[T comparable]() (result *LinkedStack[T]) {
+func NewLinkedStack[T any]() (result *LinkedStack[T]) {
 	defer func() {
-		contract.Ensure(result.IsLinkedStack(), "stack invariant holds")
+		contract.EnsureInvariant(result.IsLinkedStack, "stack invariant holds")
 		contract.Ensure(result.IsEmpty(), "new stack is empty")
 	}()
 
@@ -33,32 +35,103 @@ func (s *LinkedStack[T]) IsEmpty() bool {
 }
 
 func (s *LinkedStack[T]) Push(x T) {
-	contract.Require(s.IsLinkedStack(), "stack invariant holds")
+	contract.RequireInvariant(s.IsLinkedStack, "stack invariant holds")
 	defer func() {
-		contract.Ensure(s.IsLinkedStack(), "stack invariant holds")
+		contract.EnsureInvariant(s.IsLinkedStack, "stack invariant holds")
 	}()
 
 	l := linked.NewNode(x)
 	l.Next = s.top
 	s.top = &l
+	s.modCount++
 }
 
 func (s *LinkedStack[T]) Pop() (result T) {
-	contract.Require(s.IsLinkedStack(), "stack invariant holds")
+	contract.RequireInvariant(s.IsLinkedStack, "stack invariant holds")
 	contract.Require(!s.IsEmpty(), "stack is not empty")
 	defer func() {
-		contract.Ensure(s.IsLinkedStack(), "stack invariant holds")
+		contract.EnsureInvariant(s.IsLinkedStack, "stack invariant holds")
 	}()
 
 	result = s.top.Data
 	s.top = s.top.Next
+	s.modCount++
 
 	return
 }
 
 func (s *LinkedStack[T]) Peek() T {
-	contract.Require(s.IsLinkedStack(), "stack invariant holds")
+	contract.RequireInvariant(s.IsLinkedStack, "stack invariant holds")
 	contract.Require(!s.IsEmpty(), "stack is not empty")
 
 	return s.top.Data
 }
+
+// linkedStackIterator walks the elements from top to bottom, it fails fast when the stack is modified other than by Remove
+type linkedStackIterator[T any] struct {
+	s        *LinkedStack[T]
+	prev     *linked.Node[T]
+	last     *linked.Node[T]
+	curr     *linked.Node[T]
+	modCount int
+}
+
+func (it *linkedStackIterator[T]) HasNext() bool {
+	iterator.CheckMods(it.s.modCount, it.modCount)
+
+	return it.curr != it.s.bottom
+}
+
+func (it *linkedStackIterator[T]) Next() (result T) {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	if it.last != nil {
+		it.prev = it.last
+	}
+	it.last = it.curr
+	it.curr = it.curr.Next
+
+	return it.last.Data
+}
+
+// Remove unlinks the node last returned by Next
+func (it *linkedStackIterator[T]) Remove() {
+	iterator.CheckMods(it.s.modCount, it.modCount)
+	contract.Require(it.last != nil, "Next is called after the last Remove")
+	defer func() {
+		contract.EnsureInvariant(it.s.IsLinkedStack, "stack invariant holds")
+	}()
+
+	if it.prev == nil {
+		it.s.top = it.curr
+	} else {
+		it.prev.Next = it.curr
+	}
+	it.last.Next = nil
+	it.last = nil
+
+	it.s.modCount++
+	it.modCount = it.s.modCount
+}
+
+func (s *LinkedStack[T]) mods() int {
+	return s.modCount
+}
+
+// Iterator walks the elements from top to bottom
+func (s *LinkedStack[T]) Iterator() iterator.MutableIterator[T] {
+	contract.RequireInvariant(s.IsLinkedStack, "stack invariant holds")
+
+	return &linkedStackIterator[T]{
+		s:        s,
+		curr:     s.top,
+		modCount: s.modCount,
+	}
+}
+
+// All walks the elements from top to bottom
+func (s *LinkedStack[T]) All() iterator.Seq[T] {
+	contract.RequireInvariant(s.IsLinkedStack, "stack invariant holds")
+
+	return iterator.FailFastSeq(linked.AllSegment(s.top, s.bottom), s.mods)
+}
@@ -1,6 +1,7 @@
 package stack
 
 import (
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -25,3 +26,54 @@ func TestLinkedStack(t *testing.T) {
 	assert.Equal(t, 1, v)
 	assert.True(t, s.IsEmpty())
 }
+
+func TestLinkedStack_Iterator(t *testing.T) {
+	var s Stack[int] = NewLinkedStack[int]()
+	assert.False(t, s.Iterator().HasNext())
+
+	s.Push(1)
+	s.Push(2)
+	s.Push(3)
+
+	var a []int
+	for it := s.Iterator(); it.HasNext(); {
+		a = append(a, it.Next())
+	}
+	assert.Equal(t, []int{3, 2, 1}, a)
+
+	var b []int
+	s.All()(func(x int) bool {
+		b = append(b, x)
+		return true
+	})
+	assert.Equal(t, a, b)
+}
+
+func TestLinkedStack_IteratorRemove(t *testing.T) {
+	s := NewLinkedStack[int]()
+	for i := 1; i <= 6; i++ {
+		s.Push(i)
+	}
+
+	for it := s.Iterator(); it.HasNext(); {
+		if it.Next()%2 == 0 {
+			it.Remove()
+		}
+	}
+	assert.Equal(t, []int{5, 3, 1}, iterator.Collect[int](s.Iterator()))
+
+	it := s.Iterator()
+	assert.Panics(t, func() { it.Remove() })
+	it.Next()
+	it.Remove()
+	assert.Equal(t, 3, s.Peek())
+
+	s.Push(7)
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() { it.Next() })
+	assert.PanicsWithError(t, iterator.ModifiedMsg, func() {
+		s.All()(func(int) bool {
+			s.Pop()
+			return true
+		})
+	})
+}
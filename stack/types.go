@@ -1,8 +1,12 @@
 package stack
 
+import "github.com/song-flying/GoDataStructures/pkg/iterator"
+
 type Stack[T any] interface {
 	IsEmpty() bool
 	Push(x T)
 	Pop() T
 	Peek() T
+	Iterator() iterator.MutableIterator[T]
+	All() iterator.Seq[T]
 }
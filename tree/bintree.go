@@ -15,22 +15,23 @@ const (
 	visited       = 3
 )
 
-type BinaryNode[T comparable] struct {
+type BinaryNode[T any] struct {
 	id     int
 	state  int
 	Data   T
 	Left   *BinaryNode[T] `json:",omitempty"`
 	Right  *BinaryNode[T] `json:",omitempty"`
 	Height int            `json:",omitempty"`
+	Size   int            `json:",omitempty"`
 }
 
-func NewBinaryNode[T comparable](data T) BinaryNode[T] {
+func NewBinaryNode[T any](data T) BinaryNode[T] {
 	return BinaryNode[T]{
 		Data: data,
 	}
 }
 
-func Nil[T comparable]() *BinaryNode[T] {
+func Nil[T any]() *BinaryNode[T] {
 	return nil
 }
 
@@ -50,13 +51,67 @@ func (n *BinaryNode[T]) SetHeight() {
 	n.Height = order.Max(n.Left.GetHeight(), n.Right.GetHeight()) + 1
 }
 
+func (n *BinaryNode[T]) GetSize() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.Size
+}
+
+// SetSize recomputes the number of nodes in the subtree rooted at n from the sizes of its children
+func (n *BinaryNode[T]) SetSize() {
+	if n == nil {
+		return
+	}
+
+	n.Size = n.Left.GetSize() + n.Right.GetSize() + 1
+}
+
+func (n *BinaryNode[T]) GetData() T {
+	return n.Data
+}
+
+func (n *BinaryNode[T]) GetLeft() *BinaryNode[T] {
+	if n == nil {
+		return nil
+	}
+
+	return n.Left
+}
+
+func (n *BinaryNode[T]) GetRight() *BinaryNode[T] {
+	if n == nil {
+		return nil
+	}
+
+	return n.Right
+}
+
+// NewBalanced links the sorted data into a tree of minimal height in O(n),
+// with Height and Size set so that it is an AVL tree as well
+func NewBalanced[T any](sorted []T) *BinaryNode[T] {
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	mid := len(sorted) / 2
+	node := NewBinaryNode(sorted[mid])
+	node.Left = NewBalanced(sorted[:mid])
+	node.Right = NewBalanced(sorted[mid+1:])
+	node.SetHeight()
+	node.SetSize()
+
+	return &node
+}
+
 // IsBinaryTree data structure invariant
 func (n *BinaryNode[T]) IsBinaryTree() bool {
 	return !hasCycle(n)
 }
 
 func (n *BinaryNode[T]) ToArrayPreorder() (result []T) {
-	contract.Require(n.IsBinaryTree(), "n is valid binary tree")
+	contract.RequireInvariant(n.IsBinaryTree, "n is valid binary tree")
 
 	var nodesVisited []*BinaryNode[T]
 	defer func() {
@@ -105,7 +160,7 @@ func (n *BinaryNode[T]) ToArrayPreorder() (result []T) {
 }
 
 func (n *BinaryNode[T]) ToArrayInorder() (result []T) {
-	contract.Require(n.IsBinaryTree(), "n is valid binary tree")
+	contract.RequireInvariant(n.IsBinaryTree, "n is valid binary tree")
 
 	var nodesVisited []*BinaryNode[T]
 	defer func() {
@@ -144,7 +199,7 @@ func (n *BinaryNode[T]) ToArrayInorder() (result []T) {
 }
 
 func (n *BinaryNode[T]) ToArrayPostorder() (result []T) {
-	contract.Require(n.IsBinaryTree(), "n is valid binary tree")
+	contract.RequireInvariant(n.IsBinaryTree, "n is valid binary tree")
 
 	var nodesVisited []*BinaryNode[T]
 	defer func() {
@@ -201,7 +256,7 @@ func (n *BinaryNode[T]) ToArrayPostorder() (result []T) {
 	return
 }
 
-type BinaryTree[T comparable] struct {
+type BinaryTree[T any] struct {
 	Root *BinaryNode[T]
 }
 
@@ -210,9 +265,9 @@ func (t *BinaryTree[T]) IsBinaryTree() bool {
 	return t.Root.IsBinaryTree()
 }
 
-func NewBinaryTree[T comparable](root *BinaryNode[T]) (result *BinaryTree[T]) {
+func NewBinaryTree[T any](root *BinaryNode[T]) (result *BinaryTree[T]) {
 	defer func() {
-		contract.Ensure(result.IsBinaryTree(), "binary tree invariant holds")
+		contract.EnsureInvariant(result.IsBinaryTree, "binary tree invariant holds")
 	}()
 
 	return &BinaryTree[T]{
@@ -28,3 +28,33 @@ func TestBinaryNode_ToArrayOrder(t *testing.T) {
 	assert.Equal(t, []int{1, 2, 4, 5, 3, 6, 7}, root.ToArrayInorder())
 	assert.Equal(t, []int{4, 5, 2, 6, 7, 3, 1}, root.ToArrayPostorder())
 }
+
+func TestBinaryTree_Iterator(t *testing.T) {
+	binTree := NewBinaryTree(&BinaryNode[int]{
+		Data: 4,
+		Left: &BinaryNode[int]{
+			Data:  2,
+			Left:  &BinaryNode[int]{Data: 1},
+			Right: &BinaryNode[int]{Data: 3},
+		},
+		Right: &BinaryNode[int]{
+			Data:  6,
+			Right: &BinaryNode[int]{Data: 7},
+		},
+	})
+
+	var fromIterator []int
+	for it := binTree.Iterator(); it.HasNext(); {
+		fromIterator = append(fromIterator, it.Next())
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 6, 7}, fromIterator)
+
+	var fromAll []int
+	binTree.All()(func(x int) bool {
+		fromAll = append(fromAll, x)
+		return x < 4
+	})
+	assert.Equal(t, []int{1, 2, 3, 4}, fromAll)
+
+	assert.False(t, NewBinaryTree(Nil[int]()).Iterator().HasNext())
+}
@@ -0,0 +1,67 @@
+package tree
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/stack"
+)
+
+// InorderIterator lazily walks a binary tree in-order (left, root, right) using O(height) extra space
+type InorderIterator[T any] struct {
+	path *stack.LinkedStack[*BinaryNode[T]]
+}
+
+func NewInorderIterator[T any](root *BinaryNode[T]) *InorderIterator[T] {
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
+
+	it := &InorderIterator[T]{
+		path: stack.NewLinkedStack[*BinaryNode[T]](),
+	}
+	it.pushLeftSpine(root)
+
+	return it
+}
+
+func (it *InorderIterator[T]) pushLeftSpine(node *BinaryNode[T]) {
+	for ; node != nil; node = node.Left {
+		it.path.Push(node)
+	}
+}
+
+func (it *InorderIterator[T]) HasNext() bool {
+	return !it.path.IsEmpty()
+}
+
+func (it *InorderIterator[T]) Next() T {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	node := it.path.Pop()
+	it.pushLeftSpine(node.Right)
+
+	return node.Data
+}
+
+// All walks the tree rooted at n in-order
+func (n *BinaryNode[T]) All() iterator.Seq[T] {
+	contract.RequireInvariant(n.IsBinaryTree, "n is valid binary tree")
+
+	return func(yield func(T) bool) {
+		n.inorder(yield)
+	}
+}
+
+func (n *BinaryNode[T]) inorder(yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	return n.Left.inorder(yield) && yield(n.Data) && n.Right.inorder(yield)
+}
+
+func (t *BinaryTree[T]) Iterator() iterator.Iterator[T] {
+	return NewInorderIterator(t.Root)
+}
+
+func (t *BinaryTree[T]) All() iterator.Seq[T] {
+	return t.Root.All()
+}
@@ -0,0 +1,103 @@
+package tree
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/stack"
+)
+
+// SearchNode is implemented by *BinaryNode and *RBNode, so that walks and searches of binary search trees
+// are written once for both. GetLeft and GetRight of a nil node return nil.
+type SearchNode[T any, N any] interface {
+	comparable
+	GetData() T
+	GetLeft() N
+	GetRight() N
+}
+
+// OrderedIterator lazily walks a binary search tree in ascending or descending order,
+// starting at the first node satisfying afterStart and stopping at the first node not satisfying beforeEnd.
+// Along the walking order, afterStart must be false up to some node and true from there on,
+// beforeEnd must be true up to some node and false from there on, nil stands for always true.
+// Positioning takes O(height) and every step amortized O(1).
+type OrderedIterator[T any] struct {
+	walk iterator.Iterator[T]
+}
+
+func NewOrderedIterator[T any](root *BinaryNode[T], descending bool, afterStart, beforeEnd func(T) bool) *OrderedIterator[T] {
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
+
+	return &OrderedIterator[T]{walk: newOrderedWalk[T](root, descending, afterStart, beforeEnd)}
+}
+
+// NewRBOrderedIterator walks a red-black tree the way NewOrderedIterator walks a tree of BinaryNode
+func NewRBOrderedIterator[T any](root *RBNode[T], descending bool, afterStart, beforeEnd func(T) bool) *OrderedIterator[T] {
+	contract.RequireInvariant(root.IsBinaryTree, "root is binary tree")
+
+	return &OrderedIterator[T]{walk: newOrderedWalk[T](root, descending, afterStart, beforeEnd)}
+}
+
+func (it *OrderedIterator[T]) HasNext() bool {
+	return it.walk.HasNext()
+}
+
+func (it *OrderedIterator[T]) Next() T {
+	contract.Require(it.HasNext(), "iterator has next element")
+
+	return it.walk.Next()
+}
+
+// orderedWalk is the walk of OrderedIterator over either kind of node
+type orderedWalk[T any, N SearchNode[T, N]] struct {
+	path       *stack.LinkedStack[N]
+	descending bool
+	beforeEnd  func(T) bool
+}
+
+func newOrderedWalk[T any, N SearchNode[T, N]](root N, descending bool, afterStart, beforeEnd func(T) bool) *orderedWalk[T, N] {
+	it := &orderedWalk[T, N]{
+		path:       stack.NewLinkedStack[N](),
+		descending: descending,
+		beforeEnd:  beforeEnd,
+	}
+
+	var none N
+	for node := root; node != none; {
+		if afterStart == nil || afterStart(node.GetData()) {
+			it.path.Push(node)
+			node = it.towardsStart(node)
+		} else {
+			node = it.awayFromStart(node)
+		}
+	}
+
+	return it
+}
+
+func (it *orderedWalk[T, N]) towardsStart(node N) N {
+	if it.descending {
+		return node.GetRight()
+	}
+	return node.GetLeft()
+}
+
+func (it *orderedWalk[T, N]) awayFromStart(node N) N {
+	if it.descending {
+		return node.GetLeft()
+	}
+	return node.GetRight()
+}
+
+func (it *orderedWalk[T, N]) HasNext() bool {
+	return !it.path.IsEmpty() && (it.beforeEnd == nil || it.beforeEnd(it.path.Peek().GetData()))
+}
+
+func (it *orderedWalk[T, N]) Next() T {
+	node := it.path.Pop()
+	var none N
+	for next := it.awayFromStart(node); next != none; next = it.towardsStart(next) {
+		it.path.Push(next)
+	}
+
+	return node.GetData()
+}
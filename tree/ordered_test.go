@@ -0,0 +1,26 @@
+package tree
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOrderedIterator(t *testing.T) {
+	//       4
+	//     2   6
+	//    1 3 5 7
+	root := &BinaryNode[int]{Data: 4,
+		Left:  &BinaryNode[int]{Data: 2, Left: &BinaryNode[int]{Data: 1}, Right: &BinaryNode[int]{Data: 3}},
+		Right: &BinaryNode[int]{Data: 6, Left: &BinaryNode[int]{Data: 5}, Right: &BinaryNode[int]{Data: 7}},
+	}
+	atLeast := func(x int) func(int) bool { return func(n int) bool { return n >= x } }
+	atMost := func(x int) func(int) bool { return func(n int) bool { return n <= x } }
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, iterator.Collect[int](NewOrderedIterator(root, false, nil, nil)))
+	assert.Equal(t, []int{7, 6, 5, 4, 3, 2, 1}, iterator.Collect[int](NewOrderedIterator(root, true, nil, nil)))
+	assert.Equal(t, []int{3, 4, 5}, iterator.Collect[int](NewOrderedIterator(root, false, atLeast(3), atMost(5))))
+	assert.Equal(t, []int{5, 4, 3}, iterator.Collect[int](NewOrderedIterator(root, true, atMost(5), atLeast(3))))
+	assert.Empty(t, iterator.Collect[int](NewOrderedIterator(root, false, atLeast(8), nil)))
+	assert.Empty(t, iterator.Collect[int](NewOrderedIterator[int](nil, true, nil, nil)))
+}
@@ -0,0 +1,130 @@
+package tree
+
+import (
+	"encoding/json"
+	"github.com/song-flying/GoDataStructures/pkg/contract"
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/song-flying/GoDataStructures/queue"
+)
+
+// RBNode is a node of a red-black tree, which keeps its colour instead of the height and size BinaryNode keeps
+type RBNode[T any] struct {
+	Data  T
+	Left  *RBNode[T] `json:",omitempty"`
+	Right *RBNode[T] `json:",omitempty"`
+	Red   bool       `json:",omitempty"`
+}
+
+func NewRBNode[T any](data T) RBNode[T] {
+	return RBNode[T]{
+		Data: data,
+	}
+}
+
+// IsRed reports the colour of n, nil leaves are black
+func (n *RBNode[T]) IsRed() bool {
+	return n != nil && n.Red
+}
+
+func (n *RBNode[T]) GetData() T {
+	return n.Data
+}
+
+func (n *RBNode[T]) GetLeft() *RBNode[T] {
+	if n == nil {
+		return nil
+	}
+
+	return n.Left
+}
+
+func (n *RBNode[T]) GetRight() *RBNode[T] {
+	if n == nil {
+		return nil
+	}
+
+	return n.Right
+}
+
+// IsBinaryTree data structure invariant
+func (n *RBNode[T]) IsBinaryTree() bool {
+	return isTree[T](n)
+}
+
+// All walks the tree rooted at n in-order
+func (n *RBNode[T]) All() iterator.Seq[T] {
+	contract.RequireInvariant(n.IsBinaryTree, "n is valid binary tree")
+
+	return func(yield func(T) bool) {
+		n.inorder(yield)
+	}
+}
+
+func (n *RBNode[T]) inorder(yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	return n.Left.inorder(yield) && yield(n.Data) && n.Right.inorder(yield)
+}
+
+type RBTree[T any] struct {
+	Root *RBNode[T]
+}
+
+// IsBinaryTree data structure invariant
+func (t *RBTree[T]) IsBinaryTree() bool {
+	return t.Root.IsBinaryTree()
+}
+
+func NewRBTree[T any](root *RBNode[T]) (result *RBTree[T]) {
+	defer func() {
+		contract.EnsureInvariant(result.IsBinaryTree, "binary tree invariant holds")
+	}()
+
+	return &RBTree[T]{
+		Root: root,
+	}
+}
+
+func (t *RBTree[T]) Iterator() iterator.Iterator[T] {
+	return NewRBOrderedIterator(t.Root, false, nil, nil)
+}
+
+func (t *RBTree[T]) All() iterator.Seq[T] {
+	return t.Root.All()
+}
+
+func (t *RBTree[T]) String() string {
+	treeJson, _ := json.Marshal(t)
+	return string(treeJson)
+}
+
+// isTree reports whether every node below root is reached along a single path,
+// it keeps the nodes seen aside as RBNode has no room to mark them the way hasCycle does
+func isTree[T any, N SearchNode[T, N]](root N) bool {
+	var none N
+	if root == none {
+		return true
+	}
+
+	seen := map[N]bool{root: true}
+	q := queue.NewLinkedQueue[N]()
+	q.Enqueue(root)
+
+	for !q.IsEmpty() {
+		node := q.Dequeue()
+		for _, child := range []N{node.GetLeft(), node.GetRight()} {
+			if child == none {
+				continue
+			}
+			if seen[child] {
+				return false
+			}
+			seen[child] = true
+			q.Enqueue(child)
+		}
+	}
+
+	return true
+}
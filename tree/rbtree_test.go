@@ -0,0 +1,38 @@
+package tree
+
+import (
+	"github.com/song-flying/GoDataStructures/pkg/iterator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRBTree(t *testing.T) {
+	//       4
+	//     2   6
+	//    1 3 5 7
+	root := &RBNode[int]{Data: 4,
+		Left:  &RBNode[int]{Data: 2, Left: &RBNode[int]{Data: 1, Red: true}, Right: &RBNode[int]{Data: 3, Red: true}},
+		Right: &RBNode[int]{Data: 6, Left: &RBNode[int]{Data: 5, Red: true}, Right: &RBNode[int]{Data: 7, Red: true}},
+	}
+	atLeast := func(x int) func(int) bool { return func(n int) bool { return n >= x } }
+	atMost := func(x int) func(int) bool { return func(n int) bool { return n <= x } }
+
+	rbTree := NewRBTree(root)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, iterator.Collect[int](rbTree.Iterator()))
+	var fromAll []int
+	rbTree.All()(func(x int) bool {
+		fromAll = append(fromAll, x)
+		return x < 4
+	})
+	assert.Equal(t, []int{1, 2, 3, 4}, fromAll)
+	assert.Equal(t, []int{5, 4, 3}, iterator.Collect[int](NewRBOrderedIterator(root, true, atMost(5), atLeast(3))))
+	assert.True(t, root.Left.Left.IsRed())
+	assert.False(t, root.IsRed())
+	assert.False(t, (*RBNode[int])(nil).IsRed(), "nil leaves are black")
+
+	assert.True(t, root.IsBinaryTree())
+	root.Right.Right.Left = root.Left
+	assert.False(t, root.IsBinaryTree(), "a shared subtree is caught")
+	root.Right.Right.Left = root
+	assert.False(t, root.IsBinaryTree(), "a cycle is caught")
+}
@@ -2,7 +2,7 @@ package tree
 
 import "github.com/song-flying/GoDataStructures/queue"
 
-func hasCycle[T comparable](root *BinaryNode[T]) bool {
+func hasCycle[T any](root *BinaryNode[T]) bool {
 	var visited []*BinaryNode[T]
 	defer func() {
 		for _, node := range visited {